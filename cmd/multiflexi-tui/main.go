@@ -1,18 +1,77 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
+	"github.com/VitexSoftware/multiflexi-tui/internal/alert"
 	"github.com/VitexSoftware/multiflexi-tui/internal/app"
+	"github.com/VitexSoftware/multiflexi-tui/internal/audit"
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/config"
 	"github.com/VitexSoftware/multiflexi-tui/internal/entity"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
-	client := cli.NewCLIClient()
+	flag.BoolVar(&entity.Layout.Split, "split", false, "show a live detail preview next to entity lists")
+	flag.Float64Var(&entity.Layout.SplitRatio, "split-ratio", entity.Layout.SplitRatio, "fraction of the width used by the list pane in split layout")
+	flag.IntVar(&entity.Layout.MinWidth, "split-min-width", entity.Layout.MinWidth, "terminal width below which the split layout shows a single pane")
+	themeName := flag.String("theme", ui.AutoTheme, "colour theme: auto, turbovision, dark, light, high-contrast or a theme from themes.json")
+	open := flag.String("open", "", "open a record on start, e.g. job:4711 or runtemplate:12/edit")
+	menu := flag.String("menu", "", "open a menu item on start, e.g. Queue")
+	fresh := flag.Bool("fresh", false, "start from the Status dashboard instead of restoring the last session")
+	lang := flag.String("lang", i18n.Detect(), "interface language: en or cs (defaults to $LANG)")
+	flag.DurationVar(&entity.DashboardInterval, "refresh", entity.DashboardInterval, "dashboard auto-refresh interval, 0 to disable")
+	notify := flag.String("notify", "", "announce alerts: off, bell or osc9 (defaults to alerts.json)")
+	auditPath := flag.String("audit-log", config.StatePath("audit.jsonl"), "append creates, updates, deletes and actions to this JSON Lines file, empty to disable")
+	profile := flag.String("profile", os.Getenv("MULTIFLEXI_PROFILE"), "profile name recorded in the audit log (defaults to $MULTIFLEXI_PROFILE)")
+	flag.Parse()
+
+	i18n.SetLanguage(*lang)
+	ui.InitColor()
+	if err := ui.LoadThemes(config.Path("themes.json")); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if err := ui.SetTheme(*themeName); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	// Health rules, evaluated on every dashboard poll.
+	alerts, err := alert.Load(config.Path("alerts.json"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		alerts = &alert.Config{Rules: alert.DefaultRules}
+	}
+	if *notify != "" {
+		alerts.Notify = *notify
+	}
+	if err := alert.CheckNotify(alerts.Notify); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	entity.Alerts = alert.NewMonitor(alerts.Rules)
+	entity.Alerts.Notify = alerts.Notify
+
+	// Audit log of every change made through the client.
+	entity.Audit.Path = *auditPath
+	client := audit.Wrap(cli.NewCLIClient(), entity.Audit, *profile)
+
+	// Session: restored unless --fresh, saved again on exit either way.
+	sessionPath := config.StatePath("session.json")
+	state := session.New()
+	if !*fresh {
+		loaded, err := session.Load(sessionPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		state = loaded
+	}
+	entity.Session = state
+	entity.Drafts = session.Drafts{Dir: config.StatePath("drafts")}
 
 	// Build menu items: Status (home) + all registered entities + Help + Quit
 	items := []app.MenuItem{
@@ -20,7 +79,14 @@ func main() {
 			Label: "Status",
 			Hint:  "View system dashboard with status information",
 			Action: func(a *app.App) (tea.Model, tea.Cmd) {
-				return nil, nil // nil view = show status dashboard
+				return nil, nil // nil view = show the home dashboard
+			},
+		},
+		{
+			Label: "Statistics",
+			Hint:  "Job history charts: outcomes, durations and executors",
+			Action: func(a *app.App) (tea.Model, tea.Cmd) {
+				return entity.NewStatistics(a.Client), nil
 			},
 		},
 	}
@@ -29,8 +95,9 @@ func main() {
 	for _, e := range entity.All {
 		entry := e // capture loop variable
 		items = append(items, app.MenuItem{
-			Label: entry.Label,
-			Hint:  entry.Hint,
+			Label:  entry.Label,
+			Hint:   entry.Hint,
+			Entity: entry.Def.CLIEntity,
 			Action: func(a *app.App) (tea.Model, tea.Cmd) {
 				view := entity.NewListViewForEntity(a.Client, entry.Def)
 				return view, nil
@@ -38,19 +105,60 @@ func main() {
 		})
 	}
 
+	// Alert log
+	items = append(items, app.MenuItem{
+		Label:  "Alerts",
+		Hint:   "Health rule breaches and recoveries",
+		Entity: entity.AlertLogDef.CLIEntity,
+		Action: func(a *app.App) (tea.Model, tea.Cmd) {
+			return entity.NewListView(a.Client, entity.AlertLogDef), nil
+		},
+	})
+
+	// Undo history and audit log
+	items = append(items, app.MenuItem{
+		Label:  "Undo History",
+		Hint:   "Changes made in this session, to undo",
+		Entity: entity.UndoDef.CLIEntity,
+		Action: func(a *app.App) (tea.Model, tea.Cmd) {
+			return entity.NewListView(a.Client, entity.UndoDef), nil
+		},
+	})
+
+	items = append(items, app.MenuItem{
+		Label:  "Audit Log",
+		Hint:   "Who created, changed and deleted what, from the audit log",
+		Entity: entity.AuditDef.CLIEntity,
+		Action: func(a *app.App) (tea.Model, tea.Cmd) {
+			return entity.NewListView(a.Client, entity.AuditDef), nil
+		},
+	})
+
+	// Generic command runner
+	items = append(items, app.MenuItem{
+		Label:  "Commands",
+		Hint:   "Run any multiflexi-cli command from a generated form",
+		Entity: entity.CommandDef.CLIEntity,
+		Action: func(a *app.App) (tea.Model, tea.Cmd) {
+			return entity.NewListView(a.Client, entity.CommandDef), nil
+		},
+	})
+
+	// Theme
+	items = append(items, app.MenuItem{
+		Label: "Theme",
+		Hint:  "Switch the colour theme",
+		Action: func(a *app.App) (tea.Model, tea.Cmd) {
+			return ui.NewThemePicker(), nil
+		},
+	})
+
 	// Help
 	items = append(items, app.MenuItem{
 		Label: "Help",
 		Hint:  "View help and documentation",
 		Action: func(a *app.App) (tea.Model, tea.Cmd) {
-			viewer := ui.NewViewer("Help")
-			return viewer, func() tea.Msg {
-				content, err := a.Client.GetCommandHelp("help")
-				if err != nil {
-					return ui.HelpErrorMsg{Command: "help", Err: err}
-				}
-				return ui.HelpLoadedMsg{Command: "help", Content: content}
-			}
+			return entity.NewHelpBrowser(a.Client, ""), nil
 		},
 	})
 
@@ -63,8 +171,47 @@ func main() {
 		},
 	})
 
-	if err := app.Run(client, items); err != nil {
+	opts := app.Options{
+		Session:    state,
+		Restore:    !*fresh,
+		Open:       *open,
+		Menu:       *menu,
+		OpenRecord: openRecord,
+		Home:       func(c cli.Client) tea.Model { return entity.NewDashboard(c) },
+		Help:       func(c cli.Client, topic string) tea.Model { return entity.NewHelpBrowser(c, topic) },
+		Undo:       entity.Journal.Undo,
+		Alerts:     entity.Alerts,
+	}
+	err = app.Run(client, items, opts)
+	if serr := state.Save(sessionPath); serr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: saving session: %v\n", serr)
+	}
+	if aerr := client.Err(); aerr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: writing audit log: %v\n", aerr)
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// openRecord loads the linked record of a registered entity and returns its
+// detail view, with the editor on top for edit links.
+func openRecord(c cli.Client, l app.Link) ([]tea.Model, error) {
+	def := entity.Lookup(l.Record.Entity)
+	if def == nil {
+		return nil, fmt.Errorf("unknown entity %q", l.Record.Entity)
+	}
+	data, err := def.Get(c, l.Record.ID)
+	if err != nil {
+		return nil, err
+	}
+	views := []tea.Model{entity.NewDetailView(c, def, data)}
+	if l.Action == app.LinkEdit {
+		if def.ToEditor == nil {
+			return nil, fmt.Errorf("%s records cannot be edited", def.CLIEntity)
+		}
+		views = append(views, entity.NewEditorView(c, def, data, false))
+	}
+	return views, nil
+}
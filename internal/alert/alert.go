@@ -0,0 +1,293 @@
+// Package alert evaluates health rules against the metrics of each status
+// poll and keeps a log of when rules start and stop breaching.
+package alert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics a rule can watch. The dashboard reports them on every poll.
+const (
+	Scheduler      = "scheduler"        // scheduler service state, e.g. "active"
+	Executor       = "executor"         // executor service state
+	Database       = "database"         // database connection state
+	Queue          = "queue"            // jobs waiting in the queue
+	Running        = "running"          // jobs running now
+	FailedLastHour = "failed_last_hour" // jobs that failed in the last hour
+	Overdue        = "overdue"          // active RunTemplates past their schedule
+)
+
+var metricNames = []string{Scheduler, Executor, Database, Queue, Running, FailedLastHour, Overdue}
+
+// Notification modes: how a rule starting or stopping to breach is
+// announced besides the banner and the log.
+const (
+	NotifyOff  = "off"
+	NotifyBell = "bell" // terminal bell
+	NotifyOSC9 = "osc9" // OSC 9 desktop notification (iTerm2, kitty, WezTerm, …)
+)
+
+// maxLog is how many events the log keeps.
+const maxLog = 500
+
+// Metrics are the values observed by one poll, keyed by metric name.
+// Metrics a poll could not read are left out; their rules keep their state.
+type Metrics map[string]string
+
+// Rule breaches while its metric compared to Value by Op holds, e.g.
+// queue > 50 or scheduler != active. Numbers compare numerically; other
+// values compare case-insensitively by their first word, so a state of
+// "active (running)" equals "active".
+type Rule struct {
+	Name   string      `json:"name"`
+	Metric string      `json:"metric"`
+	Op     string      `json:"op"` // =, !=, >, >=, < or <=
+	Value  interface{} `json:"value"`
+}
+
+// DefaultRules are used when no alerts.json exists.
+var DefaultRules = []Rule{
+	{Name: "Scheduler not active", Metric: Scheduler, Op: "!=", Value: "active"},
+	{Name: "Queue backed up", Metric: Queue, Op: ">", Value: 50},
+	{Name: "Jobs failing", Metric: FailedLastHour, Op: ">", Value: 5},
+}
+
+// limit returns the rule's value as text.
+func (r Rule) limit() string { return fmt.Sprint(r.Value) }
+
+// validate reports rules that could never be evaluated.
+func (r Rule) validate() error {
+	if r.Name == "" {
+		return errors.New("rule without a name")
+	}
+	known := false
+	for _, m := range metricNames {
+		known = known || m == r.Metric
+	}
+	if !known {
+		return fmt.Errorf("rule %q: unknown metric %q (use %s)", r.Name, r.Metric, strings.Join(metricNames, ", "))
+	}
+	switch r.Op {
+	case "=", "!=":
+	case ">", ">=", "<", "<=":
+		if _, err := strconv.ParseFloat(r.limit(), 64); err != nil {
+			return fmt.Errorf("rule %q: %s needs a number, got %q", r.Name, r.Op, r.limit())
+		}
+	default:
+		return fmt.Errorf("rule %q: unknown operator %q", r.Name, r.Op)
+	}
+	return nil
+}
+
+// breached reports whether value v breaches the rule.
+func (r Rule) breached(v string) bool {
+	want := r.limit()
+	a, errA := strconv.ParseFloat(v, 64)
+	b, errB := strconv.ParseFloat(want, 64)
+	if errA == nil && errB == nil {
+		switch r.Op {
+		case "=":
+			return a == b
+		case "!=":
+			return a != b
+		case ">":
+			return a > b
+		case ">=":
+			return a >= b
+		case "<":
+			return a < b
+		case "<=":
+			return a <= b
+		}
+		return false
+	}
+	switch r.Op {
+	case "=":
+		return firstWord(v) == firstWord(want)
+	case "!=":
+		return firstWord(v) != firstWord(want)
+	}
+	return false
+}
+
+// firstWord lowercases the first word of s.
+func firstWord(s string) string {
+	if f := strings.Fields(s); len(f) > 0 {
+		return strings.ToLower(f[0])
+	}
+	return ""
+}
+
+// Event records a rule starting (Breached) or stopping to breach.
+type Event struct {
+	At       time.Time
+	Rule     string
+	Breached bool
+	Metric   string
+	Value    string // the metric's value when the event was raised
+	Op       string
+	Limit    string
+}
+
+// Detail describes the observation behind the event, e.g.
+// "queue 120 > 50" while breaching or "queue 3" once resolved.
+func (e Event) Detail() string {
+	if e.Breached {
+		return fmt.Sprintf("%s %s %s %s", e.Metric, e.Value, e.Op, e.Limit)
+	}
+	return e.Metric + " " + e.Value
+}
+
+// Monitor evaluates rules poll after poll and remembers which breach.
+// Evaluating the same state twice raises nothing, so several views may
+// feed the same monitor, and it is safe for use by the commands that read
+// its log.
+type Monitor struct {
+	mu     sync.Mutex
+	rules  []Rule
+	active map[string]Event // breaching rules, with the event that raised them
+	log    []Event          // oldest first
+
+	// Notify is NotifyOff (or empty), NotifyBell or NotifyOSC9.
+	Notify string
+}
+
+// NewMonitor creates a monitor for the given rules.
+func NewMonitor(rules []Rule) *Monitor {
+	return &Monitor{rules: rules, active: map[string]Event{}}
+}
+
+// Rules returns the monitored rules.
+func (m *Monitor) Rules() []Rule { return m.rules }
+
+// Evaluate checks every rule whose metric was observed and returns the
+// rules that started or stopped breaching, logging them.
+func (m *Monitor) Evaluate(metrics Metrics, at time.Time) []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var events []Event
+	for _, r := range m.rules {
+		v, ok := metrics[r.Metric]
+		if !ok {
+			continue
+		}
+		breached := r.breached(v)
+		if _, was := m.active[r.Name]; was == breached {
+			continue
+		}
+		e := Event{At: at, Rule: r.Name, Breached: breached, Metric: r.Metric, Value: v, Op: r.Op, Limit: r.limit()}
+		if breached {
+			m.active[r.Name] = e
+		} else {
+			delete(m.active, r.Name)
+		}
+		events = append(events, e)
+	}
+	m.log = append(m.log, events...)
+	if len(m.log) > maxLog {
+		m.log = append([]Event(nil), m.log[len(m.log)-maxLog:]...)
+	}
+	return events
+}
+
+// Active returns the events of the rules breaching now, in rule order.
+func (m *Monitor) Active() []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Event
+	for _, r := range m.rules {
+		if e, ok := m.active[r.Name]; ok {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Log returns the logged events, newest first.
+func (m *Monitor) Log() []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]Event, len(m.log))
+	for i, e := range m.log {
+		out[len(m.log)-1-i] = e
+	}
+	return out
+}
+
+// Notification returns the terminal sequence announcing text in the
+// configured mode, or "" when notifications are off. The program writes it
+// together with a frame, never from a command, so it cannot land inside
+// another escape sequence.
+func (m *Monitor) Notification(text string) string {
+	switch m.Notify {
+	case NotifyBell:
+		return "\a"
+	case NotifyOSC9:
+		// Control characters would end the sequence early.
+		text = strings.Map(func(r rune) rune {
+			if r < 0x20 || r == 0x7f {
+				return ' '
+			}
+			return r
+		}, text)
+		return "\x1b]9;" + text + "\a"
+	}
+	return ""
+}
+
+// Config is the alerts.json file:
+//
+//	{"notify": "osc9", "rules": [{"name": "Queue backed up", "metric": "queue", "op": ">", "value": 50}]}
+type Config struct {
+	Notify string `json:"notify,omitempty"`
+	Rules  []Rule `json:"rules"`
+}
+
+// Load reads the alert configuration at path. A missing file, or a file
+// without "rules", gives the DefaultRules; an empty list turns alerts off.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+	if cfg.Rules == nil {
+		cfg.Rules = DefaultRules
+	}
+	seen := map[string]bool{}
+	for _, r := range cfg.Rules {
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if seen[r.Name] {
+			return nil, fmt.Errorf("%s: duplicate rule %q", path, r.Name)
+		}
+		seen[r.Name] = true
+	}
+	if err := CheckNotify(cfg.Notify); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// CheckNotify reports an unknown notification mode.
+func CheckNotify(mode string) error {
+	switch mode {
+	case "", NotifyOff, NotifyBell, NotifyOSC9:
+		return nil
+	}
+	return fmt.Errorf("unknown notification %q (use %s, %s or %s)", mode, NotifyOff, NotifyBell, NotifyOSC9)
+}
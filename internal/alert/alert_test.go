@@ -0,0 +1,126 @@
+package alert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRuleComparisons(t *testing.T) {
+	for _, tc := range []struct {
+		rule  Rule
+		value string
+		want  bool
+	}{
+		{Rule{Op: ">", Value: 50}, "51", true},
+		{Rule{Op: ">", Value: 50}, "50", false},
+		{Rule{Op: ">=", Value: 50.0}, "50", true},
+		{Rule{Op: "<", Value: "1"}, "0", true},
+		{Rule{Op: "!=", Value: "active"}, "inactive", true},
+		{Rule{Op: "!=", Value: "active"}, "Active (running)", false},
+		{Rule{Op: "=", Value: "failed"}, "failed since 10:00", true},
+		{Rule{Op: ">", Value: 5}, "unknown", false},
+	} {
+		if got := tc.rule.breached(tc.value); got != tc.want {
+			t.Errorf("%v %s %v on %q = %v, want %v", tc.value, tc.rule.Op, tc.rule.Value, tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestEvaluateRaisesTransitionsOnce(t *testing.T) {
+	m := NewMonitor(DefaultRules)
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	events := m.Evaluate(Metrics{Scheduler: "inactive", Queue: "80", FailedLastHour: "0"}, at)
+	if len(events) != 2 || events[0].Rule != "Scheduler not active" || events[1].Detail() != "queue 80 > 50" {
+		t.Fatalf("expected the scheduler and queue rules to breach, got %+v", events)
+	}
+	if again := m.Evaluate(Metrics{Scheduler: "inactive", Queue: "90"}, at.Add(time.Minute)); len(again) != 0 {
+		t.Errorf("a breach is raised once, got %+v", again)
+	}
+
+	// Missing metrics keep their state; recovered ones are logged.
+	events = m.Evaluate(Metrics{Queue: "3"}, at.Add(2*time.Minute))
+	if len(events) != 1 || events[0].Breached || events[0].Detail() != "queue 3" {
+		t.Errorf("expected the queue to recover, got %+v", events)
+	}
+	if active := m.Active(); len(active) != 1 || active[0].Rule != "Scheduler not active" {
+		t.Errorf("only the scheduler should still breach, got %+v", active)
+	}
+	if log := m.Log(); len(log) != 3 || log[0].Breached || log[0].Rule != "Queue backed up" {
+		t.Errorf("the log should hold every transition, newest first: %+v", log)
+	}
+}
+
+func TestMonitorReadWhileEvaluating(t *testing.T) {
+	m := NewMonitor(DefaultRules)
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			m.Log()
+			m.Active()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		m.Evaluate(Metrics{Queue: fmt.Sprint(i % 2 * 100)}, at.Add(time.Duration(i)*time.Minute))
+	}
+	<-done
+	if log := m.Log(); len(log) != 99 {
+		t.Errorf("expected a transition per poll after the first, got %d", len(log))
+	}
+}
+
+func TestNotification(t *testing.T) {
+	m := NewMonitor(nil)
+	var got []string
+	got = append(got, m.Notification("quiet"))
+	m.Notify = NotifyBell
+	got = append(got, m.Notification("ring"))
+	m.Notify = NotifyOSC9
+	got = append(got, m.Notification("Alert: queue\n120"))
+	if want := []string{"", "\a", "\x1b]9;Alert: queue 120\a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("notifications = %q, want %q", got, want)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := Load(filepath.Join(dir, "missing.json"))
+	if err != nil || len(cfg.Rules) != len(DefaultRules) {
+		t.Fatalf("a missing file gives the default rules: %+v, %v", cfg, err)
+	}
+
+	write := func(content string) string {
+		path := filepath.Join(dir, "alerts.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	cfg, err = Load(write(`{"notify": "osc9", "rules": [{"name": "Busy", "metric": "running", "op": ">=", "value": 10}]}`))
+	if err != nil || cfg.Notify != NotifyOSC9 || len(cfg.Rules) != 1 || !cfg.Rules[0].breached("10") {
+		t.Errorf("unexpected config %+v, %v", cfg, err)
+	}
+	if cfg, err = Load(write(`{"rules": []}`)); err != nil || len(cfg.Rules) != 0 {
+		t.Errorf("an empty list turns alerts off: %+v, %v", cfg, err)
+	}
+
+	for content, want := range map[string]string{
+		`{"rules": [{"name": "x", "metric": "disk", "op": ">", "value": 1}]}`:       "unknown metric",
+		`{"rules": [{"name": "x", "metric": "queue", "op": "~", "value": 1}]}`:      "unknown operator",
+		`{"rules": [{"name": "x", "metric": "queue", "op": ">", "value": "many"}]}`: "needs a number",
+		`{"rules": [{"metric": "queue", "op": ">", "value": 1}]}`:                   "without a name",
+		`{"notify": "email"}`: "unknown notification",
+		`{"rules": [{"name": "x", "metric": "queue", "op": ">", "value": 1}, {"name": "x", "metric": "running", "op": ">", "value": 1}]}`: "duplicate rule",
+	} {
+		if _, err := Load(write(content)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Load(%s) = %v, want an error about %q", content, err, want)
+		}
+	}
+}
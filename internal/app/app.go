@@ -2,143 +2,219 @@ package app
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/VitexSoftware/multiflexi-tui/internal/alert"
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// chromeLines is the height of the menu bar (3 lines) plus the footer (2 lines).
+const (
+	menuBarLines = 3
+	chromeLines  = menuBarLines + 2
+)
+
+// Options configure an App beyond its menu.
+type Options struct {
+	// Session is kept up to date while the user navigates. With Restore
+	// set, Init reopens its menu item and record.
+	Session *session.State
+	Restore bool
+
+	// Open is a deep link (see ParseLink) and Menu a menu item to show on
+	// start; both take precedence over the restored session.
+	Open string
+	Menu string
+
+	// OpenRecord builds the views a link leads to, bottom first, e.g. the
+	// detail view and the editor on top of it.
+	OpenRecord func(c cli.Client, l Link) ([]tea.Model, error)
+
+	// Home builds the view each tab shows while no menu item is open, e.g.
+	// the dashboard. Without it the home screen is empty.
+	Home func(c cli.Client) tea.Model
+
+	// Help builds the help browser F1 opens, at the command documenting
+	// the view in front (see ui.HelpTopic) or at the top for other views.
+	Help func(c cli.Client, topic string) tea.Model
+
+	// Undo, when set, undoes the last change on ctrl+z.
+	Undo func(c cli.Client) tea.Cmd
+
+	// Alerts, when set, are the health rules whose breaches are shown in a
+	// banner above the footer.
+	Alerts *alert.Monitor
+}
+
 // App is the top-level bubbletea model.
 type App struct {
 	Client cli.Client
-	nav    Navigator
 	items  []MenuItem
+	opts   Options
 
-	// Current view (nil = home/status view)
-	activeView tea.Model
+	// Workspaces (tabs). ws is the workspace the current message belongs to;
+	// for terminal input it is the tab in front.
+	tabs      []*Workspace
+	tab       int
+	ws        *Workspace
+	nextTabID int
 
 	// Menu bar
-	menuCursor     int
-	activeMenuItem int
-	menuFocus      bool // true = menu focused
-	menuViewStart  int  // index of first visible menu item (horizontal scroll)
+	menuCursor    int
+	menuFocus     bool // true = menu focused
+	menuViewStart int  // index of first visible menu item (horizontal scroll)
 
 	// Layout
 	width, height int
+	barZones      ui.Zones // menu items and tab cells of the last render
+
+	// Previous left click, for double-click detection
+	lastClickX, lastClickY int
+	lastClickAt            time.Time
 
 	// Status
-	statusInfo    *cli.StatusInfo
 	statusMessage string
+
+	// Terminal notification written with the next frames, and the number
+	// of the latest one, so only its notifiedMsg clears it.
+	notification string
+	notifyGen    int
 }
 
-// New creates a new App with the given client and menu items.
-func New(client cli.Client, items []MenuItem) *App {
-	return &App{
+// New creates a new App with the given client, menu items and options.
+func New(client cli.Client, items []MenuItem, opts Options) *App {
+	a := &App{
 		Client:    client,
 		items:     items,
+		opts:      opts,
 		menuFocus: true,
 	}
+	first := a.newWorkspace(0)
+	a.tabs = []*Workspace{first}
+	a.ws = first
+	return a
 }
 
-// statusLoadedMsg carries the loaded status.
-type statusLoadedMsg struct{ status *cli.StatusInfo }
-
 func (a *App) Init() tea.Cmd {
-	return func() tea.Msg {
-		status, err := a.Client.GetStatus()
-		if err != nil {
-			return statusLoadedMsg{status: &cli.StatusInfo{VersionCli: "Error", User: err.Error()}}
-		}
-		return statusLoadedMsg{status: status}
+	var home tea.Cmd
+	if h := a.tabs[0].home; h != nil {
+		home = a.tabs[0].wrap(h.Init())
 	}
+	return tea.Batch(home, a.startup())
 }
 
+// Update routes msg to the workspace it belongs to and tags the resulting
+// command with that workspace, so background tabs keep their async results.
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	a.ws = a.active()
+	if tm, ok := msg.(tabMsg); ok {
+		if a.ws = a.workspace(tm.tab); a.ws == nil {
+			return a, nil // tab was closed
+		}
+		msg = tm.msg
+	}
+	_, cmd := a.update(msg)
+	return a, a.ws.wrap(cmd)
+}
+
+func (a *App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
 		a.adjustMenuViewport()
-		if a.activeView != nil {
-			// menuBar=3 lines, footer=2 lines → content area = height - 5
-			contentMsg := tea.WindowSizeMsg{Width: msg.Width, Height: msg.Height - 5}
-			var cmd tea.Cmd
-			a.activeView, cmd = a.activeView.Update(contentMsg)
-			return a, cmd
-		}
-		return a, nil
-
-	case statusLoadedMsg:
-		a.statusInfo = msg.status
-		return a, nil
+		return a, a.ws.updateFront(a.contentSize())
 
 	case ui.NavigateToMsg:
 		// Push current view onto stack, switch to new view
-		a.nav.Push(ViewState{View: a.activeView, MenuIdx: a.activeMenuItem})
-		a.activeView = msg.View
-		a.menuFocus = false
+		a.ws.nav.Push(ViewState{View: a.ws.activeView, MenuIdx: a.ws.activeMenuItem})
+		a.ws.activeView = msg.View
+		a.setMenuFocus(false)
+		// Init loads the data, so the resize command is not needed here.
+		a.sizeView(msg.View)
 		return a, msg.View.Init()
 
+	case openViewsMsg:
+		var cmds []tea.Cmd
+		for _, v := range msg.views {
+			_, cmd := a.update(ui.NavigateToMsg{View: v})
+			cmds = append(cmds, cmd)
+		}
+		return a, tea.Batch(cmds...)
+
 	case ui.NavigateBackMsg:
 		return a.goBack()
 
 	case ui.NavigateBackAndRefreshMsg:
 		if msg.Status != "" {
-			a.statusMessage = msg.Status
-		}
-		// Pop views until we land on one that can refresh itself.
-		for {
-			prev, ok := a.nav.Pop()
-			if !ok {
-				a.activeView = nil
-				a.menuFocus = true
-				return a, nil
-			}
-			a.activeView = prev.View
-			if prev.View == nil {
-				a.menuFocus = true
-				return a, nil
-			}
-			if r, ok := prev.View.(ui.Refreshable); ok {
-				return a, r.Refresh()
-			}
+			a.statusMessage = a.tabPrefix() + i18n.T(msg.Status)
+		}
+		return a, a.backAndRefresh(0)
+
+	case ui.NavigateBackAndOpenMsg:
+		if msg.Status != "" {
+			a.statusMessage = a.tabPrefix() + i18n.T(msg.Status)
 		}
+		refresh := a.backAndRefresh(msg.Select)
+		open := func() tea.Msg { return ui.NavigateToMsg{View: msg.View} }
+		// Sequenced so the refresh reaches the list rather than the view
+		// opened on top of it.
+		return a, tea.Sequence(a.ws.wrap(refresh), a.ws.wrap(open))
 
 	case ui.StatusMsg:
-		a.statusMessage = msg.Text
+		a.statusMessage = a.tabPrefix() + i18n.T(msg.Text)
+		return a, nil
+
+	case ui.NotifyMsg:
+		a.statusMessage = a.tabPrefix() + i18n.T(msg.Text)
+		return a, a.notify(msg.Text)
+
+	case notifiedMsg:
+		if msg.gen == a.notifyGen {
+			a.notification = ""
+		}
 		return a, nil
 
 	case ui.RefreshCurrentMsg:
 		if msg.Status != "" {
-			a.statusMessage = msg.Status
+			a.statusMessage = a.tabPrefix() + i18n.T(msg.Status)
 		}
-		if a.activeView != nil {
-			if r, ok := a.activeView.(ui.Refreshable); ok {
-				return a, r.Refresh()
-			}
+		if r, ok := a.ws.front().(ui.Refreshable); ok {
+			return a, r.Refresh()
 		}
 		return a, nil
 
 	case ui.ConfirmMsg:
-		confirm := ui.NewConfirmDialog(msg.Label, msg.Action)
-		a.nav.Push(ViewState{View: a.activeView, MenuIdx: a.activeMenuItem})
-		a.activeView = confirm
-		a.menuFocus = false
+		confirm := ui.NewConfirmDialog(msg.Label, msg.Action).WithCancel(msg.Cancel)
+		a.ws.nav.Push(ViewState{View: a.ws.activeView, MenuIdx: a.ws.activeMenuItem})
+		a.ws.activeView = confirm
+		a.setMenuFocus(false)
+		if a.ws != a.active() {
+			a.statusMessage = a.tabPrefix() + i18n.T("waiting for confirmation")
+		}
 		return a, nil
 
 	case ui.ConfirmYesMsg:
-		prev, _ := a.nav.Pop()
-		a.activeView = prev.View
+		prev, _ := a.ws.nav.Pop()
+		a.ws.activeView = prev.View
 		if msg.Action != nil {
 			return a, func() tea.Msg { return msg.Action() }
 		}
 		return a, nil
 
 	case ui.ConfirmNoMsg:
-		prev, _ := a.nav.Pop()
-		a.activeView = prev.View
+		prev, _ := a.ws.nav.Pop()
+		a.ws.activeView = prev.View
+		if msg.Action != nil {
+			return a, func() tea.Msg { return msg.Action() }
+		}
 		return a, nil
 
 	case tea.MouseMsg:
@@ -148,13 +224,37 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a.handleKey(msg)
 	}
 
-	// Forward non-key messages to active view
-	if a.activeView != nil {
-		var cmd tea.Cmd
-		a.activeView, cmd = a.activeView.Update(msg)
-		return a, cmd
+	// Forward non-key messages to the view in front
+	return a, a.ws.updateFront(msg)
+}
+
+// backAndRefresh pops views until it lands on one that can refresh itself
+// and refreshes it, with the cursor on the record selectID if non-zero.
+func (a *App) backAndRefresh(selectID int) tea.Cmd {
+	for {
+		prev, ok := a.ws.nav.Pop()
+		a.ws.activeView = prev.View
+		view, home := prev.View, !ok || prev.View == nil
+		if home {
+			a.setMenuFocus(true)
+			view = a.ws.home
+		}
+		if r, ok := view.(ui.Refreshable); ok {
+			if s, ok := view.(ui.Selector); ok && selectID != 0 {
+				s.Select(selectID)
+			}
+			return r.Refresh()
+		}
+		if home {
+			return nil
+		}
 	}
-	return a, nil
+}
+
+// capturingInput reports whether the view in front takes text input.
+func (a *App) capturingInput() bool {
+	c, ok := a.ws.activeView.(ui.InputCapturer)
+	return ok && !a.menuFocus && c.CapturingInput()
 }
 
 func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -165,16 +265,50 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, tea.Quit
 	}
 
+	// Workspace tabs; views taking text input keep alt+←/→ to move by word.
+	capturing := a.capturingInput()
+	switch key {
+	case "alt+t":
+		return a.newTab()
+	case "alt+w":
+		return a.closeTab()
+	case "ctrl+pgdown", "alt+right":
+		if key == "ctrl+pgdown" || !capturing {
+			return a.switchTab((a.tab + 1) % len(a.tabs))
+		}
+	case "ctrl+pgup", "alt+left":
+		if key == "ctrl+pgup" || !capturing {
+			return a.switchTab((a.tab - 1 + len(a.tabs)) % len(a.tabs))
+		}
+	case "alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9":
+		return a.switchTab(int(key[len(key)-1] - '1'))
+	}
+
+	if key == "f1" && a.opts.Help != nil {
+		return a.openHelp()
+	}
+
 	// If active view is a confirm dialog, let it handle keys
-	if _, ok := a.activeView.(*ui.ConfirmDialog); ok {
+	if _, ok := a.ws.activeView.(*ui.ConfirmDialog); ok {
+		var cmd tea.Cmd
+		a.ws.activeView, cmd = a.ws.activeView.Update(msg)
+		return a, cmd
+	}
+
+	if key == "ctrl+z" && a.opts.Undo != nil {
+		return a, a.opts.Undo(a.Client)
+	}
+
+	// Views taking text input get esc, q and tab themselves.
+	if capturing {
 		var cmd tea.Cmd
-		a.activeView, cmd = a.activeView.Update(msg)
+		a.ws.activeView, cmd = a.ws.activeView.Update(msg)
 		return a, cmd
 	}
 
 	switch key {
 	case "q":
-		if a.menuFocus && a.activeView == nil {
+		if a.menuFocus && a.ws.activeView == nil {
 			return a, tea.Quit
 		}
 		if a.menuFocus {
@@ -182,10 +316,10 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case "esc":
 		if !a.menuFocus {
-			if a.nav.Depth() > 0 {
+			if a.ws.nav.Depth() > 0 {
 				return a.goBack()
 			}
-			a.menuFocus = true
+			a.setMenuFocus(true)
 			return a, nil
 		}
 	case "tab":
@@ -216,54 +350,69 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
-	// Forward to active view
-	if a.activeView != nil {
-		var cmd tea.Cmd
-		a.activeView, cmd = a.activeView.Update(msg)
-		return a, cmd
-	}
-	return a, nil
+	// Forward to the view in front
+	return a, a.ws.updateFront(msg)
 }
 
 func (a *App) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	switch msg.Type {
 	case tea.MouseLeft:
-		if msg.Y == 0 {
-			// Title: " MultiFlexi TUI " = 16 visible chars + 1 space = 17
-			// Left indicator: 2 chars ("< " or "  ")
-			xPos := len(" MultiFlexi TUI ") + 1 + 2
-			for i := a.menuViewStart; i < len(a.items); i++ {
-				itemWidth := len(a.items[i].Label) + 3 // " label " + space
-				if msg.X >= xPos && msg.X < xPos+itemWidth {
-					a.menuCursor = i
-					a.adjustMenuViewport()
-					return a.selectMenuItem()
-				}
-				xPos += itemWidth
+		if msg.Y < menuBarLines {
+			id, _ := a.barZones.Hit(msg.X, msg.Y)
+			kind, arg, _ := strings.Cut(id, ":")
+			i, _ := strconv.Atoi(arg)
+			switch kind {
+			case "menu":
+				a.menuCursor = i
+				a.adjustMenuViewport()
+				return a.selectMenuItem()
+			case "tab":
+				return a.switchTab(i)
 			}
-		} else if msg.Y >= 3 && a.menuFocus {
-			a.menuFocus = false
+			return a, nil
+		}
+		if msg.Y >= a.height-(chromeLines-menuBarLines) {
+			return a, nil // footer
 		}
+		a.setMenuFocus(false)
+		click := ui.ClickMsg{X: msg.X, Y: msg.Y - menuBarLines, Double: a.isDoubleClick(msg)}
+		return a, a.ws.updateFront(click)
 	case tea.MouseWheelUp:
-		if !a.menuFocus && a.activeView != nil {
-			keyMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}}
-			var cmd tea.Cmd
-			a.activeView, cmd = a.activeView.Update(keyMsg)
-			return a, cmd
+		if !a.menuFocus {
+			return a, a.ws.updateFront(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
 		}
 	case tea.MouseWheelDown:
-		if !a.menuFocus && a.activeView != nil {
-			keyMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}
-			var cmd tea.Cmd
-			a.activeView, cmd = a.activeView.Update(keyMsg)
-			return a, cmd
+		if !a.menuFocus {
+			return a, a.ws.updateFront(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
 		}
 	}
 	return a, nil
 }
 
+// openHelp pushes the help browser at the topic of the view in front.
+func (a *App) openHelp() (tea.Model, tea.Cmd) {
+	topic := ""
+	if t, ok := a.ws.front().(ui.HelpTopic); ok {
+		topic = t.HelpTopic()
+	}
+	return a.update(ui.NavigateToMsg{View: a.opts.Help(a.Client, topic)})
+}
+
+// isDoubleClick reports whether msg repeats the previous click on the same
+// cell within ui.DoubleClickInterval. A double click is not the first half
+// of another one.
+func (a *App) isDoubleClick(msg tea.MouseMsg) bool {
+	now := time.Now()
+	double := msg.X == a.lastClickX && msg.Y == a.lastClickY && now.Sub(a.lastClickAt) <= ui.DoubleClickInterval
+	a.lastClickX, a.lastClickY, a.lastClickAt = msg.X, msg.Y, now
+	if double {
+		a.lastClickAt = time.Time{}
+	}
+	return double
+}
+
 // adjustMenuViewport updates menuViewStart so the cursor item is always visible.
-// Each item occupies len(label)+3 visible columns (" label " + space separator).
+// Each item occupies ui.Width(label)+3 visible columns (" label " + space separator).
 func (a *App) adjustMenuViewport() {
 	if a.width == 0 || len(a.items) == 0 {
 		return
@@ -292,7 +441,7 @@ func (a *App) adjustMenuViewport() {
 		used := 0
 		lastVisible := a.menuViewStart - 1
 		for i := a.menuViewStart; i < len(a.items); i++ {
-			w := len(a.items[i].Label) + 3
+			w := ui.Width(a.menuLabel(i)) + 3
 			if used+w > avail {
 				break
 			}
@@ -311,31 +460,77 @@ func (a *App) adjustMenuViewport() {
 }
 
 func (a *App) goBack() (tea.Model, tea.Cmd) {
-	prev, ok := a.nav.Pop()
+	prev, ok := a.ws.nav.Pop()
 	if !ok {
-		a.activeView = nil
-		a.menuFocus = true
-		return a, nil
+		a.ws.activeView = nil
+		a.setMenuFocus(true)
+		return a, a.sizeView(a.ws.home)
 	}
-	a.activeView = prev.View
+	a.ws.activeView = prev.View
 	if prev.View == nil {
-		a.menuFocus = true
+		a.setMenuFocus(true)
 	}
-	return a, nil
+	return a, a.sizeView(a.ws.front())
+}
+
+// setMenuFocus changes menu focus, but only for the tab in front; results
+// arriving for background tabs must not steal focus.
+func (a *App) setMenuFocus(focus bool) {
+	if a.ws == a.active() {
+		a.menuFocus = focus
+	}
+}
+
+// menuLabel returns the translated label of menu item i.
+func (a *App) menuLabel(i int) string {
+	return i18n.T(a.items[i].Label)
+}
+
+// tabPrefix marks footer messages that originate from a background tab.
+func (a *App) tabPrefix() string {
+	if a.ws == a.active() {
+		return ""
+	}
+	for i, w := range a.tabs {
+		if w == a.ws {
+			return i18n.Tf("[tab %d] ", i+1)
+		}
+	}
+	return ""
+}
+
+// contentSize describes the content area: menuBar=3 lines, footer=2 lines.
+func (a *App) contentSize() tea.WindowSizeMsg {
+	return tea.WindowSizeMsg{Width: a.width, Height: a.height - chromeLines}
+}
+
+// sizeView sends the current content-area size to v. Views created or
+// restored after the last terminal resize would otherwise keep stale sizes.
+func (a *App) sizeView(v tea.Model) tea.Cmd {
+	if v == nil || a.width == 0 {
+		return nil
+	}
+	_, cmd := v.Update(a.contentSize())
+	return cmd
 }
 
 func (a *App) selectMenuItem() (tea.Model, tea.Cmd) {
 	if a.menuCursor < 0 || a.menuCursor >= len(a.items) {
 		return a, nil
 	}
-	a.activeMenuItem = a.menuCursor
+	a.ws.activeMenuItem = a.menuCursor
 	item := a.items[a.menuCursor]
 	if item.Action != nil {
 		// Clear nav stack when selecting from menu
-		a.nav.Clear()
+		a.ws.nav.Clear()
 		view, cmd := item.Action(a)
-		a.activeView = view
-		a.menuFocus = false
+		a.ws.activeView = view
+		a.rememberMenu(item, view, cmd)
+		a.setMenuFocus(false)
+		if view == nil && cmd == nil {
+			return a, a.sizeView(a.ws.home) // the home view refreshes when stale
+		}
+		a.sizeView(view)
 		if view != nil && cmd == nil {
 			cmd = view.Init()
 		}
@@ -347,21 +542,22 @@ func (a *App) selectMenuItem() (tea.Model, tea.Cmd) {
 // View renders the full UI.
 func (a *App) View() string {
 	if a.width == 0 {
-		return "Initializing..."
+		return i18n.T("Initializing...")
 	}
 
 	var content string
-	if a.activeView != nil {
-		content = a.activeView.View()
-	} else {
-		content = a.renderStatus()
+	if front := a.active().front(); front != nil {
+		content = front.View()
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left,
+	view := lipgloss.JoinVertical(lipgloss.Left,
 		a.renderMenuBar(),
 		content,
 		a.renderFooter(),
 	)
+	// Written before the frame, so the renderer cuts the menu bar rather
+	// than the sequence when it counts the text as wide.
+	return a.notification + view
 }
 
 func (a *App) renderMenuBar() string {
@@ -377,52 +573,78 @@ func (a *App) renderMenuBar() string {
 		avail = 6
 	}
 
+	titleRendered := ui.TitleStyle().Render(" MultiFlexi TUI ")
+	a.barZones.Reset()
+
+	// Scroll indicators — show "<" / ">" when items are hidden
+	leftInd := "  "
+	if a.menuViewStart > 0 {
+		leftInd = "< "
+	}
+	x := ui.Width(titleRendered + " " + leftInd)
+
 	// Render only items that fit in the viewport window
 	var parts []string
 	used := 0
 	lastVisible := a.menuViewStart - 1
 	for i := a.menuViewStart; i < len(a.items); i++ {
-		item := a.items[i]
-		itemVW := len(item.Label) + 3
+		label := a.menuLabel(i)
+		itemVW := ui.Width(label) + 3
 		if used+itemVW > avail {
 			break
 		}
 		var rendered string
 		if i == a.menuCursor && a.menuFocus {
-			rendered = ui.SelectedStyle().Render(" " + item.Label + " ")
-		} else if i == a.activeMenuItem {
-			rendered = ui.ActiveMenuStyle().Render(" " + item.Label + " ")
+			rendered = ui.SelectedStyle().Render(" " + label + " ")
+		} else if i == a.active().activeMenuItem {
+			rendered = ui.ActiveMenuStyle().Render(" " + label + " ")
 		} else {
-			rendered = ui.UnselectedStyle().Render(" " + item.Label + " ")
+			rendered = ui.UnselectedStyle().Render(" " + label + " ")
 		}
 		parts = append(parts, rendered)
+		a.barZones.Add(fmt.Sprintf("menu:%d", i), x, 0, ui.Width(rendered))
+		x += ui.Width(rendered) + 1
 		used += itemVW
 		lastVisible = i
 	}
 
-	// Scroll indicators — show "<" / ">" when items are hidden
-	leftInd := "  "
 	rightInd := "  "
-	if a.menuViewStart > 0 {
-		leftInd = "< "
-	}
 	if lastVisible >= 0 && lastVisible < len(a.items)-1 {
 		rightInd = " >"
 	}
 
-	titleRendered := ui.TitleStyle().Render(" MultiFlexi TUI ")
 	menuLine := titleRendered + " " + leftInd + strings.Join(parts, " ") + rightInd
 
-	hint := "←/→: navigate • enter: select • tab: content"
+	hint := i18n.T("←/→: navigate • enter: select • tab: content")
 	if a.menuCursor >= 0 && a.menuCursor < len(a.items) {
-		hint = a.items[a.menuCursor].Hint
+		hint = i18n.T(a.items[a.menuCursor].Hint)
 	}
 	hintLine := ui.DescriptionStyle().Render(" " + hint + " ")
+	if len(a.tabs) > 1 {
+		hintLine = a.renderTabStrip() + hintLine
+	}
 	sep := strings.Repeat("═", w)
 
 	return menuLine + "\n" + hintLine + "\n" + sep + "\n"
 }
 
+// renderTabStrip renders one " n:Label " cell per workspace, front tab highlighted.
+func (a *App) renderTabStrip() string {
+	var b strings.Builder
+	for i, w := range a.tabs {
+		cell := fmt.Sprintf(" %d:%s ", i+1, a.tabLabel(w))
+		x, _ := ui.Cursor(&b)
+		a.barZones.Add(fmt.Sprintf("tab:%d", i), x, 1, ui.Width(cell))
+		if i == a.tab {
+			b.WriteString(ui.SelectedStyle().Render(cell))
+		} else {
+			b.WriteString(ui.UnselectedStyle().Render(cell))
+		}
+	}
+	b.WriteString(ui.DescriptionStyle().Render(" │"))
+	return b.String()
+}
+
 func (a *App) renderFooter() string {
 	w := a.width
 	if w == 0 {
@@ -431,13 +653,16 @@ func (a *App) renderFooter() string {
 	sep := strings.Repeat("═", w)
 	var helpLine string
 	if a.menuFocus {
-		helpLine = ui.FooterStyle().Render(" ←/→: navigate menu • enter: select • tab: content • q: quit ")
+		helpLine = ui.FooterStyle().Render(" " + i18n.T("←/→: navigate menu • enter: select • tab: content • alt+t/alt+w: new/close tab • q: quit") + " ")
 	} else {
-		helpLine = ui.FooterStyle().Render(" ↑/↓: rows • ←/→: pages • enter: detail • e: edit • n: new • esc: back • tab: menu ")
+		helpLine = ui.FooterStyle().Render(" " + i18n.T("↑/↓: rows • ←/→: pages • enter: detail • e: edit • n: new • esc: back • tab: menu") + " ")
 	}
 
 	var lines []string
 	lines = append(lines, sep)
+	if banner := a.alertBanner(w); banner != "" {
+		lines = append(lines, banner)
+	}
 	if a.statusMessage != "" {
 		lines = append(lines, ui.FooterStyle().Render(" "+a.statusMessage+" "))
 	}
@@ -448,59 +673,54 @@ func (a *App) renderFooter() string {
 	return strings.Join(lines, "\n")
 }
 
-func (a *App) renderStatus() string {
-	var b strings.Builder
-	b.WriteString(ui.TitleStyle().Render(" MultiFlexi System Dashboard "))
-	b.WriteString("\n\n")
-
-	if a.statusInfo == nil {
-		b.WriteString(ui.DescriptionStyle().Render("Loading system status..."))
-		b.WriteString("\n")
-		return b.String()
-	}
-	s := a.statusInfo
-	rows := []struct{ icon, label, value string }{
-		{"", "CLI Version", s.VersionCli},
-		{"", "DB Migration", s.DbMigration},
-		{"", "User", s.User},
-		{"", "PHP", s.PHP},
-		{"", "OS", s.OS},
-		{"", "Memory", fmt.Sprintf("%d KB", s.Memory)},
-		{"", "Companies", fmt.Sprintf("%d", s.Companies)},
-		{"", "Applications", fmt.Sprintf("%d", s.Apps)},
-		{"", "RunTemplates", fmt.Sprintf("%d", s.RunTemplates)},
-		{"", "Topics", fmt.Sprintf("%d", s.Topics)},
-		{"", "Credentials", fmt.Sprintf("%d", s.Credentials)},
-		{"", "Credential Types", fmt.Sprintf("%d", s.CredentialTypes)},
-		{"", "Jobs", s.Jobs},
-		{"", "Executor", s.Executor},
-		{"", "Scheduler", s.Scheduler},
-		{"", "Encryption", s.Encryption},
-		{"", "Zabbix", s.Zabbix},
-		{"", "Telemetry", s.Telemetry},
-		{"", "Database", s.Database},
-		{"", "Timestamp", s.Timestamp},
-	}
-	for _, r := range rows {
-		if r.value == "" {
-			continue
-		}
-		style := ui.DescriptionStyle()
-		v := strings.ToLower(r.value)
-		if v == "active" || strings.HasPrefix(v, "active") {
-			style = ui.ActiveStatusStyle()
-		} else if v == "inactive" || v == "disabled" || v == "failed" {
-			style = ui.DisabledStatusStyle()
-		}
-		b.WriteString(fmt.Sprintf("%-20s %s\n", r.label+":", style.Render(r.value)))
+// alertBanner lists the breaching alert rules in one line of at most w
+// columns, or returns "" while all is well.
+func (a *App) alertBanner(w int) string {
+	if a.opts.Alerts == nil {
+		return ""
 	}
-	return b.String()
+	active := a.opts.Alerts.Active()
+	if len(active) == 0 {
+		return ""
+	}
+	parts := make([]string, len(active))
+	for i, e := range active {
+		parts[i] = i18n.T(e.Rule) + ": " + e.Detail()
+	}
+	return ui.ErrorStyle().Render(ui.Truncate(" ⚠ "+strings.Join(parts, " • ")+" ", w))
+}
+
+// notifyHold is how long a notification stays in the rendered frames: long
+// enough for the renderer to flush one of them.
+const notifyHold = 100 * time.Millisecond
+
+// notifiedMsg ends the notification numbered gen.
+type notifiedMsg struct{ gen int }
+
+// notify puts the terminal sequence announcing text in front of the frames
+// rendered for the next notifyHold. Writing it from a command instead could
+// split an escape sequence of a frame being drawn. The text is cut to half
+// the width, as the renderer counts it when fitting the menu bar.
+func (a *App) notify(text string) tea.Cmd {
+	if a.opts.Alerts == nil {
+		return nil
+	}
+	seq := a.opts.Alerts.Notification(ui.Truncate(text, max(a.width/2, 10)))
+	if seq == "" {
+		return nil
+	}
+	a.notifyGen++
+	a.notification = seq
+	gen := a.notifyGen
+	return tea.Tick(notifyHold, func(time.Time) tea.Msg { return notifiedMsg{gen: gen} })
 }
 
-// Run starts the TUI application.
-func Run(client cli.Client, items []MenuItem) error {
-	app := New(client, items)
+// Run starts the TUI application. On exit the record open in the front tab
+// is stored in opts.Session; saving the session is up to the caller.
+func Run(client cli.Client, items []MenuItem, opts Options) error {
+	app := New(client, items, opts)
 	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	_, err := p.Run()
+	app.captureRecord()
 	return err
 }
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Link is a deep link to a record, written as "entity:id" or
+// "entity:id/action", e.g. "job:4711" or "runtemplate:12/edit".
+type Link struct {
+	Record session.Record
+	Action string // "" opens the detail view
+}
+
+// Link actions understood by OpenRecord.
+const (
+	LinkDetail = ""
+	LinkEdit   = "edit"
+)
+
+// ParseLink parses a deep link.
+func ParseLink(s string) (Link, error) {
+	var l Link
+	ent, rest, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || ent == "" {
+		return l, fmt.Errorf("%q: expected entity:id", s)
+	}
+	idStr, action, _ := strings.Cut(rest, "/")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		return l, fmt.Errorf("%q: invalid record ID %q", s, idStr)
+	}
+	if action != LinkDetail && action != LinkEdit {
+		return l, fmt.Errorf("%q: unknown action %q", s, action)
+	}
+	l.Record = session.Record{Entity: strings.ToLower(ent), ID: id}
+	l.Action = action
+	return l, nil
+}
+
+// String formats the link as ParseLink reads it.
+func (l Link) String() string {
+	s := fmt.Sprintf("%s:%d", l.Record.Entity, l.Record.ID)
+	if l.Action != LinkDetail {
+		s += "/" + l.Action
+	}
+	return s
+}
+
+// openViewsMsg pushes views onto the navigation stack, bottom first.
+type openViewsMsg struct{ views []tea.Model }
+
+// startup opens what the command line asked for, or else the last session.
+func (a *App) startup() tea.Cmd {
+	switch {
+	case a.opts.Open != "":
+		return a.openLink(a.opts.Open)
+	case a.opts.Menu != "":
+		return a.openMenu(a.opts.Menu)
+	case a.opts.Restore:
+		return a.restoreSession()
+	}
+	return nil
+}
+
+// openMenu selects the menu item matching name by label, translated label
+// or entity, ignoring case.
+func (a *App) openMenu(name string) tea.Cmd {
+	for i, item := range a.items {
+		if strings.EqualFold(item.Label, name) || strings.EqualFold(a.menuLabel(i), name) ||
+			(item.Entity != "" && strings.EqualFold(item.Entity, name)) {
+			a.menuCursor = i
+			a.adjustMenuViewport()
+			_, cmd := a.selectMenuItem()
+			return cmd
+		}
+	}
+	return statusCmd(i18n.Tf("Unknown menu item: %s", name))
+}
+
+// openLink opens the list of the linked entity and pushes the record's
+// views on top of it, so Esc walks back editor → detail → list → menu.
+func (a *App) openLink(s string) tea.Cmd {
+	l, err := ParseLink(s)
+	if err != nil {
+		return statusCmd(i18n.Tf("Invalid link: %v", err))
+	}
+	var list tea.Cmd
+	for i, item := range a.items {
+		if item.Entity == l.Record.Entity {
+			a.menuCursor = i
+			a.adjustMenuViewport()
+			_, list = a.selectMenuItem()
+			break
+		}
+	}
+	return tea.Sequence(list, a.openLinkCmd(l))
+}
+
+// openLinkCmd loads the record of l and pushes its views. Failures, such as
+// a record that no longer exists, end up in the footer.
+func (a *App) openLinkCmd(l Link) tea.Cmd {
+	open, client := a.opts.OpenRecord, a.Client
+	if open == nil {
+		return nil
+	}
+	// Sequenced after the list's own load by the callers, so that result
+	// reaches the list rather than the views pushed on top of it.
+	return func() tea.Msg {
+		views, err := open(client, l)
+		if err != nil {
+			return ui.StatusMsg{Text: i18n.Tf("Cannot open %s: %v", l.String(), err)}
+		}
+		return openViewsMsg{views: views}
+	}
+}
+
+func statusCmd(text string) tea.Cmd {
+	return func() tea.Msg { return ui.StatusMsg{Text: text} }
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseLink(t *testing.T) {
+	for in, want := range map[string]Link{
+		"job:4711":            {Record: session.Record{Entity: "job", ID: 4711}},
+		"RunTemplate:12/edit": {Record: session.Record{Entity: "runtemplate", ID: 12}, Action: LinkEdit},
+	} {
+		got, err := ParseLink(in)
+		if err != nil || got != want {
+			t.Errorf("ParseLink(%q) = %+v, %v", in, got, err)
+		}
+	}
+	for _, bad := range []string{"", "job", "job:", "job:abc", "job:-1", "job:1/delete", ":5"} {
+		if _, err := ParseLink(bad); err == nil {
+			t.Errorf("ParseLink(%q) should fail", bad)
+		}
+	}
+	if s := (Link{Record: session.Record{Entity: "runtemplate", ID: 12}, Action: LinkEdit}).String(); s != "runtemplate:12/edit" {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func linkApp(open func(c cli.Client, l Link) ([]tea.Model, error)) (*App, *recordView) {
+	list := &recordView{}
+	items := []MenuItem{
+		{Label: "Status", Action: func(a *App) (tea.Model, tea.Cmd) { return nil, nil }},
+		{Label: "Jobs", Entity: "job", Action: func(a *App) (tea.Model, tea.Cmd) { return list, nil }},
+		{Label: "Queue", Entity: "queue", Action: func(a *App) (tea.Model, tea.Cmd) { return &recordView{}, nil }},
+	}
+	return New(nil, items, Options{OpenRecord: open}), list
+}
+
+func TestOpenLinkStacksViews(t *testing.T) {
+	detail, editor := &recordView{got: "detail"}, &recordView{got: "editor"}
+	a, list := linkApp(func(c cli.Client, l Link) ([]tea.Model, error) {
+		return []tea.Model{detail, editor}, nil
+	})
+
+	a.opts.Open = "job:4711/edit"
+	a.startup()
+	if a.active().activeView != list {
+		t.Fatal("the entity's list should open under the record")
+	}
+	l, _ := ParseLink("job:4711/edit")
+	a.Update(a.openLinkCmd(l)())
+	if a.active().activeView != editor {
+		t.Fatal("the editor should be in front")
+	}
+
+	a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if a.active().activeView != detail {
+		t.Error("esc from the editor should lead to the detail")
+	}
+	a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if a.active().activeView != list {
+		t.Error("esc from the detail should lead to the list")
+	}
+}
+
+func TestOpenLinkFailuresBecomeStatus(t *testing.T) {
+	a, _ := linkApp(func(c cli.Client, l Link) ([]tea.Model, error) {
+		return nil, errors.New("not found")
+	})
+
+	a.opts.Open = "job:nope"
+	if _, ok := a.startup()().(ui.StatusMsg); !ok {
+		t.Error("an invalid link should produce a status message")
+	}
+
+	l, _ := ParseLink("job:1")
+	if _, ok := a.openLinkCmd(l)().(ui.StatusMsg); !ok {
+		t.Error("a missing record should produce a status message")
+	}
+}
+
+func TestOpenMenu(t *testing.T) {
+	a, _ := linkApp(nil)
+	a.opts.Menu = "queue"
+	a.startup()
+	if a.menuCursor != 2 || a.active().activeView == nil {
+		t.Error("--menu should match labels case-insensitively and open the item")
+	}
+
+	b, _ := linkApp(nil)
+	b.opts.Menu = "Nonsense"
+	if _, ok := b.startup()().(ui.StatusMsg); !ok || b.active().activeView != nil {
+		t.Error("an unknown menu item should only produce a status message")
+	}
+}
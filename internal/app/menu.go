@@ -6,5 +6,6 @@ import tea "github.com/charmbracelet/bubbletea"
 type MenuItem struct {
 	Label  string
 	Hint   string
+	Entity string // CLI entity listed by the item, used to resolve deep links
 	Action func(a *App) (tea.Model, tea.Cmd)
 }
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// clickView remembers the clicks it received.
+type clickView struct {
+	recordView
+	clicks []ui.ClickMsg
+}
+
+func (c *clickView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if click, ok := msg.(ui.ClickMsg); ok {
+		c.clicks = append(c.clicks, click)
+	}
+	return c, nil
+}
+
+func TestMouseClickSelectsMenuItem(t *testing.T) {
+	view := &clickView{}
+	a := New(nil, []MenuItem{
+		{Label: "Status", Action: func(a *App) (tea.Model, tea.Cmd) { return nil, nil }},
+		{Label: "Jobs", Action: func(a *App) (tea.Model, tea.Cmd) { return view, nil }},
+	}, Options{})
+	a.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	bar := strings.Split(a.renderMenuBar(), "\n")[0]
+
+	x := ui.Width(bar[:strings.Index(bar, "Jobs")])
+	a.Update(tea.MouseMsg{Type: tea.MouseLeft, X: x, Y: 0})
+	if a.active().activeView != view {
+		t.Fatal("clicking a menu item should open it")
+	}
+}
+
+func TestMenuBarMeasuresEmojiLabels(t *testing.T) {
+	var items []MenuItem
+	for _, l := range []string{"📊 Status", "💼 Jobs", "🏢 Companies", "🧩 Applications", "⏱ RunTemplates", "📋 Queue"} {
+		items = append(items, MenuItem{Label: l, Action: func(a *App) (tea.Model, tea.Cmd) { return &clickView{}, nil }})
+	}
+	a := New(nil, items, Options{})
+	a.Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	for i := range items {
+		a.menuCursor = i
+		a.adjustMenuViewport()
+		bar := strings.Split(a.renderMenuBar(), "\n")[0]
+		if w := ui.Width(bar); w > 60 {
+			t.Errorf("cursor %d: menu bar is %d columns on a 60-column terminal", i, w)
+		}
+		label := a.menuLabel(i)
+		if !strings.Contains(bar, label) {
+			t.Fatalf("cursor %d: %q scrolled out of view", i, label)
+		}
+		a.Update(tea.MouseMsg{Type: tea.MouseLeft, X: ui.Width(bar[:strings.Index(bar, label)]), Y: 0})
+		if a.active().activeMenuItem != i {
+			t.Errorf("clicking %q selected item %d", label, a.active().activeMenuItem)
+		}
+	}
+}
+
+func TestMouseClickIsContentRelative(t *testing.T) {
+	view := &clickView{}
+	a := New(nil, nil, Options{})
+	a.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	a.active().activeView = view
+
+	a.Update(tea.MouseMsg{Type: tea.MouseLeft, X: 4, Y: menuBarLines + 2})
+	a.Update(tea.MouseMsg{Type: tea.MouseLeft, X: 4, Y: menuBarLines + 2})
+	a.Update(tea.MouseMsg{Type: tea.MouseLeft, X: 4, Y: menuBarLines + 2})
+	a.Update(tea.MouseMsg{Type: tea.MouseLeft, X: 4, Y: 29}) // footer
+
+	if len(view.clicks) != 3 {
+		t.Fatalf("expected 3 content clicks, got %d", len(view.clicks))
+	}
+	if c := view.clicks[0]; c.X != 4 || c.Y != 2 || c.Double {
+		t.Errorf("first click = %+v, want content-relative (4,2)", c)
+	}
+	if !view.clicks[1].Double {
+		t.Error("second click on the same cell should be a double click")
+	}
+	if view.clicks[2].Double {
+		t.Error("a third click starts a new double click")
+	}
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// menuIndex returns the index of the menu item with the given label, or -1.
+func (a *App) menuIndex(label string) int {
+	for i, item := range a.items {
+		if item.Label == label {
+			return i
+		}
+	}
+	return -1
+}
+
+// rememberMenu records the selected menu item in the session. Items that
+// open no view clear it (the home view), except those that only run a
+// command such as Quit, which must never be replayed on startup.
+func (a *App) rememberMenu(item MenuItem, view tea.Model, cmd tea.Cmd) {
+	s := a.opts.Session
+	if s == nil || a.ws != a.active() {
+		return
+	}
+	switch {
+	case view != nil:
+		s.Menu = item.Label
+	case cmd == nil:
+		s.Menu = ""
+	}
+}
+
+// restoreSession reopens the menu item of the last session and, on top of
+// it, the record that was open. Records that no longer exist are reported
+// in the footer and the list stays in front.
+func (a *App) restoreSession() tea.Cmd {
+	s := a.opts.Session
+	if s == nil || s.Menu == "" {
+		return nil
+	}
+	idx := a.menuIndex(s.Menu)
+	if idx < 0 {
+		return nil
+	}
+	a.menuCursor = idx
+	_, cmd := a.selectMenuItem()
+	if s.Record == nil || a.ws.activeView == nil {
+		return cmd
+	}
+	return tea.Sequence(cmd, a.openLinkCmd(Link{Record: *s.Record}))
+}
+
+// captureRecord stores the topmost record shown in the front tab.
+func (a *App) captureRecord() {
+	s := a.opts.Session
+	if s == nil {
+		return
+	}
+	s.Record = nil
+	w := a.active()
+	views := []tea.Model{w.activeView}
+	for i := len(w.nav.stack) - 1; i >= 0; i-- {
+		views = append(views, w.nav.stack[i].View)
+	}
+	for _, v := range views {
+		if r, ok := v.(session.Recorder); ok {
+			rec := r.SessionRecord()
+			s.Record = &rec
+			return
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// detailStub is a view showing one record.
+type detailStub struct {
+	recordView
+	rec session.Record
+}
+
+func (d *detailStub) SessionRecord() session.Record { return d.rec }
+
+func sessionItems(list tea.Model) []MenuItem {
+	return []MenuItem{
+		{Label: "Status", Action: func(a *App) (tea.Model, tea.Cmd) { return nil, nil }},
+		{Label: "Jobs", Action: func(a *App) (tea.Model, tea.Cmd) { return list, nil }},
+		{Label: "Quit", Action: func(a *App) (tea.Model, tea.Cmd) { return nil, tea.Quit }},
+	}
+}
+
+func TestSessionRemembersMenu(t *testing.T) {
+	s := session.New()
+	a := New(nil, sessionItems(&recordView{}), Options{Session: s})
+
+	a.menuCursor = 1
+	a.selectMenuItem()
+	if s.Menu != "Jobs" {
+		t.Fatalf("menu = %q, want Jobs", s.Menu)
+	}
+	a.menuCursor = 2
+	a.selectMenuItem()
+	if s.Menu != "Jobs" {
+		t.Errorf("Quit must not be remembered, menu = %q", s.Menu)
+	}
+	a.menuCursor = 0
+	a.selectMenuItem()
+	if s.Menu != "" {
+		t.Errorf("home view should clear the menu, got %q", s.Menu)
+	}
+}
+
+func TestSessionRestoresMenu(t *testing.T) {
+	list := &recordView{}
+	s := session.New()
+	s.Menu = "Jobs"
+	a := New(nil, sessionItems(list), Options{Session: s, Restore: true})
+
+	a.restoreSession()
+	if a.active().activeView != list || a.menuCursor != 1 {
+		t.Error("the last menu item should be reopened")
+	}
+
+	s.Menu = "Removed entity"
+	b := New(nil, sessionItems(list), Options{Session: s, Restore: true})
+	if cmd := b.restoreSession(); cmd != nil || b.active().activeView != nil {
+		t.Error("an unknown menu item should leave the home view in front")
+	}
+}
+
+func TestSessionCapturesOpenRecord(t *testing.T) {
+	s := session.New()
+	a := New(nil, sessionItems(&recordView{}), Options{Session: s})
+	w := a.active()
+	w.nav.Push(ViewState{View: &recordView{}})
+	w.nav.Push(ViewState{View: &detailStub{rec: session.Record{Entity: "job", ID: 4711}}})
+	w.activeView = &recordView{} // e.g. an editor opened from the detail
+
+	a.captureRecord()
+	if s.Record == nil || *s.Record != (session.Record{Entity: "job", ID: 4711}) {
+		t.Errorf("record = %+v", s.Record)
+	}
+
+	w.nav.Clear()
+	a.captureRecord()
+	if s.Record != nil {
+		t.Error("no record view open should clear the record")
+	}
+}
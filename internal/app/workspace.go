@@ -0,0 +1,156 @@
+package app
+
+import (
+	"reflect"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxTabs is the number of workspaces reachable with alt+1 … alt+9.
+const maxTabs = 9
+
+// Workspace is one tab: its own navigation stack, active menu item and view.
+type Workspace struct {
+	id             int
+	nav            Navigator
+	activeView     tea.Model // nil = home view
+	home           tea.Model // shown while activeView is nil; may be nil
+	activeMenuItem int
+}
+
+// newWorkspace creates a workspace with its own home view.
+func (a *App) newWorkspace(id int) *Workspace {
+	w := &Workspace{id: id}
+	if a.opts.Home != nil {
+		w.home = a.opts.Home(a.Client)
+	}
+	return w
+}
+
+// front returns the view the workspace shows: the active view or, with
+// none open, the home view.
+func (w *Workspace) front() tea.Model {
+	if w.activeView != nil {
+		return w.activeView
+	}
+	return w.home
+}
+
+// updateFront passes msg to the view in front and keeps the updated model.
+// Messages for the home view go to it wherever it is.
+func (w *Workspace) updateFront(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	_, home := msg.(ui.HomeMsg)
+	switch {
+	case home && w.home != nil:
+		w.home, cmd = w.home.Update(msg)
+	case w.activeView != nil:
+		w.activeView, cmd = w.activeView.Update(msg)
+	case w.home != nil:
+		w.home, cmd = w.home.Update(msg)
+	}
+	return cmd
+}
+
+// tabMsg tags an async result with the workspace whose command produced it,
+// so results keep reaching their tab while another tab is in front.
+type tabMsg struct {
+	tab int
+	msg tea.Msg
+}
+
+// bubbleteaPkg is the package path of bubbletea's own messages. Those are
+// handled by the program itself (quit, exec, batches) and must not be tagged.
+var bubbleteaPkg = reflect.TypeOf(tea.QuitMsg{}).PkgPath()
+
+// wrap tags the result of cmd with the workspace id.
+func (w *Workspace) wrap(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	id := w.id
+	return func() tea.Msg {
+		msg := cmd()
+		switch m := msg.(type) {
+		case nil:
+			return nil
+		case tea.BatchMsg:
+			wrapped := make(tea.BatchMsg, len(m))
+			for i, c := range m {
+				wrapped[i] = w.wrap(c)
+			}
+			return wrapped
+		}
+		if reflect.TypeOf(msg).PkgPath() == bubbleteaPkg {
+			return msg
+		}
+		return tabMsg{tab: id, msg: msg}
+	}
+}
+
+// active returns the workspace in front.
+func (a *App) active() *Workspace {
+	return a.tabs[a.tab]
+}
+
+// workspace looks up a workspace by id; nil when the tab was closed.
+func (a *App) workspace(id int) *Workspace {
+	for _, w := range a.tabs {
+		if w.id == id {
+			return w
+		}
+	}
+	return nil
+}
+
+// newTab opens an empty workspace on the home view and switches to it.
+func (a *App) newTab() (tea.Model, tea.Cmd) {
+	if len(a.tabs) >= maxTabs {
+		a.statusMessage = i18n.Tf("At most %d tabs can be open", maxTabs)
+		return a, nil
+	}
+	a.nextTabID++
+	w := a.newWorkspace(a.nextTabID)
+	a.tabs = append(a.tabs, w)
+	_, cmd := a.switchTab(len(a.tabs) - 1)
+	if w.home != nil {
+		cmd = tea.Batch(w.home.Init(), cmd)
+	}
+	return a, cmd
+}
+
+// closeTab closes the workspace in front; the last tab cannot be closed.
+func (a *App) closeTab() (tea.Model, tea.Cmd) {
+	if len(a.tabs) < 2 {
+		return a, nil
+	}
+	a.tabs = append(a.tabs[:a.tab], a.tabs[a.tab+1:]...)
+	if a.tab >= len(a.tabs) {
+		a.tab = len(a.tabs) - 1
+	}
+	return a.switchTab(a.tab)
+}
+
+// switchTab brings workspace i to front and resizes its view, which may
+// have missed terminal resizes while it was in the background.
+func (a *App) switchTab(i int) (tea.Model, tea.Cmd) {
+	if i < 0 || i >= len(a.tabs) {
+		return a, nil
+	}
+	a.tab = i
+	a.ws = a.tabs[i]
+	a.menuCursor = a.ws.activeMenuItem
+	a.menuFocus = a.ws.activeView == nil
+	a.adjustMenuViewport()
+	return a, a.sizeView(a.ws.front())
+}
+
+// tabLabel is the title shown in the tab strip.
+func (a *App) tabLabel(w *Workspace) string {
+	if w.activeMenuItem >= 0 && w.activeMenuItem < len(a.items) {
+		return a.menuLabel(w.activeMenuItem)
+	}
+	return "—"
+}
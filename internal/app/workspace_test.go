@@ -0,0 +1,329 @@
+package app
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/alert"
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recordView remembers the last string message it received.
+type recordView struct{ got string }
+
+func (r *recordView) Init() tea.Cmd { return nil }
+func (r *recordView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if s, ok := msg.(string); ok {
+		r.got = s
+	}
+	return r, nil
+}
+func (r *recordView) View() string { return r.got }
+
+func TestWorkspaceTabsKeepSeparateViews(t *testing.T) {
+	a := New(nil, []MenuItem{{Label: "Status"}, {Label: "Jobs"}}, Options{})
+	first := &recordView{}
+	a.active().activeView = first
+
+	a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}, Alt: true})
+	if len(a.tabs) != 2 || a.tab != 1 {
+		t.Fatalf("expected a second tab in front, got %d tabs, front %d", len(a.tabs), a.tab)
+	}
+	if a.active().activeView != nil {
+		t.Error("new tab should start on the home view")
+	}
+
+	a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}, Alt: true})
+	if a.tab != 0 || a.active().activeView != first {
+		t.Error("alt+1 should bring back the first tab and its view")
+	}
+}
+
+// inputView takes text input and remembers the keys it received.
+type inputView struct {
+	recordView
+	keys []string
+}
+
+func (v *inputView) CapturingInput() bool { return true }
+func (v *inputView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if k, ok := msg.(tea.KeyMsg); ok {
+		v.keys = append(v.keys, k.String())
+	}
+	return v, nil
+}
+
+func TestWorkspaceTabKeysLeaveWordMovesToInput(t *testing.T) {
+	a := New(nil, nil, Options{})
+	a.newTab()
+	input := &inputView{}
+	a.active().activeView = input
+	a.setMenuFocus(false)
+
+	a.Update(tea.KeyMsg{Type: tea.KeyLeft, Alt: true})
+	if a.tab != 1 || !reflect.DeepEqual(input.keys, []string{"alt+left"}) {
+		t.Fatalf("alt+left should move the cursor of a text field, got tab %d, keys %q", a.tab, input.keys)
+	}
+	a.Update(tea.KeyMsg{Type: tea.KeyCtrlPgUp})
+	if a.tab != 0 {
+		t.Error("ctrl+pgup should switch tabs from a text field too")
+	}
+}
+
+func TestWorkspaceBackgroundTabReceivesResults(t *testing.T) {
+	a := New(nil, nil, Options{})
+	background := &recordView{}
+	a.active().activeView = background
+	bgID := a.active().id
+	a.newTab()
+	front := &recordView{}
+	a.active().activeView = front
+
+	// A command issued by the background tab resolves while tab 2 is in front.
+	cmd := a.workspace(bgID).wrap(func() tea.Msg { return "loaded" })
+	a.Update(cmd())
+
+	if background.got != "loaded" {
+		t.Errorf("background tab did not receive its result, got %q", background.got)
+	}
+	if front.got != "" {
+		t.Errorf("front tab received a foreign result %q", front.got)
+	}
+}
+
+// listView is a refreshable, selectable list.
+type listView struct {
+	recordView
+	selected int
+}
+
+func (l *listView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	l.recordView.Update(msg)
+	return l, nil
+}
+func (l *listView) Refresh() tea.Cmd { return func() tea.Msg { return "refreshed" } }
+func (l *listView) Select(id int)    { l.selected = id }
+
+func TestNavigateBackAndOpen(t *testing.T) {
+	a := New(nil, nil, Options{})
+	list, editor, detail := &listView{}, &recordView{}, &recordView{}
+	ws := a.active()
+	ws.nav.Push(ViewState{View: list})
+	ws.activeView = editor
+
+	_, cmd := a.Update(ui.NavigateBackAndOpenMsg{Status: "Saved", Select: 12, View: detail})
+	// Run the sequence the way the program does: in order, each message
+	// handled before the next command runs.
+	seq := reflect.ValueOf(cmd())
+	for i := 0; i < seq.Len(); i++ {
+		a.Update(seq.Index(i).Interface().(tea.Cmd)())
+	}
+
+	if ws.activeView != detail {
+		t.Fatal("the new record should be in front")
+	}
+	if list.got != "refreshed" || list.selected != 12 {
+		t.Errorf("list got %q, selected %d", list.got, list.selected)
+	}
+	if !strings.Contains(a.statusMessage, "Saved") {
+		t.Errorf("status = %q", a.statusMessage)
+	}
+	a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if ws.activeView != list {
+		t.Error("esc from the new record should lead to the list")
+	}
+}
+
+func TestCtrlZUndoes(t *testing.T) {
+	undone := 0
+	a := New(nil, nil, Options{Undo: func(cli.Client) tea.Cmd {
+		undone++
+		return nil
+	}})
+	a.active().activeView = &recordView{}
+	a.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if undone != 1 {
+		t.Errorf("ctrl+z undid %d times, want 1", undone)
+	}
+}
+
+func TestWorkspaceWrapPassesQuit(t *testing.T) {
+	w := &Workspace{id: 3}
+	if _, ok := w.wrap(tea.Quit)().(tea.QuitMsg); !ok {
+		t.Error("quit must not be tagged with a tab")
+	}
+}
+
+func TestWorkspaceCloseTab(t *testing.T) {
+	a := New(nil, nil, Options{})
+	a.newTab()
+	a.closeTab()
+	if len(a.tabs) != 1 {
+		t.Fatalf("expected 1 tab after close, got %d", len(a.tabs))
+	}
+	a.closeTab()
+	if len(a.tabs) != 1 {
+		t.Error("the last tab must not be closable")
+	}
+}
+
+// sizedView counts the sizes it is sent and records polls.
+type sizedView struct {
+	recordView
+	sized int
+}
+
+func (s *sizedView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.sized++
+	case pollMsg:
+		s.got = string(msg)
+	}
+	s.recordView.Update(msg)
+	return s, nil
+}
+
+func TestWorkspaceHomeView(t *testing.T) {
+	var homes []*sizedView
+	opts := Options{Home: func(c cli.Client) tea.Model {
+		h := &sizedView{}
+		homes = append(homes, h)
+		return h
+	}}
+	list := &recordView{}
+	a := New(nil, []MenuItem{
+		{Label: "Status", Action: func(a *App) (tea.Model, tea.Cmd) { return nil, nil }},
+		{Label: "Jobs", Action: func(a *App) (tea.Model, tea.Cmd) { return list, nil }},
+	}, opts)
+	a.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	home := homes[0]
+
+	a.Update("loaded")
+	if home.got != "loaded" || home.sized != 1 {
+		t.Fatalf("the home view should get messages and sizes while no view is open: %+v", home)
+	}
+
+	a.menuCursor = 1
+	a.selectMenuItem()
+	a.Update("for the list")
+	if home.got != "loaded" || list.got != "for the list" {
+		t.Error("messages should reach the open view, not the home view")
+	}
+
+	a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	a.menuCursor = 0
+	a.selectMenuItem()
+	if home.sized != 2 {
+		t.Errorf("returning home should resize the home view so it can catch up, sized %d times", home.sized)
+	}
+
+	a.newTab()
+	if len(homes) != 2 || a.active().home != homes[1] {
+		t.Error("each tab should get its own home view")
+	}
+}
+
+// pollMsg is a home view result, like a dashboard poll.
+type pollMsg string
+
+func (pollMsg) HomeMsg() {}
+
+func TestWorkspaceHomeMsgReachesHomeBehindView(t *testing.T) {
+	var home *sizedView
+	a := New(nil, nil, Options{Home: func(c cli.Client) tea.Model {
+		home = &sizedView{}
+		return home
+	}})
+	list := &recordView{}
+	a.active().activeView = list
+
+	a.Update(pollMsg("poll"))
+	if home.got != "poll" || list.got != "" {
+		t.Errorf("home messages should reach the home view behind the list: home %q, list %q", home.got, list.got)
+	}
+	a.Update("for the list")
+	if home.got != "poll" || list.got != "for the list" {
+		t.Errorf("ordinary messages belong to the view in front: home %q, list %q", home.got, list.got)
+	}
+	if _, ok := a.active().home.(*sizedView); !ok {
+		t.Error("the home view must be kept after a background update")
+	}
+}
+
+func TestAlertBanner(t *testing.T) {
+	m := alert.NewMonitor([]alert.Rule{{Name: "Queue backed up", Metric: alert.Queue, Op: ">", Value: 50}})
+	a := New(nil, nil, Options{Alerts: m})
+	if a.alertBanner(80) != "" {
+		t.Error("no banner while all is well")
+	}
+	m.Evaluate(alert.Metrics{alert.Queue: "120"}, time.Now())
+	if b := a.alertBanner(80); !strings.Contains(b, "Queue backed up: queue 120 > 50") || ui.Width(b) > 80 {
+		t.Errorf("unexpected banner %q", b)
+	}
+	m.Evaluate(alert.Metrics{alert.Queue: "3"}, time.Now())
+	if a.alertBanner(80) != "" {
+		t.Error("the banner should go once the rule recovers")
+	}
+}
+
+func TestNotifyWritesWithFrames(t *testing.T) {
+	m := alert.NewMonitor(nil)
+	m.Notify = alert.NotifyOSC9
+	a := New(nil, nil, Options{Alerts: m})
+	a.width = 80
+
+	_, first := a.Update(ui.NotifyMsg{Text: "Alert: Queue backed up"})
+	_, cmd := a.Update(ui.NotifyMsg{Text: "Alert: Jobs failing"})
+	if first == nil || cmd == nil || a.notification != "\x1b]9;Alert: Jobs failing\a" {
+		t.Fatalf("the notification should wait for the next frames, got %q", a.notification)
+	}
+	if !strings.Contains(a.statusMessage, "Jobs failing") {
+		t.Errorf("the notification belongs in the footer too, got %q", a.statusMessage)
+	}
+	a.Update(first())
+	if a.notification == "" {
+		t.Error("an earlier notification's hold must not clear a later one")
+	}
+	a.Update(cmd())
+	if a.notification != "" {
+		t.Errorf("the notification should be written once, still %q", a.notification)
+	}
+
+	m.Notify = alert.NotifyOff
+	if _, cmd := a.Update(ui.NotifyMsg{Text: "quiet"}); cmd != nil || a.notification != "" {
+		t.Error("nothing is written with notifications off")
+	}
+}
+
+// topicView is a view documented by a multiflexi-cli command.
+type topicView struct{ recordView }
+
+func (v *topicView) HelpTopic() string { return "job" }
+
+func TestF1OpensHelpAtViewTopic(t *testing.T) {
+	var topics []string
+	opts := Options{Help: func(c cli.Client, topic string) tea.Model {
+		topics = append(topics, topic)
+		return &recordView{}
+	}}
+	a := New(nil, []MenuItem{{Label: "Jobs"}}, opts)
+	view := &topicView{}
+	a.active().activeView = view
+
+	a.Update(tea.KeyMsg{Type: tea.KeyF1})
+	if len(topics) != 1 || topics[0] != "job" {
+		t.Fatalf("help topics = %q, want job", topics)
+	}
+	if a.active().activeView == view {
+		t.Fatal("F1 should open the help browser in front")
+	}
+	a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if a.active().activeView != view {
+		t.Error("esc should return from help to the view")
+	}
+}
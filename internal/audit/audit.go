@@ -0,0 +1,301 @@
+// Package audit keeps an append-only log, in JSON Lines, of the creates,
+// updates, deletes and actions run through multiflexi-cli, so it can be
+// told who changed what from the TUI.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+)
+
+// Operations of a record; actions are recorded under the CLI verb they run,
+// e.g. "schedule".
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// Results of a record.
+const (
+	ResultOK    = "ok"
+	ResultError = "error"
+)
+
+// Redacted replaces the values of secret options.
+const Redacted = "***"
+
+// secretOptions are the options whose values are never written to the log.
+var secretOptions = map[string]bool{
+	"password": true, "plaintext": true, "db_password": true,
+	"token": true, "secret": true, "api_key": true,
+}
+
+// IsSecret reports whether the values of the option are secret, such as
+// passwords and tokens.
+func IsSecret(option string) bool {
+	return secretOptions[option]
+}
+
+// readOnlyVerbs are the verbs RunRaw may run without changing anything;
+// they are not logged.
+var readOnlyVerbs = map[string]bool{
+	"list": true, "get": true, "show": true, "showconfig": true,
+	"status": true, "describe": true, "help": true, "test": true,
+}
+
+// ReadOnly reports whether the RunRaw arguments run a command that changes
+// nothing, such as a list.
+func ReadOnly(args []string) bool {
+	_, verb, _ := command(args)
+	return readOnlyVerbs[verb]
+}
+
+// Record is one line of the log.
+type Record struct {
+	Time    time.Time         `json:"time"`
+	User    string            `json:"user"`
+	Profile string            `json:"profile,omitempty"`
+	Op      string            `json:"op"`
+	Entity  string            `json:"entity"`
+	ID      int               `json:"id,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"` // options given, secrets redacted
+	Args    []string          `json:"args,omitempty"`   // positional arguments
+	Result  string            `json:"result"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// Log appends records to the file at Path. The zero value logs nothing.
+type Log struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// Append writes r as one line at the end of the log.
+func (l *Log) Append(r Record) error {
+	if l == nil || l.Path == "" {
+		return nil
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Read returns the records of the log, newest first. A missing log has
+// none; lines that do not parse are skipped.
+func (l *Log) Read() ([]Record, error) {
+	if l == nil || l.Path == "" {
+		return nil, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.Open(l.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r Record
+		if json.Unmarshal(scanner.Bytes(), &r) == nil {
+			records = append(records, r)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", l.Path, err)
+	}
+	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+		records[i], records[j] = records[j], records[i]
+	}
+	return records, nil
+}
+
+// Client wraps a cli.Client and logs every create, update, delete and
+// action run through it. Reads pass through unlogged. A record that cannot
+// be written does not fail the operation; the error is kept for Err.
+type Client struct {
+	cli.Client
+	Log     *Log
+	User    string
+	Profile string
+
+	mu  sync.Mutex
+	err error
+}
+
+// Wrap returns c logging to log as the current OS user.
+func Wrap(c cli.Client, log *Log, profile string) *Client {
+	return &Client{Client: c, Log: log, User: CurrentUser(), Profile: profile}
+}
+
+// CurrentUser names the OS user running the TUI.
+func CurrentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// Err returns the last error writing the log, if any.
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *Client) Create(entity string, args ...string) ([]byte, error) {
+	out, err := c.Client.Create(entity, args...)
+	r := c.record(OpCreate, entity, args, err)
+	if r.ID == 0 {
+		r.ID = createdID(out)
+	}
+	c.append(r)
+	return out, err
+}
+
+func (c *Client) Update(entity string, args ...string) error {
+	err := c.Client.Update(entity, args...)
+	c.append(c.record(OpUpdate, entity, args, err))
+	return err
+}
+
+func (c *Client) Delete(entity string, deleteAction string, id int) error {
+	err := c.Client.Delete(entity, deleteAction, id)
+	r := c.record(OpDelete, entity, nil, err)
+	r.ID = id
+	c.append(r)
+	return err
+}
+
+// RunRaw logs the commands that are not read-only, such as actions and the
+// command runner.
+func (c *Client) RunRaw(args ...string) ([]byte, error) {
+	out, err := c.Client.RunRaw(args...)
+	entity, verb, rest := command(args)
+	if !readOnlyVerbs[verb] {
+		c.append(c.record(verb, entity, rest, err))
+	}
+	return out, err
+}
+
+// record describes an operation with the given arguments and outcome.
+func (c *Client) record(op, entity string, args []string, err error) Record {
+	r := Record{
+		Time: time.Now(), User: c.User, Profile: c.Profile,
+		Op: op, Entity: entity, Result: ResultOK,
+	}
+	r.Fields, r.Args = parseArgs(args)
+	if id, ok := r.Fields["id"]; ok {
+		r.ID, _ = strconv.Atoi(id)
+		delete(r.Fields, "id")
+	}
+	if err != nil {
+		r.Result, r.Error = ResultError, err.Error()
+	}
+	return r
+}
+
+func (c *Client) append(r Record) {
+	err := c.Log.Append(r)
+	c.mu.Lock()
+	if err != nil {
+		c.err = err
+	}
+	c.mu.Unlock()
+}
+
+// command splits the arguments of RunRaw into the entity, the verb and the
+// rest. Commands are given as "entity:verb" or "entity verb"; a command of
+// one word, such as "status", is its own verb.
+func command(args []string) (entity, verb string, rest []string) {
+	if len(args) == 0 {
+		return "", "", nil
+	}
+	if e, v, ok := strings.Cut(args[0], ":"); ok {
+		return e, v, args[1:]
+	}
+	if len(args) > 1 && !strings.HasPrefix(args[1], "-") {
+		return args[0], args[1], args[2:]
+	}
+	return args[0], args[0], args[1:]
+}
+
+// parseArgs collects the options, as "--name value" or "--name=value", and
+// the positional arguments. Secret values are redacted and --format, which
+// only shapes the output, is left out.
+func parseArgs(args []string) (map[string]string, []string) {
+	var fields map[string]string
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if !strings.HasPrefix(a, "--") {
+			positional = append(positional, a)
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimPrefix(a, "--"), "=")
+		if !ok && i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+			i++
+			value = args[i]
+		}
+		if name == "format" {
+			continue
+		}
+		if IsSecret(name) && value != "" {
+			value = Redacted
+		}
+		if fields == nil {
+			fields = map[string]string{}
+		}
+		fields[name] = value
+	}
+	return fields, positional
+}
+
+// createdID reads the ID from the output of a create: an object or a list
+// holding it.
+func createdID(out []byte) int {
+	var obj struct {
+		ID int `json:"id"`
+	}
+	if json.Unmarshal(out, &obj) == nil {
+		return obj.ID
+	}
+	var list []struct {
+		ID int `json:"id"`
+	}
+	if json.Unmarshal(out, &list) == nil && len(list) > 0 {
+		return list[0].ID
+	}
+	return 0
+}
@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+)
+
+type fakeClient struct {
+	cli.Client
+	err error
+}
+
+func (f *fakeClient) RunRaw(args ...string) ([]byte, error) { return []byte("{}"), f.err }
+func (f *fakeClient) Create(entity string, args ...string) ([]byte, error) {
+	return []byte(`[{"id":42}]`), f.err
+}
+func (f *fakeClient) Update(entity string, args ...string) error { return f.err }
+func (f *fakeClient) Delete(entity string, deleteAction string, id int) error {
+	return f.err
+}
+
+func TestClientLogsMutations(t *testing.T) {
+	log := &Log{Path: filepath.Join(t.TempDir(), "audit", "audit.jsonl")}
+	fake := &fakeClient{}
+	c := &Client{Client: fake, Log: log, User: "alice", Profile: "prod"}
+
+	c.Create("user", "--login", "bob", "--plaintext", "hunter2")
+	c.Update("eventsource", "--id", "3", "--name", "Shop", "--db_password=secret")
+	c.RunRaw("runtemplate", "list", "--format=json")
+	c.RunRaw("runtemplate", "schedule", "--format=json", "--id", "7")
+	fake.err = errors.New("exit 1")
+	c.Delete("job", "delete", 9)
+
+	records, err := log.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("got %d records, want 4 (reads are not logged): %+v", len(records), records)
+	}
+	del, sched, upd, create := records[0], records[1], records[2], records[3]
+
+	if create.Op != OpCreate || create.Entity != "user" || create.ID != 42 || create.User != "alice" || create.Profile != "prod" {
+		t.Errorf("create record = %+v", create)
+	}
+	if create.Fields["plaintext"] != Redacted || create.Fields["login"] != "bob" {
+		t.Errorf("create fields = %v, want the password redacted", create.Fields)
+	}
+	want := map[string]string{"name": "Shop", "db_password": Redacted}
+	if upd.ID != 3 || !reflect.DeepEqual(upd.Fields, want) {
+		t.Errorf("update record = %+v, want ID 3 and fields %v", upd, want)
+	}
+	if sched.Op != "schedule" || sched.Entity != "runtemplate" || sched.ID != 7 || sched.Result != ResultOK {
+		t.Errorf("action record = %+v", sched)
+	}
+	if del.Op != OpDelete || del.ID != 9 || del.Result != ResultError || del.Error != "exit 1" {
+		t.Errorf("delete record = %+v", del)
+	}
+
+	data, _ := os.ReadFile(log.Path)
+	if strings.Contains(string(data), "hunter2") || strings.Contains(string(data), "secret") {
+		t.Errorf("log holds a secret:\n%s", data)
+	}
+	if c.Err() != nil {
+		t.Errorf("Err() = %v", c.Err())
+	}
+}
+
+func TestCommand(t *testing.T) {
+	for _, tc := range []struct {
+		args         []string
+		entity, verb string
+	}{
+		{[]string{"queue:truncate", "--format=json"}, "queue", "truncate"},
+		{[]string{"queue", "fix", "--format=json"}, "queue", "fix"},
+		{[]string{"status", "--format=json"}, "status", "status"},
+	} {
+		entity, verb, _ := command(tc.args)
+		if entity != tc.entity || verb != tc.verb {
+			t.Errorf("command(%v) = %q, %q; want %q, %q", tc.args, entity, verb, tc.entity, tc.verb)
+		}
+	}
+	if !ReadOnly([]string{"job", "list", "--format=json"}) || ReadOnly([]string{"queue:truncate"}) {
+		t.Error("ReadOnly should tell lists from commands that change something")
+	}
+}
+
+func TestZeroLogKeepsNothing(t *testing.T) {
+	var log Log
+	if err := log.Append(Record{Op: OpCreate}); err != nil {
+		t.Fatal(err)
+	}
+	if records, err := log.Read(); err != nil || records != nil {
+		t.Errorf("Read() = %v, %v; want nothing", records, err)
+	}
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os/exec"
@@ -138,8 +139,15 @@ func (c *CLIClient) GetCommands() ([]Command, error) {
 	if err != nil {
 		return nil, err
 	}
+	return ParseDescribe(output)
+}
+
+// ParseDescribe reads the output of multiflexi-cli describe: commands with
+// their arguments and options, sorted by name. Internal commands (starting
+// with "_") are left out.
+func ParseDescribe(data []byte) ([]Command, error) {
 	var cmdMap map[string]CommandInfo
-	if err := json.Unmarshal(output, &cmdMap); err != nil {
+	if err := json.Unmarshal(data, &cmdMap); err != nil {
 		return nil, fmt.Errorf("parse describe JSON: %w", err)
 	}
 	var commands []Command
@@ -147,7 +155,25 @@ func (c *CLIClient) GetCommands() ([]Command, error) {
 		if strings.HasPrefix(name, "_") {
 			continue
 		}
-		commands = append(commands, Command{Name: name, Description: info.Description})
+		cmd := Command{Name: name, Description: info.Description}
+		err := eachField(info.Arguments, func(raw json.RawMessage) error {
+			var a Argument
+			err := json.Unmarshal(raw, &a)
+			cmd.Arguments = append(cmd.Arguments, a)
+			return err
+		})
+		if err == nil {
+			err = eachField(info.Options, func(raw json.RawMessage) error {
+				var o Option
+				err := json.Unmarshal(raw, &o)
+				cmd.Options = append(cmd.Options, o)
+				return err
+			})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse describe JSON: %s: %w", name, err)
+		}
+		commands = append(commands, cmd)
 	}
 	sort.Slice(commands, func(i, j int) bool {
 		return commands[i].Name < commands[j].Name
@@ -155,6 +181,36 @@ func (c *CLIClient) GetCommands() ([]Command, error) {
 	return commands, nil
 }
 
+// eachField calls fn with the value of every member of a JSON object, in
+// document order, because argument order is positional. Arrays (describe
+// prints [] for none) and null have no members.
+func eachField(raw json.RawMessage, fn func(json.RawMessage) error) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return nil
+	}
+	for dec.More() {
+		if _, err := dec.Token(); err != nil { // the name
+			return err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if err := fn(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *CLIClient) GetCommandHelp(name string) (string, error) {
 	output, err := c.RunRaw(name, "--help")
 	if err != nil {
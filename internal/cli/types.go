@@ -1,5 +1,10 @@
 package cli
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 // StatusInfo represents comprehensive system status.
 type StatusInfo struct {
 	VersionCli      string `json:"version-cli"`
@@ -26,15 +31,69 @@ type StatusInfo struct {
 
 // Command represents a CLI command from multiflexi-cli describe.
 type Command struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Arguments   []Argument `json:"arguments,omitempty"` // in positional order
+	Options     []Option   `json:"options,omitempty"`
 }
 
-// CommandInfo is the raw structure from multiflexi-cli describe.
-type CommandInfo struct {
+// Argument is a positional argument of a command.
+type Argument struct {
+	Name        string      `json:"name"`
+	Required    bool        `json:"is_required"`
 	Description string      `json:"description"`
-	Arguments   interface{} `json:"arguments,omitempty"`
-	Options     interface{} `json:"options,omitempty"`
+	Default     interface{} `json:"default"`
+}
+
+// Choices returns the values an argument lists in its description, as in
+// "Action: list|get|create" or "Action: status, init", or nil when the
+// description names none.
+func (a Argument) Choices() []string {
+	i := strings.Index(a.Description, ":")
+	if i < 0 {
+		return nil
+	}
+	list := a.Description[i+1:]
+	sep := ","
+	if strings.Contains(list, "|") {
+		sep = "|"
+	}
+	var choices []string
+	for _, c := range strings.Split(list, sep) {
+		c = strings.TrimSpace(c)
+		if c == "" || strings.ContainsAny(c, " \t") {
+			return nil
+		}
+		choices = append(choices, c)
+	}
+	if len(choices) < 2 {
+		return nil
+	}
+	return choices
+}
+
+// Option is a --name option of a command.
+type Option struct {
+	Name          string      `json:"name"`
+	Shortcut      string      `json:"shortcut"`
+	ValueRequired bool        `json:"is_value_required"` // a value must follow when the option is given
+	Description   string      `json:"description"`
+	Default       interface{} `json:"default"`
+}
+
+// IsFlag reports whether the option is a switch without a value, which
+// describe shows as a boolean default.
+func (o Option) IsFlag() bool {
+	_, ok := o.Default.(bool)
+	return ok
+}
+
+// CommandInfo is the raw structure from multiflexi-cli describe. Arguments
+// and options are objects keyed by name, or empty arrays.
+type CommandInfo struct {
+	Description string          `json:"description"`
+	Arguments   json.RawMessage `json:"arguments,omitempty"`
+	Options     json.RawMessage `json:"options,omitempty"`
 }
 
 // Application represents an application.
@@ -2,6 +2,8 @@ package cli
 
 import (
 	"encoding/json"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -109,3 +111,57 @@ func TestArtifactUnmarshal(t *testing.T) {
 		t.Errorf("unexpected: %+v", a)
 	}
 }
+
+func TestParseDescribe(t *testing.T) {
+	data, err := os.ReadFile("../../multiflexi-cli.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	commands, err := ParseDescribe(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]Command{}
+	for _, c := range commands {
+		byName[c.Name] = c
+	}
+	if _, ok := byName["_complete"]; ok {
+		t.Error("internal commands must be left out")
+	}
+
+	job := byName["job"]
+	if len(job.Arguments) != 1 || job.Arguments[0].Name != "action" || !job.Arguments[0].Required {
+		t.Fatalf("job should take a required action: %+v", job.Arguments)
+	}
+	if got := job.Arguments[0].Choices(); len(got) != 6 || got[0] != "status" {
+		t.Errorf("job actions = %v", got)
+	}
+	if got := byName["encryption"].Arguments[0].Choices(); len(got) != 2 || got[1] != "init" {
+		t.Errorf("encryption actions = %v", got)
+	}
+
+	prune := byName["prune"]
+	var names []string
+	for _, o := range prune.Options {
+		names = append(names, o.Name)
+	}
+	if len(prune.Arguments) != 0 || strings.Join(names, ",") != "logs,jobs,keep" {
+		t.Errorf("prune options should keep their order: %v", names)
+	}
+	if !prune.Options[0].IsFlag() || prune.Options[2].IsFlag() || prune.Options[2].Default != 1000.0 {
+		t.Errorf("logs is a flag, keep a number: %+v", prune.Options)
+	}
+}
+
+func TestArgumentChoices(t *testing.T) {
+	for desc, want := range map[string]string{
+		"Action: list|get|create": "list,get,create",
+		"Action: status, init":    "status,init",
+		"The command name":        "",
+		"Note: use with care":     "",
+	} {
+		if got := strings.Join(Argument{Description: desc}.Choices(), ","); got != want {
+			t.Errorf("Choices(%q) = %q, want %q", desc, got, want)
+		}
+	}
+}
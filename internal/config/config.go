@@ -0,0 +1,49 @@
+// Package config locates the TUI's configuration and state files following
+// the XDG Base Directory specification.
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// AppName is the directory name used under the XDG base directories.
+const AppName = "multiflexi-tui"
+
+// Dir returns the configuration directory ($XDG_CONFIG_HOME/multiflexi-tui,
+// falling back to ~/.config/multiflexi-tui).
+func Dir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, AppName)
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, AppName)
+	}
+	return filepath.Join(home(), ".config", AppName)
+}
+
+// StateDir returns the state directory ($XDG_STATE_HOME/multiflexi-tui,
+// falling back to ~/.local/state/multiflexi-tui).
+func StateDir() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, AppName)
+	}
+	return filepath.Join(home(), ".local", "state", AppName)
+}
+
+// Path returns the path of a file in the configuration directory.
+func Path(name string) string {
+	return filepath.Join(Dir(), name)
+}
+
+// StatePath returns the path of a file in the state directory.
+func StatePath(name string) string {
+	return filepath.Join(StateDir(), name)
+}
+
+func home() string {
+	if dir, err := os.UserHomeDir(); err == nil {
+		return dir
+	}
+	return "."
+}
@@ -1,11 +1,11 @@
 package entity
 
 import (
-	"fmt"
 	"strings"
 
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
-	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -13,91 +13,91 @@ import (
 // Used for actions that require user input before executing (e.g., schedule, save-to-file).
 type ActionFormView struct {
 	title  string
-	labels []string
-	inputs []textinput.Model
-	cursor int
+	fields []ui.EditorField
+	form   *ui.Form
+	pick   pickers
 	onSave func(fields map[string]string) tea.Cmd
 }
 
 // NewActionFormView creates an action form with the given title, fields, and save callback.
 func NewActionFormView(title string, fields []ui.EditorField, onSave func(map[string]string) tea.Cmd) *ActionFormView {
-	inputs := make([]textinput.Model, len(fields))
-	labels := make([]string, len(fields))
-	for i, f := range fields {
-		ti := textinput.New()
-		ti.Placeholder = f.Placeholder
-		ti.SetValue(f.Value)
-		if i == 0 {
-			ti.Focus()
-		}
-		inputs[i] = ti
-		labels[i] = f.Label
-	}
+	form := ui.NewForm(fields)
 	return &ActionFormView{
 		title:  title,
-		labels: labels,
-		inputs: inputs,
+		fields: fields,
+		form:   form,
+		pick:   pickers{form: form},
 		onSave: onSave,
 	}
 }
 
-func (m *ActionFormView) Init() tea.Cmd { return textinput.Blink }
+// WithPickers lets the form's PickerFields choose their records, loaded
+// through c.
+func (m *ActionFormView) WithPickers(c cli.Client) *ActionFormView {
+	m.pick.client = c
+	return m
+}
+
+func (m *ActionFormView) Init() tea.Cmd { return tea.Batch(m.form.Init(), m.pick.init(m.fields)) }
+
+// CapturingInput satisfies ui.InputCapturer: tab moves between fields and
+// q is text.
+func (m *ActionFormView) CapturingInput() bool { return true }
 
 func (m *ActionFormView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if click, ok := msg.(ui.ClickMsg); ok {
+		click.Y -= formFieldsTop
+		msg = click
+	}
+	if cmd, ok := m.pick.update(msg); ok {
+		return m, cmd
+	}
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.form.SetWidth(msg.Width)
+		return m, nil
+
+	case externalEditedMsg:
+		return m, applyEdited(m.form, msg)
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c":
 			return m, tea.Quit
 		case "esc":
-			return m, func() tea.Msg { return ui.NavigateBackMsg{} }
-		case "tab", "down":
-			if len(m.inputs) > 0 {
-				m.inputs[m.cursor].Blur()
-				m.cursor = (m.cursor + 1) % len(m.inputs)
-				m.inputs[m.cursor].Focus()
-			}
-			return m, textinput.Blink
-		case "shift+tab", "up":
-			if len(m.inputs) > 0 {
-				m.inputs[m.cursor].Blur()
-				m.cursor = (m.cursor - 1 + len(m.inputs)) % len(m.inputs)
-				m.inputs[m.cursor].Focus()
-			}
-			return m, textinput.Blink
+			return m, leaveForm(m.form, nil)
+		case externalKey:
+			return m, editExternally(m.form)
+		case "ctrl+s":
+			return m, m.submit()
 		case "enter":
-			fields := make(map[string]string, len(m.inputs))
-			for i, inp := range m.inputs {
-				fields[m.labels[i]] = inp.Value()
+			if !m.form.WantsEnter() {
+				return m, m.submit()
 			}
-			return m, m.onSave(fields)
 		}
 	}
-	if len(m.inputs) > 0 {
-		var cmd tea.Cmd
-		m.inputs[m.cursor], cmd = m.inputs[m.cursor].Update(msg)
-		return m, cmd
+	return m, m.form.Update(msg)
+}
+
+// submit calls onSave once every field is valid.
+func (m *ActionFormView) submit() tea.Cmd {
+	if !m.form.Validate() {
+		return nil
 	}
-	return m, nil
+	return m.onSave(m.form.Values())
 }
 
 func (m *ActionFormView) View() string {
 	var b strings.Builder
-	b.WriteString(ui.TitleStyle().Render(m.title))
+	b.WriteString(ui.TitleStyle().Render(i18n.T(m.title)))
 	b.WriteString("\n\n")
-	for i, input := range m.inputs {
-		label := m.labels[i]
-		if i == m.cursor {
-			b.WriteString(ui.SelectedStyle().Render(fmt.Sprintf("%-15s", label+":")))
-		} else {
-			b.WriteString(fmt.Sprintf("%-15s", label+":"))
-		}
-		b.WriteString(" ")
-		b.WriteString(input.View())
-		b.WriteString("\n")
+	if picker := m.pick.view(); picker != "" {
+		b.WriteString(picker)
+		return b.String()
 	}
+	b.WriteString(m.form.View())
 	b.WriteString("\n")
-	b.WriteString(ui.FooterStyle().Render("tab/↑↓: fields • enter: confirm • esc: cancel"))
+	b.WriteString(ui.FooterStyle().Render(formHelp("tab/↑↓: fields • enter: confirm • ctrl+e: editor • esc: cancel", m.form)))
 	b.WriteString("\n")
 	return b.String()
 }
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+)
+
+// AlertLogDef lists the alert log of Alerts, newest first. It is not a
+// multiflexi-cli entity, so it is not registered; main adds its menu item.
+// Its rows have no record to open.
+var AlertLogDef = &EntityDef{
+	Name: "🔔 Alerts", CLIEntity: "alert", Limit: 10,
+	Columns: []ui.TableColumn{
+		{Header: "Time", Width: 19, Field: "time"},
+		{Header: "State", Width: 10, Field: "state"},
+		{Header: "Rule", Width: 24, Field: "rule", Flex: 1},
+		{Header: "Detail", Width: 30, Field: "detail", Flex: 2},
+	},
+	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
+		events := Alerts.Log()
+		if offset >= len(events) {
+			return nil, nil
+		}
+		events = events[offset:]
+		if len(events) > limit {
+			events = events[:limit]
+		}
+		rows := make([]ui.TableRow, len(events))
+		for i, e := range events {
+			state := i18n.T("resolved")
+			if e.Breached {
+				state = i18n.T("breached")
+			}
+			rows[i] = ui.TableRow{ID: offset + i + 1, Values: map[string]string{
+				"time": e.At.Format("2006-01-02 15:04:05"), "state": state,
+				"rule": i18n.T(e.Rule), "detail": e.Detail(),
+			}}
+		}
+		return rows, nil
+	},
+}
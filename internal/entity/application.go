@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -11,7 +12,7 @@ import (
 var ApplicationDef = &EntityDef{
 	Name: "📦 Applications", CLIEntity: "application", DeleteAction: "delete", Limit: 10,
 	Columns: []ui.TableColumn{
-		{Header: "ID", Width: 5, Field: "id"}, {Header: "Name", Width: 30, Field: "name"},
+		{Header: "ID", Width: 5, Field: "id"}, {Header: "Name", Width: 30, Field: "name", Flex: 2},
 		{Header: "Version", Width: 15, Field: "version"}, {Header: "Status", Width: 10, Field: "status"},
 	},
 	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
@@ -44,37 +45,20 @@ var ApplicationDef = &EntityDef{
 	ToEditor: func(data interface{}) []ui.EditorField {
 		a := data.(cli.Application)
 		return []ui.EditorField{
-			{Label: "Name", Placeholder: "Application name", Value: a.Name},
-			{Label: "Description", Placeholder: "Description", Value: a.Description},
-			{Label: "Executable", Placeholder: "Executable path", Value: a.Executable},
-			{Label: "Homepage", Placeholder: "Homepage URL", Value: a.Homepage},
-			{Label: "Topics", Placeholder: "Topics", Value: a.Topics},
+			{Label: "Name", Flag: "--name", Placeholder: "Application name", Value: a.Name, Required: true},
+			{Label: "Description", Flag: "--description", Placeholder: "Description", Value: a.Description, Kind: ui.TextAreaField},
+			{Label: "Executable", Flag: "--executable", Placeholder: "Executable path", Value: a.Executable},
+			{Label: "Homepage", Flag: "--homepage", Placeholder: "Homepage URL", Value: a.Homepage, Validators: []ui.Validator{validURL}},
+			{Label: "Topics", Flag: "--topics", Placeholder: "Topics", Value: a.Topics},
 		}
 	},
-	UpdateArgs: func(data interface{}, fields map[string]string) []string {
-		a := data.(cli.Application)
-		args := []string{"--id", fmt.Sprintf("%d", a.ID), "--name", fields["Name"]}
-		if v := fields["Description"]; v != "" {
-			args = append(args, "--description", v)
-		}
-		if v := fields["Executable"]; v != "" {
-			args = append(args, "--executable", v)
-		}
-		if v := fields["Homepage"]; v != "" {
-			args = append(args, "--homepage", v)
-		}
-		if v := fields["Topics"]; v != "" {
-			args = append(args, "--topics", v)
-		}
-		return args
-	},
 	NewFields: func() []ui.EditorField {
 		return []ui.EditorField{
 			{Label: "Name", Placeholder: "Application name", Required: true},
-			{Label: "UUID", Placeholder: "UUID", Required: true},
+			{Label: "UUID", Placeholder: "UUID", Required: true, Validators: []ui.Validator{validUUID}},
 			{Label: "Executable", Placeholder: "Executable path", Required: true},
-			{Label: "Description", Placeholder: "Description"},
-			{Label: "Homepage", Placeholder: "Homepage URL"},
+			{Label: "Description", Placeholder: "Description", Kind: ui.TextAreaField},
+			{Label: "Homepage", Placeholder: "Homepage URL", Validators: []ui.Validator{validURL}},
 		}
 	},
 	CreateArgs: func(fields map[string]string) []string {
@@ -87,10 +71,21 @@ var ApplicationDef = &EntityDef{
 		}
 		return args
 	},
+	Duplicate: func(data interface{}) map[string]string {
+		a := data.(cli.Application)
+		return map[string]string{
+			"Name":        a.Name,
+			"Executable":  a.Executable,
+			"Description": a.Description,
+			"Homepage":    a.Homepage,
+		}
+	},
+	Record:   func() interface{} { return &cli.Application{} },
 	GetID:    func(data interface{}) int { return data.(cli.Application).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("App: %s", data.(cli.Application).Name) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("App: %s", data.(cli.Application).Name) },
 	Actions: []ui.ActionDef{
 		{Label: "Edit", Key: "e", Command: "edit"},
+		{Label: "Duplicate", Key: "D", Command: "duplicate"},
 		{
 			Label:   "Config",
 			Key:     "c",
@@ -99,11 +94,11 @@ var ApplicationDef = &EntityDef{
 				a := data.(cli.Application)
 				return func() tea.Msg {
 					output, err := c.RunRaw("application", "showconfig", "--format=json", "--id", fmt.Sprintf("%d", a.ID))
-					viewer := ui.NewViewer(fmt.Sprintf("Config: %s", a.Name))
+					viewer := ui.NewViewer(i18n.Tf("Config: %s", a.Name))
 					if err != nil {
-						viewer.SetContent(fmt.Sprintf("Config: %s", a.Name), fmt.Sprintf("Error: %v", err))
+						viewer.SetContent(i18n.Tf("Config: %s", a.Name), i18n.Tf("Error: %v", err))
 					} else {
-						viewer.SetContent(fmt.Sprintf("Config: %s", a.Name), string(output))
+						viewer.SetContent(i18n.Tf("Config: %s", a.Name), string(output))
 					}
 					return ui.NavigateToMsg{View: viewer}
 				}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -12,7 +13,7 @@ var ArtifactDef = &EntityDef{
 	Name: "📎 Artifacts", CLIEntity: "artifact", DeleteAction: "delete", Limit: 10,
 	Columns: []ui.TableColumn{
 		{Header: "ID", Width: 8, Field: "id"}, {Header: "Job ID", Width: 10, Field: "job_id"},
-		{Header: "Content Type", Width: 20, Field: "content_type"}, {Header: "Filename", Width: 35, Field: "filename"},
+		{Header: "Content Type", Width: 20, Field: "content_type", Flex: 1}, {Header: "Filename", Width: 35, Field: "filename", Flex: 2},
 	},
 	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
 		var items []cli.Artifact
@@ -39,8 +40,9 @@ var ArtifactDef = &EntityDef{
 			{Label: "Note", Value: a.Note},
 		}
 	},
+	Record:   func() interface{} { return &cli.Artifact{} },
 	GetID:    func(data interface{}) int { return data.(cli.Artifact).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("Artifact: %s", data.(cli.Artifact).Filename) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("Artifact: %s", data.(cli.Artifact).Filename) },
 	Actions: []ui.ActionDef{
 		{
 			Label:   "Save to file",
@@ -49,7 +51,7 @@ var ArtifactDef = &EntityDef{
 			Handler: func(c cli.Client, data interface{}) tea.Cmd {
 				a := data.(cli.Artifact)
 				form := NewActionFormView(
-					fmt.Sprintf("Save Artifact: %s", a.Filename),
+					i18n.Tf("Save Artifact: %s", a.Filename),
 					[]ui.EditorField{
 						{Label: "File Path", Placeholder: "/path/to/save", Value: "/tmp/" + a.Filename},
 					},
@@ -60,9 +62,9 @@ var ArtifactDef = &EntityDef{
 								"--file", fields["File Path"],
 							)
 							if err != nil {
-								return ui.StatusMsg{Text: fmt.Sprintf("Save failed: %v", err)}
+								return ui.StatusMsg{Text: i18n.Tf("Save failed: %v", err)}
 							}
-							return ui.StatusMsg{Text: fmt.Sprintf("Saved to %s", fields["File Path"])}
+							return ui.StatusMsg{Text: i18n.Tf("Saved to %s", fields["File Path"])}
 						}
 					},
 				)
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/audit"
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+)
+
+// Audit is the log of the changes made through the TUI. main points it at
+// the configured file; the zero value keeps nothing.
+var Audit = &audit.Log{}
+
+// AuditDef lists the Audit log, newest first; / filters it by user,
+// operation, entity or result. Like AlertLogDef it is not registered; main
+// adds its menu item.
+var AuditDef = &EntityDef{
+	Name: "📜 Audit Log", CLIEntity: "audit", Limit: 50,
+	Columns: []ui.TableColumn{
+		{Header: "Time", Width: 19, Field: "time"},
+		{Header: "User", Width: 12, Field: "user"},
+		{Header: "Profile", Width: 10, Field: "profile"},
+		{Header: "Operation", Width: 10, Field: "op"},
+		{Header: "Entity", Width: 14, Field: "entity", Flex: 1},
+		{Header: "ID", Width: 6, Field: "id"},
+		{Header: "Result", Width: 8, Field: "result"},
+	},
+	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
+		records, err := Audit.Read()
+		if err != nil {
+			return nil, err
+		}
+		if offset >= len(records) {
+			return nil, nil
+		}
+		records = records[offset:]
+		if len(records) > limit {
+			records = records[:limit]
+		}
+		rows := make([]ui.TableRow, len(records))
+		for i, r := range records {
+			rows[i] = ui.TableRow{ID: offset + i + 1, FullData: r, Values: map[string]string{
+				"time": r.Time.Local().Format("2006-01-02 15:04:05"), "user": r.User,
+				"profile": r.Profile, "op": r.Op, "entity": r.Entity,
+				"id": auditID(r), "result": i18n.T(r.Result),
+			}}
+		}
+		return rows, nil
+	},
+	ToDetail: func(data interface{}) []ui.DetailField {
+		r := data.(audit.Record)
+		fields := []ui.DetailField{
+			{Label: "Time", Value: r.Time.Local().Format("2006-01-02 15:04:05")},
+			{Label: "User", Value: r.User},
+			{Label: "Profile", Value: r.Profile},
+			{Label: "Operation", Value: r.Op},
+			{Label: "Entity", Value: r.Entity},
+			{Label: "ID", Value: auditID(r)},
+			{Label: "Result", Value: i18n.T(r.Result)},
+		}
+		if r.Error != "" {
+			fields = append(fields, ui.DetailField{Label: "Error", Value: r.Error})
+		}
+		names := make([]string, 0, len(r.Fields))
+		for name := range r.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fields = append(fields, ui.DetailField{Label: "--" + name, Value: r.Fields[name]})
+		}
+		for i, a := range r.Args {
+			fields = append(fields, ui.DetailField{Label: i18n.Tf("Argument %d", i+1), Value: a})
+		}
+		return fields
+	},
+	GetID: func(data interface{}) int { return data.(audit.Record).ID },
+	GetLabel: func(data interface{}) string {
+		r := data.(audit.Record)
+		return fmt.Sprintf("%s %s %s", r.Op, r.Entity, auditID(r))
+	},
+}
+
+func auditID(r audit.Record) string {
+	if r.ID == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", r.ID)
+}
@@ -0,0 +1,451 @@
+package entity
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/audit"
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const commandFormHelp = "tab/↑↓: fields • space: toggle • enter: run • ctrl+p/ctrl+n: history • esc: back"
+
+// CommandEntry is one runnable entry of the Commands browser: a command
+// and, for commands taking an action argument, one of its actions.
+type CommandEntry struct {
+	Command cli.Command
+	Action  string
+}
+
+// Line returns the entry as typed after multiflexi-cli, e.g. "job status".
+func (e CommandEntry) Line() string {
+	if e.Action == "" {
+		return e.Command.Name
+	}
+	return e.Command.Name + " " + e.Action
+}
+
+// actionArgument returns the index of the argument selecting the action:
+// the first one listing its choices, or -1.
+func actionArgument(c cli.Command) int {
+	for i, a := range c.Arguments {
+		if len(a.Choices()) > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// commandEntries expands commands into one entry per action.
+func commandEntries(commands []cli.Command) []CommandEntry {
+	var entries []CommandEntry
+	for _, c := range commands {
+		i := actionArgument(c)
+		if i < 0 {
+			entries = append(entries, CommandEntry{Command: c})
+			continue
+		}
+		for _, action := range c.Arguments[i].Choices() {
+			entries = append(entries, CommandEntry{Command: c, Action: action})
+		}
+	}
+	return entries
+}
+
+// CommandDef browses every multiflexi-cli command and action from the
+// describe schema; a row opens a form generated from its arguments and
+// options. Like the alert log it is not registered; main adds its menu item.
+var CommandDef = &EntityDef{
+	Name: "⌨ Commands", CLIEntity: "command", Limit: 10,
+	Columns: []ui.TableColumn{
+		{Header: "Command", Width: 28, Field: "command"},
+		{Header: "Description", Width: 40, Field: "description", Flex: 1},
+	},
+	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
+		commands, err := c.GetCommands()
+		if err != nil {
+			return nil, err
+		}
+		entries := commandEntries(commands)
+		if offset >= len(entries) {
+			return nil, nil
+		}
+		entries = entries[offset:]
+		if len(entries) > limit {
+			entries = entries[:limit]
+		}
+		rows := make([]ui.TableRow, len(entries))
+		for i, e := range entries {
+			rows[i] = ui.TableRow{ID: offset + i + 1, Values: map[string]string{
+				"command": e.Line(), "description": e.Command.Description,
+			}, FullData: e}
+		}
+		return rows, nil
+	},
+	Open: func(c cli.Client, data interface{}) tea.Model {
+		return NewCommandForm(c, data.(CommandEntry))
+	},
+}
+
+// commandField is an argument or option of a command form.
+type commandField struct {
+	name     string
+	option   bool // passed as --name=value rather than by position
+	flag     bool // an option without a value, toggled on or off
+	number   bool // the default is a number, so the value must be one too
+	required bool
+	secret   bool // a password or token, masked and kept out of the history
+	desc     string
+	input    textinput.Model
+	on       bool // state of a flag
+}
+
+// value returns the field as recorded in the history: the text, or "1"
+// for a flag that is on.
+func (f *commandField) value() string {
+	if f.flag {
+		if f.on {
+			return "1"
+		}
+		return ""
+	}
+	return strings.TrimSpace(f.input.Value())
+}
+
+// CommandForm runs one command entry with parameters entered in a form
+// generated from the describe schema. The output is shown in a viewer and
+// each parameter set is kept in the session's command history.
+type CommandForm struct {
+	client cli.Client
+	entry  CommandEntry
+	fields []*commandField
+	cursor int
+	top    int // first field shown
+	height int
+	err    string
+
+	history []map[string]string // newest first
+	histPos int                 // -1 while editing a new set
+}
+
+// NewCommandForm builds the form of a command entry.
+func NewCommandForm(c cli.Client, e CommandEntry) *CommandForm {
+	m := &CommandForm{client: c, entry: e, height: 30, histPos: -1}
+	action := -1
+	if e.Action != "" {
+		action = actionArgument(e.Command)
+	}
+	for i, a := range e.Command.Arguments {
+		if i != action {
+			m.fields = append(m.fields, newCommandField(a.Name, a.Description, a.Default, false, a.Required))
+		}
+	}
+	for _, o := range e.Command.Options {
+		f := newCommandField(o.Name, o.Description, o.Default, true, false)
+		f.flag = o.IsFlag()
+		m.fields = append(m.fields, f)
+	}
+	if len(m.fields) > 0 {
+		m.fields[0].input.Focus()
+	}
+	m.history = Session.CommandHistory(e.Line())
+	return m
+}
+
+func newCommandField(name, desc string, def interface{}, option, required bool) *commandField {
+	f := &commandField{name: name, option: option, required: required, desc: desc, secret: audit.IsSecret(name)}
+	f.input = textinput.New()
+	if f.secret {
+		f.input.EchoMode = textinput.EchoPassword
+		f.input.EchoCharacter = '•'
+	}
+	switch d := def.(type) {
+	case float64:
+		f.number = true
+		f.input.Placeholder = strconv.FormatFloat(d, 'f', -1, 64)
+	case string:
+		f.input.Placeholder = d
+	}
+	return f
+}
+
+func (m *CommandForm) Init() tea.Cmd { return textinput.Blink }
+
+// CapturingInput satisfies ui.InputCapturer: the form uses tab and esc
+// itself and takes q as text.
+func (m *CommandForm) CapturingInput() bool { return true }
+
+// HelpTopic satisfies ui.HelpTopic.
+func (m *CommandForm) HelpTopic() string { return m.entry.Command.Name }
+
+// commandFormTop is the line of the first field: title, description, blank line.
+const commandFormTop = 3
+
+func (m *CommandForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		m.scrollToCursor()
+		return m, nil
+
+	case ui.ClickMsg:
+		if i := m.top + msg.Y - commandFormTop; i >= 0 && i < len(m.fields) && i < m.top+m.visibleFields() {
+			m.focus(i)
+			if m.fields[i].flag {
+				m.fields[i].on = !m.fields[i].on
+			}
+		}
+		return m, textinput.Blink
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return ui.NavigateBackMsg{} }
+		case "tab", "down":
+			if len(m.fields) > 0 {
+				m.focus((m.cursor + 1) % len(m.fields))
+			}
+			return m, textinput.Blink
+		case "shift+tab", "up":
+			if len(m.fields) > 0 {
+				m.focus((m.cursor - 1 + len(m.fields)) % len(m.fields))
+			}
+			return m, textinput.Blink
+		case "ctrl+p":
+			m.recall(m.histPos + 1)
+			return m, nil
+		case "ctrl+n":
+			m.recall(m.histPos - 1)
+			return m, nil
+		case "enter":
+			return m, m.run()
+		case " ":
+			if len(m.fields) > 0 && m.fields[m.cursor].flag {
+				m.fields[m.cursor].on = !m.fields[m.cursor].on
+				return m, nil
+			}
+		}
+	}
+	if len(m.fields) > 0 && !m.fields[m.cursor].flag {
+		var cmd tea.Cmd
+		m.fields[m.cursor].input, cmd = m.fields[m.cursor].input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// focus moves the cursor to field i.
+func (m *CommandForm) focus(i int) {
+	m.fields[m.cursor].input.Blur()
+	m.cursor = i
+	m.fields[i].input.Focus()
+	m.scrollToCursor()
+}
+
+// visibleFields is how many fields fit between the header and the
+// description, command line and help below them.
+func (m *CommandForm) visibleFields() int {
+	n := m.height - commandFormTop - 6
+	if n < 3 {
+		n = 3
+	}
+	return n
+}
+
+func (m *CommandForm) scrollToCursor() {
+	vis := m.visibleFields()
+	if m.cursor < m.top {
+		m.top = m.cursor
+	}
+	if m.cursor >= m.top+vis {
+		m.top = m.cursor - vis + 1
+	}
+}
+
+// recall fills the form with history entry i (0 = newest); -1 clears it.
+func (m *CommandForm) recall(i int) {
+	if i < -1 || i >= len(m.history) {
+		return
+	}
+	m.histPos = i
+	var set map[string]string
+	if i >= 0 {
+		set = m.history[i]
+	}
+	for _, f := range m.fields {
+		f.on = set[f.name] != ""
+		f.input.SetValue(set[f.name])
+	}
+	m.err = ""
+}
+
+// params returns the entered parameters by field name, leaving out empty
+// ones and secrets.
+func (m *CommandForm) params() map[string]string {
+	params := map[string]string{}
+	for _, f := range m.fields {
+		if v := f.value(); v != "" && !f.secret {
+			params[f.name] = v
+		}
+	}
+	return params
+}
+
+// args builds the multiflexi-cli arguments, or reports the first field
+// that is missing or malformed.
+func (m *CommandForm) args() ([]string, error) {
+	return m.buildArgs(false)
+}
+
+// shownArgs builds the arguments as shown on screen, secret values
+// redacted.
+func (m *CommandForm) shownArgs() ([]string, error) {
+	return m.buildArgs(true)
+}
+
+func (m *CommandForm) buildArgs(redact bool) ([]string, error) {
+	args := []string{m.entry.Command.Name}
+	if m.entry.Action != "" {
+		args = append(args, m.entry.Action)
+	}
+	var options []string
+	positional := true // an empty argument ends the positional ones
+	for _, f := range m.fields {
+		v := f.value()
+		switch {
+		case v == "" && f.required:
+			return nil, errors.New(i18n.Tf("%s is required", f.name))
+		case v == "":
+			if !f.option {
+				positional = false
+			}
+			continue
+		case f.number:
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return nil, errors.New(i18n.Tf("%s must be a number", f.name))
+			}
+		}
+		if redact && f.secret {
+			v = audit.Redacted
+		}
+		switch {
+		case f.flag:
+			options = append(options, "--"+f.name)
+		case f.option:
+			options = append(options, "--"+f.name+"="+v)
+		case !positional:
+			return nil, errors.New(i18n.Tf("%s needs the arguments before it", f.name))
+		default:
+			args = append(args, v)
+		}
+	}
+	return append(args, options...), nil
+}
+
+// run records the parameters and runs the command, showing its output.
+// A command that may change something is confirmed first.
+func (m *CommandForm) run() tea.Cmd {
+	args, err := m.args()
+	if err != nil {
+		m.err = err.Error()
+		return nil
+	}
+	m.err = ""
+	Session.AddCommandHistory(m.entry.Line(), m.params())
+	m.history, m.histPos = Session.CommandHistory(m.entry.Line()), 0
+	client := m.client
+	shown, _ := m.shownArgs()
+	line := "multiflexi-cli " + strings.Join(shown, " ")
+	exec := func() tea.Msg {
+		out, err := client.RunRaw(args...)
+		if err != nil {
+			return ui.StatusMsg{Text: i18n.Tf("Command failed: %v", err)}
+		}
+		viewer := ui.NewViewer(line)
+		viewer.SetContent(line, strings.TrimRight(string(out), "\n"))
+		return ui.NavigateToMsg{View: viewer}
+	}
+	if audit.ReadOnly(args) {
+		return exec
+	}
+	label := i18n.Tf("Run %s?", line)
+	return func() tea.Msg { return ui.ConfirmMsg{Label: label, Action: exec} }
+}
+
+// labelWidth fits the longest field name, required marker included.
+func (m *CommandForm) labelWidth() int {
+	w := ui.FormLabelWidth
+	for _, f := range m.fields {
+		if n := ui.Width(f.name) + 2; n > w {
+			w = n
+		}
+	}
+	return w
+}
+
+func (m *CommandForm) View() string {
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle().Render(" multiflexi-cli " + m.entry.Line() + " "))
+	b.WriteString("\n")
+	b.WriteString(ui.DescriptionStyle().Render(m.entry.Command.Description))
+	b.WriteString("\n\n")
+
+	labelW := m.labelWidth()
+	end := m.top + m.visibleFields()
+	if end > len(m.fields) {
+		end = len(m.fields)
+	}
+	if len(m.fields) == 0 {
+		b.WriteString(ui.DescriptionStyle().Render(i18n.T("This command takes no parameters.")))
+		b.WriteString("\n")
+	}
+	for i := m.top; i < end; i++ {
+		f := m.fields[i]
+		label := f.name
+		if f.required {
+			label += "*"
+		}
+		label = ui.PadRight(label+":", labelW)
+		if i == m.cursor {
+			label = ui.SelectedStyle().Render(label)
+		}
+		b.WriteString(label + " ")
+		if f.flag {
+			box := "[ ]"
+			if f.on {
+				box = "[x]"
+			}
+			b.WriteString(box)
+		} else {
+			b.WriteString(f.input.View())
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if len(m.fields) > 0 {
+		b.WriteString(ui.DescriptionStyle().Render(m.fields[m.cursor].desc))
+	}
+	b.WriteString("\n")
+	if args, err := m.shownArgs(); err == nil {
+		b.WriteString(ui.DebugStyle().Render("$ multiflexi-cli " + strings.Join(args, " ")))
+	}
+	b.WriteString("\n")
+	switch {
+	case m.err != "":
+		b.WriteString(ui.ErrorStyle().Render(m.err))
+	case m.histPos >= 0:
+		b.WriteString(ui.DescriptionStyle().Render(i18n.Tf("history %d of %d", m.histPos+1, len(m.history))))
+	case len(m.history) > 0:
+		b.WriteString(ui.DescriptionStyle().Render(i18n.N("saved parameter sets", len(m.history))))
+	}
+	b.WriteString("\n")
+	b.WriteString(ui.FooterStyle().Render(i18n.T(commandFormHelp)))
+	b.WriteString("\n")
+	return b.String()
+}
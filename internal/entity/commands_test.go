@@ -0,0 +1,170 @@
+package entity
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runClient serves a fixed command list and records the commands run.
+type runClient struct {
+	fakeClient
+	commands []cli.Command
+	ran      [][]string
+}
+
+func (c *runClient) GetCommands() ([]cli.Command, error) { return c.commands, nil }
+func (c *runClient) RunRaw(args ...string) ([]byte, error) {
+	c.ran = append(c.ran, args)
+	return []byte("done\n"), nil
+}
+
+var jobCommand = cli.Command{
+	Name:        "job",
+	Description: "Manage jobs",
+	Arguments: []cli.Argument{
+		{Name: "action", Required: true, Description: "Action: list|get|status"},
+		{Name: "extra", Description: "Extra argument"},
+	},
+	Options: []cli.Option{
+		{Name: "id", Description: "Job ID", Default: float64(0)},
+		{Name: "verbose", Description: "More output", Default: false},
+	},
+}
+
+var statusCommand = cli.Command{Name: "status", Description: "Show status"}
+
+func TestCommandEntriesExpandActions(t *testing.T) {
+	var lines []string
+	for _, e := range commandEntries([]cli.Command{jobCommand, statusCommand}) {
+		lines = append(lines, e.Line())
+	}
+	want := []string{"job list", "job get", "job status", "status"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("entries = %q, want %q", lines, want)
+	}
+}
+
+func TestCommandDefPagesEntries(t *testing.T) {
+	c := &runClient{commands: []cli.Command{jobCommand, statusCommand}}
+	rows, err := CommandDef.Fetch(c, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].Values["command"] != "job status" || rows[1].Values["command"] != "status" {
+		t.Fatalf("rows = %+v", rows)
+	}
+	if _, ok := CommandDef.Open(c, rows[0].FullData).(*CommandForm); !ok {
+		t.Error("a command row should open a command form")
+	}
+}
+
+func TestCommandFormBuildsArguments(t *testing.T) {
+	m := NewCommandForm(&runClient{}, CommandEntry{Command: jobCommand, Action: "get"})
+	if len(m.fields) != 3 {
+		t.Fatalf("fields = %d, want extra, id and verbose", len(m.fields))
+	}
+	m.fields[0].input.SetValue("x")
+	m.fields[1].input.SetValue("7")
+	m.fields[2].on = true
+	args, err := m.args()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"job", "get", "x", "--id=7", "--verbose"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %q, want %q", args, want)
+	}
+
+	m.fields[1].input.SetValue("seven")
+	if _, err := m.args(); err == nil || !strings.Contains(err.Error(), "id") {
+		t.Errorf("a non-numeric id should be rejected, got %v", err)
+	}
+}
+
+func TestCommandFormRequiresArguments(t *testing.T) {
+	cmd := cli.Command{Name: "user", Arguments: []cli.Argument{{Name: "login", Required: true}}}
+	c := &runClient{}
+	m := NewCommandForm(c, CommandEntry{Command: cmd})
+	if run := m.run(); run != nil || !strings.Contains(m.err, "login") {
+		t.Fatalf("an empty required argument should block the run, err = %q", m.err)
+	}
+	if !strings.Contains(m.View(), "login*") {
+		t.Error("required fields should be marked")
+	}
+}
+
+func TestCommandFormRunsAndRemembersParameters(t *testing.T) {
+	saved := Session
+	defer func() { Session = saved }()
+	Session = session.New()
+
+	c := &runClient{}
+	entry := CommandEntry{Command: jobCommand, Action: "status"}
+	m := NewCommandForm(c, entry)
+	m.fields[1].input.SetValue("42")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should run the command")
+	}
+	nav, ok := cmd().(ui.NavigateToMsg)
+	if !ok {
+		t.Fatal("the output should open in a viewer")
+	}
+	if !strings.Contains(nav.View.View(), "done") {
+		t.Error("the viewer should show the output")
+	}
+	if want := [][]string{{"job", "status", "--id=42"}}; !reflect.DeepEqual(c.ran, want) {
+		t.Errorf("ran %q, want %q", c.ran, want)
+	}
+
+	// A new form recalls the parameters with ctrl+p.
+	m = NewCommandForm(c, entry)
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if got := m.fields[1].input.Value(); got != "42" {
+		t.Errorf("recalled id = %q, want 42", got)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
+	if got := m.fields[1].input.Value(); got != "" {
+		t.Errorf("ctrl+n past the newest set should clear the form, got %q", got)
+	}
+}
+
+func TestCommandFormKeepsSecretsOutOfHistory(t *testing.T) {
+	saved := Session
+	defer func() { Session = saved }()
+	Session = session.New()
+
+	cmd := cli.Command{Name: "user", Options: []cli.Option{{Name: "login"}, {Name: "plaintext"}}}
+	entry := CommandEntry{Command: cmd}
+	c := &runClient{}
+	m := NewCommandForm(c, entry)
+	m.fields[0].input.SetValue("bob")
+	m.fields[1].input.SetValue("hunter2")
+	if view := m.View(); strings.Contains(view, "hunter2") {
+		t.Errorf("the form shows the password:\n%s", view)
+	}
+	_, run := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	confirm, ok := run().(ui.ConfirmMsg)
+	if !ok {
+		t.Fatal("a command that changes something should be confirmed first")
+	}
+	if strings.Contains(confirm.Label, "hunter2") {
+		t.Errorf("the confirmation shows the password: %s", confirm.Label)
+	}
+	if len(c.ran) != 0 {
+		t.Fatalf("ran %q before the confirmation", c.ran)
+	}
+	confirm.Action()
+	if want := [][]string{{"user", "--login=bob", "--plaintext=hunter2"}}; !reflect.DeepEqual(c.ran, want) {
+		t.Errorf("ran %q, want %q", c.ran, want)
+	}
+	history := Session.CommandHistory(entry.Line())
+	if want := []map[string]string{{"login": "bob"}}; !reflect.DeepEqual(history, want) {
+		t.Errorf("history = %v, want the password left out", history)
+	}
+}
@@ -1,12 +1,45 @@
 package entity
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 )
 
+// validIC accepts a Czech company ID (IČO): eight digits, the last one a
+// weighted modulo 11 check digit.
+func validIC(v string) error {
+	bad := errors.New("Must be an 8-digit IČO with a valid check digit")
+	if len(v) != 8 {
+		return bad
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		if v[i] < '0' || v[i] > '9' {
+			return bad
+		}
+		if i < 7 {
+			sum += int(v[i]-'0') * (8 - i)
+		}
+	}
+	check := (11 - sum%11) % 10
+	if int(v[7]-'0') != check {
+		return bad
+	}
+	return nil
+}
+
+// companySettings returns the settings of a company, "" when it has none.
+func companySettings(co cli.Company) string {
+	if co.Settings == nil {
+		return ""
+	}
+	return *co.Settings
+}
+
 var CompanyDef = &EntityDef{
 	Name:         "🏢 Companies",
 	CLIEntity:    "company",
@@ -14,9 +47,9 @@ var CompanyDef = &EntityDef{
 	Limit:        10,
 	Columns: []ui.TableColumn{
 		{Header: "ID", Width: 6, Field: "id"},
-		{Header: "Name", Width: 30, Field: "name"},
+		{Header: "Name", Width: 30, Field: "name", Flex: 2},
 		{Header: "IC", Width: 15, Field: "ic"},
-		{Header: "Email", Width: 25, Field: "email"},
+		{Header: "Email", Width: 25, Field: "email", Flex: 1},
 		{Header: "Status", Width: 10, Field: "status"},
 	},
 	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
@@ -58,24 +91,20 @@ var CompanyDef = &EntityDef{
 	ToEditor: func(data interface{}) []ui.EditorField {
 		co := data.(cli.Company)
 		return []ui.EditorField{
-			{Label: "Name", Placeholder: "Company name", Value: co.Name},
-			{Label: "Email", Placeholder: "Email", Value: co.Email},
-			{Label: "IC", Placeholder: "IC", Value: co.IC},
-			{Label: "Slug", Placeholder: "Slug", Value: co.Slug},
+			{Label: "Name", Flag: "--name", Placeholder: "Company name", Value: co.Name, Required: true},
+			{Label: "Email", Flag: "--email", Placeholder: "Email", Value: co.Email, Validators: []ui.Validator{ui.IsEmail}},
+			{Label: "IC", Flag: "--ic", Placeholder: "IC", Value: co.IC, Validators: []ui.Validator{validIC}},
+			{Label: "Slug", Flag: "--slug", Placeholder: "Slug", Value: co.Slug, Validators: []ui.Validator{validSlug}},
+			{Label: "Settings", Flag: "--settings", Placeholder: "{}", Value: companySettings(co), Kind: ui.TextAreaField, Ext: ".json"},
 		}
 	},
-	UpdateArgs: func(data interface{}, fields map[string]string) []string {
-		co := data.(cli.Company)
-		return []string{"--id", fmt.Sprintf("%d", co.ID),
-			"--name", fields["Name"], "--email", fields["Email"],
-			"--ic", fields["IC"], "--slug", fields["Slug"]}
-	},
 	NewFields: func() []ui.EditorField {
 		return []ui.EditorField{
 			{Label: "Name", Placeholder: "Company name", Required: true},
-			{Label: "Email", Placeholder: "Email"},
-			{Label: "IC", Placeholder: "IC"},
-			{Label: "Slug", Placeholder: "Slug"},
+			{Label: "Email", Placeholder: "Email", Validators: []ui.Validator{ui.IsEmail}},
+			{Label: "IC", Placeholder: "IC", Validators: []ui.Validator{validIC}},
+			{Label: "Slug", Placeholder: "Slug", Validators: []ui.Validator{validSlug}},
+			{Label: "Settings", Placeholder: "{}", Kind: ui.TextAreaField, Ext: ".json"},
 		}
 	},
 	CreateArgs: func(fields map[string]string) []string {
@@ -89,12 +118,25 @@ var CompanyDef = &EntityDef{
 		if v := fields["Slug"]; v != "" {
 			args = append(args, "--slug", v)
 		}
+		if v := fields["Settings"]; v != "" {
+			args = append(args, "--settings", v)
+		}
 		return args
 	},
+	Duplicate: func(data interface{}) map[string]string {
+		co := data.(cli.Company)
+		return map[string]string{
+			"Name":     co.Name,
+			"Email":    co.Email,
+			"Settings": companySettings(co),
+		}
+	},
+	Record:   func() interface{} { return &cli.Company{} },
 	GetID:    func(data interface{}) int { return data.(cli.Company).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("Company: %s", data.(cli.Company).Name) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("Company: %s", data.(cli.Company).Name) },
 	Actions: []ui.ActionDef{
 		{Label: "Edit", Key: "e", Command: "edit"},
+		{Label: "Duplicate", Key: "D", Command: "duplicate"},
 		{Label: "Delete", Key: "d", Command: "delete"},
 	},
 }
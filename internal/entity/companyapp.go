@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -27,8 +28,9 @@ var CompanyAppDef = &EntityDef{
 			{Label: "App ID", Value: fmt.Sprintf("%d", ca.AppID)},
 		}
 	},
+	Record:   func() interface{} { return &cli.CompanyApp{} },
 	GetID:    func(data interface{}) int { return data.(cli.CompanyApp).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("CompanyApp %d", data.(cli.CompanyApp).ID) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("CompanyApp %d", data.(cli.CompanyApp).ID) },
 	Actions:  []ui.ActionDef{},
 	ListActions: []ui.ListActionDef{
 		{
@@ -39,8 +41,8 @@ var CompanyAppDef = &EntityDef{
 					form := NewActionFormView(
 						"Assign Application to Company",
 						[]ui.EditorField{
-							{Label: "Company ID", Placeholder: "Company ID (number)", Required: true},
-							{Label: "App ID", Placeholder: "Application ID (number)", Required: true},
+							{Label: "Company ID", Placeholder: "Company ID", Required: true, Kind: ui.PickerField, Ref: CompanyDef.CLIEntity},
+							{Label: "App ID", Placeholder: "Application ID", Required: true, Kind: ui.PickerField, Ref: ApplicationDef.CLIEntity},
 						},
 						func(fields map[string]string) tea.Cmd {
 							return func() tea.Msg {
@@ -52,14 +54,14 @@ var CompanyAppDef = &EntityDef{
 								viewer := ui.NewViewer("Assign Result")
 								viewer.RefreshOnBack = true
 								if err != nil {
-									viewer.SetContent("Assign Result", fmt.Sprintf("Error: %v\n\n%s", err, string(out)))
+									viewer.SetContent("Assign Result", i18n.Tf("Error: %v\n\n%s", err, string(out)))
 								} else {
 									viewer.SetContent("Assign Result", string(out))
 								}
 								return ui.NavigateToMsg{View: viewer}
 							}
 						},
-					)
+					).WithPickers(c)
 					return ui.NavigateToMsg{View: form}
 				}
 			},
@@ -72,8 +74,8 @@ var CompanyAppDef = &EntityDef{
 					form := NewActionFormView(
 						"Unassign Application from Company",
 						[]ui.EditorField{
-							{Label: "Company ID", Placeholder: "Company ID (number)", Required: true},
-							{Label: "App ID", Placeholder: "Application ID (number)", Required: true},
+							{Label: "Company ID", Placeholder: "Company ID", Required: true, Kind: ui.PickerField, Ref: CompanyDef.CLIEntity},
+							{Label: "App ID", Placeholder: "Application ID", Required: true, Kind: ui.PickerField, Ref: ApplicationDef.CLIEntity},
 						},
 						func(fields map[string]string) tea.Cmd {
 							return func() tea.Msg {
@@ -85,14 +87,14 @@ var CompanyAppDef = &EntityDef{
 								viewer := ui.NewViewer("Unassign Result")
 								viewer.RefreshOnBack = true
 								if err != nil {
-									viewer.SetContent("Unassign Result", fmt.Sprintf("Error: %v\n\n%s", err, string(out)))
+									viewer.SetContent("Unassign Result", i18n.Tf("Error: %v\n\n%s", err, string(out)))
 								} else {
 									viewer.SetContent("Unassign Result", string(out))
 								}
 								return ui.NavigateToMsg{View: viewer}
 							}
 						},
-					)
+					).WithPickers(c)
 					return ui.NavigateToMsg{View: form}
 				}
 			},
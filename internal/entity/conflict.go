@@ -0,0 +1,216 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// versionFields are the JSON names of the modification timestamps records
+// carry.
+var versionFields = []string{"DatUpdate", "DatSave", "updated_at"}
+
+const (
+	conflictHelp = "o: overwrite • m: merge field by field • esc: abort"
+	mergeHelp    = "↑/↓: field • space/←/→: theirs or mine • enter: apply • esc: back"
+)
+
+// version identifies the state of a record: its modification timestamp, or
+// a hash of the whole record when it has none.
+func version(data interface{}) string {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Struct {
+		for i := 0; i < v.NumField(); i++ {
+			tag := strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+			f := v.Field(i)
+			for _, name := range versionFields {
+				if tag == name && f.Kind() == reflect.String && f.String() != "" {
+					return tag + " " + f.String()
+				}
+			}
+		}
+	}
+	raw, _ := json.Marshal(data)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// conflictMsg reports that the record of an editor was changed by someone
+// else since the editor opened it; theirs is the record as it is now.
+type conflictMsg struct {
+	theirs interface{}
+}
+
+// conflictRow is a field that differs between the original record, theirs
+// and the editor.
+type conflictRow struct {
+	label                  string
+	original, theirs, mine string
+	secret                 bool
+	useTheirs              bool // the merge keeps their value
+}
+
+// both reports whether the field was changed both here and elsewhere, to
+// different values.
+func (r conflictRow) both() bool {
+	return r.mine != r.original && r.theirs != r.original && r.mine != r.theirs
+}
+
+// conflict is the three-way comparison an editor shows when its record was
+// changed meanwhile. The user overwrites the record with the editor's
+// values, merges field by field or aborts the save.
+type conflict struct {
+	theirs  interface{}
+	rows    []conflictRow
+	merging bool
+	cursor  int
+}
+
+// newConflict compares the original record with theirs and the edited
+// values of form. A merge starts with their value for the fields only they
+// changed and with the editor's value for the rest.
+func newConflict(def *EntityDef, original, theirs interface{}, form *ui.Form) *conflict {
+	orig := ui.NewForm(def.ToEditor(original)).Values()
+	fields := def.ToEditor(theirs)
+	their := ui.NewForm(fields).Values()
+	mine := form.Values()
+	c := &conflict{theirs: theirs}
+	for _, f := range fields {
+		r := conflictRow{
+			label:    f.Label,
+			original: orig[f.Label],
+			theirs:   their[f.Label],
+			mine:     mine[f.Label],
+			secret:   f.Kind == ui.SecretField,
+		}
+		if r.original == r.theirs && r.theirs == r.mine {
+			continue
+		}
+		r.useTheirs = r.mine == r.original
+		c.rows = append(c.rows, r)
+	}
+	return c
+}
+
+// updateConflict handles a key while the comparison is shown.
+func (m *EditorView) updateConflict(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := m.conflict
+	key := msg.String()
+	if !c.merging {
+		switch key {
+		case "o":
+			for i := range c.rows {
+				c.rows[i].useTheirs = false
+			}
+			return m.resolve()
+		case "m":
+			if len(c.rows) > 0 {
+				c.merging = true
+			}
+		case "esc", "a":
+			m.conflict = nil
+			return m, func() tea.Msg { return ui.StatusMsg{Text: i18n.T("Save aborted")} }
+		}
+		return m, nil
+	}
+	switch key {
+	case "up", "shift+tab":
+		if c.cursor > 0 {
+			c.cursor--
+		}
+	case "down", "tab":
+		if c.cursor < len(c.rows)-1 {
+			c.cursor++
+		}
+	case " ", "left", "right":
+		c.rows[c.cursor].useTheirs = !c.rows[c.cursor].useTheirs
+	case "enter":
+		return m.resolve()
+	case "esc":
+		c.merging = false
+	}
+	return m, nil
+}
+
+// resolve bases the editor on their record, keeps the values the user
+// chose and saves again, with a preview of the changes to their record.
+func (m *EditorView) resolve() (tea.Model, tea.Cmd) {
+	c := m.conflict
+	m.conflict = nil
+	m.data = c.theirs
+	for _, f := range m.def.ToEditor(c.theirs) {
+		m.form.SetOriginal(f.Label, f.Value)
+	}
+	for _, r := range c.rows {
+		if r.useTheirs {
+			m.form.SetValue(r.label, r.theirs)
+		}
+	}
+	return m.save()
+}
+
+// view renders the comparison in place of the form.
+func (c *conflict) view(width int) string {
+	labelW := 0
+	for _, r := range c.rows {
+		labelW = max(labelW, ui.Width(i18n.T(r.label)))
+	}
+	colW := previewWidth
+	if width > 0 {
+		colW = min(colW, max((width-labelW-10)/3, 8))
+	}
+	cell := func(s string, w int) string { return ui.PadRight(ui.Truncate(s, w), w) }
+
+	var b strings.Builder
+	b.WriteString(ui.ErrorStyle().Render(i18n.T("The record was changed by someone else since you opened it.")))
+	b.WriteString("\n\n")
+	b.WriteString("  " + cell("", labelW) + "  ")
+	for _, h := range []string{"Original", "Theirs", "Mine"} {
+		b.WriteString(ui.DescriptionStyle().Render(cell(i18n.T(h), colW)) + "  ")
+	}
+	b.WriteString("\n")
+	for i, r := range c.rows {
+		mark := "  "
+		if c.merging && i == c.cursor {
+			mark = "▸ "
+		}
+		label := cell(i18n.T(r.label), labelW)
+		if r.both() {
+			label = ui.ErrorStyle().Render(label)
+		}
+		b.WriteString(mark + label + "  ")
+		show := func(v string) string {
+			if r.secret && v != "" {
+				return "••••••"
+			}
+			return previewValue(v, colW)
+		}
+		b.WriteString(cell(show(r.original), colW) + "  ")
+		theirs, mine := cell(show(r.theirs), colW), cell(show(r.mine), colW)
+		if c.merging {
+			if r.useTheirs {
+				theirs = ui.SelectedStyle().Render(theirs)
+			} else {
+				mine = ui.SelectedStyle().Render(mine)
+			}
+		}
+		b.WriteString(theirs + "  " + mine + "\n")
+	}
+	if len(c.rows) == 0 {
+		b.WriteString(ui.DescriptionStyle().Render(i18n.T("None of the edited fields differ.")) + "\n")
+	}
+	b.WriteString("\n")
+	help := conflictHelp
+	if c.merging {
+		help = mergeHelp
+	}
+	b.WriteString(ui.FooterStyle().Render(i18n.T(help)))
+	b.WriteString("\n")
+	return b.String()
+}
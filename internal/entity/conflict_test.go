@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestVersion(t *testing.T) {
+	a := cli.RunTemplate{ID: 1, Name: "Daily", DatSave: "2026-10-01 10:00:00"}
+	b := a
+	b.Name = "Nightly"
+	if version(a) != version(b) {
+		t.Error("records with the same timestamp should have the same version")
+	}
+	b.DatSave = "2026-10-02 10:00:00"
+	if version(a) == version(b) {
+		t.Error("a newer timestamp should change the version")
+	}
+	src := cli.EventSource{ID: 3, Name: "Shop"}
+	changed := src
+	changed.DbHost = "db.local"
+	if version(src) == version(changed) || version(src) != version(src) {
+		t.Error("records without a timestamp should be compared whole")
+	}
+}
+
+// saveEditor saves ev, confirms the preview and returns the messages of the
+// update.
+func saveEditor(t *testing.T, ev *EditorView) []tea.Msg {
+	t.Helper()
+	_, cmd := ev.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msgs := cmdMsgs(cmd)
+	confirm, ok := msgs[0].(ui.ConfirmMsg)
+	if len(msgs) != 1 || !ok {
+		t.Fatalf("save = %v, want a preview", msgs)
+	}
+	_, cmd = ev.Update(confirm.Action())
+	return cmdMsgs(cmd)
+}
+
+func TestEditorViewConflict(t *testing.T) {
+	original := cli.RunTemplate{ID: 7, Name: "Daily", Interv: "d", Cron: "0 1 * * *", Executor: "Native", Active: 1, DatSave: "2026-10-01 10:00:00"}
+	theirs := original
+	theirs.Name = "Daily import"
+	theirs.Cron = "0 2 * * *"
+	theirs.DatSave = "2026-10-01 11:00:00"
+	stored, _ := json.Marshal(theirs)
+	c := &updateClient{fakeClient: fakeClient{getJSON: string(stored)}}
+
+	ev := NewEditorView(c, RunTemplateDef, original, false)
+	ev.form.SetValue("Name", "Daily export")
+	ev.form.SetValue("Active", "0")
+	msgs := saveEditor(t, ev)
+	if len(msgs) != 1 {
+		t.Fatalf("update = %v", msgs)
+	}
+	ev.Update(msgs[0])
+	if ev.conflict == nil || len(c.updated) != 0 {
+		t.Fatalf("a record changed elsewhere should not be updated: %v", c.updated)
+	}
+
+	rows := map[string]conflictRow{}
+	for _, r := range ev.conflict.rows {
+		rows[r.label] = r
+	}
+	if len(rows) != 3 || !rows["Name"].both() || rows["Name"].useTheirs || !rows["Cron"].useTheirs || rows["Active"].useTheirs {
+		t.Fatalf("rows = %+v", ev.conflict.rows)
+	}
+	view := ev.View()
+	for _, want := range []string{"Original", "Theirs", "Mine", "Daily import", "Daily export", "0 2 * * *"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view lacks %q:\n%s", want, view)
+		}
+	}
+
+	// Merge: their name, their cron, my Active.
+	ev.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	ev.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	_, cmd := ev.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msgs = cmdMsgs(cmd)
+	confirm, ok := msgs[0].(ui.ConfirmMsg)
+	if !ok || !strings.Contains(confirm.Label, "Active") || strings.Contains(confirm.Label, "Cron") {
+		t.Fatalf("merge = %v, want a preview of the Active change", msgs)
+	}
+	_, cmd = ev.Update(confirm.Action())
+	cmdMsgs(cmd)
+	want := [][]string{{"--id", "7", "--active", "0"}}
+	if !reflect.DeepEqual(c.updated, want) {
+		t.Errorf("updated %v, want %v", c.updated, want)
+	}
+}
+
+func TestEditorViewConflictOverwriteAndAbort(t *testing.T) {
+	original := cli.RunTemplate{ID: 7, Name: "Daily", Interv: "d", Executor: "Native", Active: 1, DatSave: "2026-10-01 10:00:00"}
+	theirs := original
+	theirs.Interv = "w"
+	theirs.DatSave = "2026-10-01 11:00:00"
+	stored, _ := json.Marshal(theirs)
+	c := &updateClient{fakeClient: fakeClient{getJSON: string(stored)}}
+
+	ev := NewEditorView(c, RunTemplateDef, original, false)
+	ev.form.SetValue("Name", "Weekly")
+	ev.Update(saveEditor(t, ev)[0])
+	_, cmd := ev.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if msgs := cmdMsgs(cmd); ev.conflict != nil || len(msgs) != 1 || msgs[0] != (ui.StatusMsg{Text: "Save aborted"}) {
+		t.Fatalf("abort = %v", msgs)
+	}
+	if ev.form.Values()["Name"] != "Weekly" {
+		t.Error("aborting should keep the edits")
+	}
+
+	ev.Update(saveEditor(t, ev)[0])
+	_, cmd = ev.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	confirm := cmdMsgs(cmd)[0].(ui.ConfirmMsg)
+	_, cmd = ev.Update(confirm.Action())
+	cmdMsgs(cmd)
+	want := [][]string{{"--id", "7", "--name", "Weekly", "--interv", "d"}}
+	if !reflect.DeepEqual(c.updated, want) {
+		t.Errorf("updated %v, want %v", c.updated, want)
+	}
+}
@@ -4,13 +4,14 @@ import (
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 )
 
 var CredentialDef = &EntityDef{
 	Name: "🔑 Credentials", CLIEntity: "credential", DeleteAction: "remove", Limit: 10,
 	Columns: []ui.TableColumn{
-		{Header: "ID", Width: 8, Field: "id"}, {Header: "Name", Width: 25, Field: "name"},
+		{Header: "ID", Width: 8, Field: "id"}, {Header: "Name", Width: 25, Field: "name", Flex: 2},
 		{Header: "Company", Width: 12, Field: "company_id"}, {Header: "Type", Width: 12, Field: "type_id"},
 	},
 	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
@@ -39,18 +40,14 @@ var CredentialDef = &EntityDef{
 	ToEditor: func(data interface{}) []ui.EditorField {
 		cr := data.(cli.Credential)
 		return []ui.EditorField{
-			{Label: "Name", Placeholder: "Credential name", Value: cr.Name},
+			{Label: "Name", Flag: "--name", Placeholder: "Credential name", Value: cr.Name, Required: true},
 		}
 	},
-	UpdateArgs: func(data interface{}, fields map[string]string) []string {
-		cr := data.(cli.Credential)
-		return []string{"--id", fmt.Sprintf("%d", cr.ID), "--name", fields["Name"]}
-	},
 	NewFields: func() []ui.EditorField {
 		return []ui.EditorField{
 			{Label: "Name", Placeholder: "Credential name", Required: true},
-			{Label: "Company ID", Placeholder: "Company ID", Required: true},
-			{Label: "CredType ID", Placeholder: "Credential Type ID", Required: true},
+			{Label: "Company ID", Placeholder: "Company ID", Required: true, Kind: ui.PickerField, Ref: CompanyDef.CLIEntity},
+			{Label: "CredType ID", Placeholder: "Credential Type ID", Required: true, Kind: ui.PickerField, Ref: CredTypeDef.CLIEntity},
 		}
 	},
 	CreateArgs: func(fields map[string]string) []string {
@@ -60,10 +57,20 @@ var CredentialDef = &EntityDef{
 			"--credential-type-id", fields["CredType ID"],
 		}
 	},
+	Duplicate: func(data interface{}) map[string]string {
+		cr := data.(cli.Credential)
+		return map[string]string{
+			"Name":        cr.Name,
+			"Company ID":  fmt.Sprintf("%d", cr.CompanyID),
+			"CredType ID": fmt.Sprintf("%d", cr.CredentialTypeID),
+		}
+	},
+	Record:   func() interface{} { return &cli.Credential{} },
 	GetID:    func(data interface{}) int { return data.(cli.Credential).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("Credential: %s", data.(cli.Credential).Name) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("Credential: %s", data.(cli.Credential).Name) },
 	Actions: []ui.ActionDef{
 		{Label: "Edit", Key: "e", Command: "edit"},
+		{Label: "Duplicate", Key: "D", Command: "duplicate"},
 		{Label: "Delete", Key: "d", Command: "delete"},
 	},
 }
@@ -4,14 +4,15 @@ import (
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 )
 
 var CredTypeDef = &EntityDef{
 	Name: "🏷️ Credential Types", CLIEntity: "credtype", DeleteAction: "delete", Limit: 10,
 	Columns: []ui.TableColumn{
-		{Header: "ID", Width: 6, Field: "id"}, {Header: "Name", Width: 30, Field: "name"},
-		{Header: "Class", Width: 35, Field: "class"}, {Header: "Version", Width: 8, Field: "version"},
+		{Header: "ID", Width: 6, Field: "id"}, {Header: "Name", Width: 30, Field: "name", Flex: 2},
+		{Header: "Class", Width: 35, Field: "class", Flex: 1}, {Header: "Version", Width: 8, Field: "version"},
 	},
 	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
 		var items []cli.CredType
@@ -42,18 +43,14 @@ var CredTypeDef = &EntityDef{
 	ToEditor: func(data interface{}) []ui.EditorField {
 		t := data.(cli.CredType)
 		return []ui.EditorField{
-			{Label: "Name", Placeholder: "Credential type name", Value: t.Name},
-			{Label: "Class", Placeholder: "PHP class name", Value: t.Class},
+			{Label: "Name", Flag: "--name", Placeholder: "Credential type name", Value: t.Name, Required: true},
+			{Label: "Class", Flag: "--class", Placeholder: "PHP class name", Value: t.Class},
 		}
 	},
-	UpdateArgs: func(data interface{}, fields map[string]string) []string {
-		t := data.(cli.CredType)
-		return []string{"--id", fmt.Sprintf("%d", t.ID), "--name", fields["Name"], "--class", fields["Class"]}
-	},
 	NewFields: func() []ui.EditorField {
 		return []ui.EditorField{
 			{Label: "Name", Placeholder: "Credential type name", Required: true},
-			{Label: "Company ID", Placeholder: "Company ID", Required: true},
+			{Label: "Company ID", Placeholder: "Company ID", Required: true, Kind: ui.IntField},
 			{Label: "Class", Placeholder: "PHP class name", Required: true},
 		}
 	},
@@ -64,9 +61,21 @@ var CredTypeDef = &EntityDef{
 			"--class", fields["Class"],
 		}
 	},
+	Duplicate: func(data interface{}) map[string]string {
+		ct := data.(cli.CredType)
+		return map[string]string{
+			"Name":       ct.Name,
+			"Company ID": fmt.Sprintf("%d", ct.CompanyID),
+			"Class":      ct.Class,
+		}
+	},
+	Record:   func() interface{} { return &cli.CredType{} },
 	GetID:    func(data interface{}) int { return data.(cli.CredType).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("CredType: %s", data.(cli.CredType).Name) },
-	Actions:  []ui.ActionDef{{Label: "Edit", Key: "e", Command: "edit"}},
+	GetLabel: func(data interface{}) string { return i18n.Tf("CredType: %s", data.(cli.CredType).Name) },
+	Actions: []ui.ActionDef{
+		{Label: "Edit", Key: "e", Command: "edit"},
+		{Label: "Duplicate", Key: "D", Command: "duplicate"},
+	},
 }
 
 func init() { Register(Entry{Label: "CredTypes", Hint: "Manage credential types", Def: CredTypeDef}) }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -11,8 +12,8 @@ import (
 var CrPrototypeDef = &EntityDef{
 	Name: "🧬 Credential Prototypes", CLIEntity: "crprototype", DeleteAction: "delete", Limit: 10,
 	Columns: []ui.TableColumn{
-		{Header: "ID", Width: 6, Field: "id"}, {Header: "Code", Width: 20, Field: "code"},
-		{Header: "Name", Width: 30, Field: "name"}, {Header: "Version", Width: 10, Field: "version"},
+		{Header: "ID", Width: 6, Field: "id"}, {Header: "Code", Width: 20, Field: "code", Flex: 1},
+		{Header: "Name", Width: 30, Field: "name", Flex: 2}, {Header: "Version", Width: 10, Field: "version"},
 	},
 	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
 		var items []cli.CrPrototype
@@ -44,34 +45,20 @@ var CrPrototypeDef = &EntityDef{
 	ToEditor: func(data interface{}) []ui.EditorField {
 		p := data.(cli.CrPrototype)
 		return []ui.EditorField{
-			{Label: "Name", Placeholder: "Prototype name", Value: p.Name},
-			{Label: "Code", Placeholder: "Prototype code", Value: p.Code},
-			{Label: "Description", Placeholder: "Description", Value: p.Description},
-			{Label: "Version", Placeholder: "1.0.0", Value: p.Version},
-			{Label: "URL", Placeholder: "Homepage URL", Value: p.URL},
+			{Label: "Name", Flag: "--name", Placeholder: "Prototype name", Value: p.Name, Required: true},
+			{Label: "Code", Flag: "--code", Placeholder: "Prototype code", Value: p.Code},
+			{Label: "Description", Flag: "--description", Placeholder: "Description", Value: p.Description},
+			{Label: "Version", Flag: "--prototype-version", Placeholder: "1.0.0", Value: p.Version, Validators: []ui.Validator{validVersion}},
+			{Label: "URL", Flag: "--url", Placeholder: "Homepage URL", Value: p.URL, Validators: []ui.Validator{validURL}},
 		}
 	},
-	UpdateArgs: func(data interface{}, fields map[string]string) []string {
-		p := data.(cli.CrPrototype)
-		args := []string{"--id", fmt.Sprintf("%d", p.ID), "--name", fields["Name"], "--code", fields["Code"]}
-		if v := fields["Description"]; v != "" {
-			args = append(args, "--description", v)
-		}
-		if v := fields["Version"]; v != "" {
-			args = append(args, "--prototype-version", v)
-		}
-		if v := fields["URL"]; v != "" {
-			args = append(args, "--url", v)
-		}
-		return args
-	},
 	NewFields: func() []ui.EditorField {
 		return []ui.EditorField{
 			{Label: "Name", Placeholder: "Prototype name", Required: true},
 			{Label: "Code", Placeholder: "Prototype code", Required: true},
 			{Label: "Description", Placeholder: "Description"},
-			{Label: "Version", Placeholder: "1.0.0"},
-			{Label: "URL", Placeholder: "Homepage URL"},
+			{Label: "Version", Placeholder: "1.0.0", Validators: []ui.Validator{validVersion}},
+			{Label: "URL", Placeholder: "Homepage URL", Validators: []ui.Validator{validURL}},
 		}
 	},
 	CreateArgs: func(fields map[string]string) []string {
@@ -87,10 +74,21 @@ var CrPrototypeDef = &EntityDef{
 		}
 		return args
 	},
+	Duplicate: func(data interface{}) map[string]string {
+		p := data.(cli.CrPrototype)
+		return map[string]string{
+			"Name":        p.Name,
+			"Description": p.Description,
+			"Version":     p.Version,
+			"URL":         p.URL,
+		}
+	},
+	Record:   func() interface{} { return &cli.CrPrototype{} },
 	GetID:    func(data interface{}) int { return data.(cli.CrPrototype).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("CrPrototype: %s", data.(cli.CrPrototype).Name) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("CrPrototype: %s", data.(cli.CrPrototype).Name) },
 	Actions: []ui.ActionDef{
 		{Label: "Edit", Key: "e", Command: "edit"},
+		{Label: "Duplicate", Key: "D", Command: "duplicate"},
 		{Label: "Delete", Key: "d", Command: "delete"},
 	},
 	ListActions: []ui.ListActionDef{
@@ -102,7 +100,7 @@ var CrPrototypeDef = &EntityDef{
 				return func() tea.Msg {
 					output, err := c.RunRaw("crprototype", "sync", "--format=json")
 					if err != nil {
-						return ui.StatusMsg{Text: fmt.Sprintf("Sync failed: %v", err)}
+						return ui.StatusMsg{Text: i18n.Tf("Sync failed: %v", err)}
 					}
 					viewer := ui.NewViewer("CrPrototype Sync Result")
 					viewer.RefreshOnBack = true
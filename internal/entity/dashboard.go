@@ -0,0 +1,735 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/alert"
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DashboardInterval is how often the dashboard reloads; main sets it from
+// the --refresh flag. Zero disables auto-refresh.
+var DashboardInterval = 30 * time.Second
+
+const (
+	dashboardScan       = 100 // newest jobs and queue entries the panels look at
+	dashboardTemplates  = 500 // RunTemplates checked for overdue schedules
+	dashboardNames      = 500 // applications and companies named in the panels
+	dashboardLines      = 5   // entries listed per panel at most
+	dashboardTwoColumns = 90  // narrower terminals stack the panels
+	dashboardPanels     = 7
+	dashboardHelp       = "↑/↓: select • enter: open list • r: refresh"
+)
+
+// Alerts evaluates the health rules on every dashboard poll; main loads its
+// rules from alerts.json. Without rules nothing is raised.
+var Alerts = alert.NewMonitor(nil)
+
+// now is the clock overdue schedules are judged by.
+var now = time.Now
+
+// Dashboard targets: the panel or count line the cursor is on and the list
+// it opens.
+const (
+	targetHealth  = "health"
+	targetQueue   = "queue"
+	targetRunning = "running"
+	targetFailed  = "failed"
+	targetOverdue = "overdue"
+	targetHistory = "history"
+	targetCount   = "count:" // followed by the CLI entity
+)
+
+// dashboardData is one snapshot of the system.
+type dashboardData struct {
+	status    *cli.StatusInfo
+	jobs      []cli.Job // newest first
+	queue     []cli.Queue
+	templates []cli.RunTemplate
+	errs      map[string]error // by target whose data failed to load
+	at        time.Time
+}
+
+type dashboardLoadedMsg struct {
+	gen       int
+	data      *dashboardData
+	apps      map[int]string // nil when the names were not reloaded
+	companies map[int]string
+}
+
+type dashboardTickMsg struct{ gen int }
+
+// Polls reach the dashboard while another view is in front, so it keeps
+// refreshing and evaluating the alert rules.
+func (dashboardLoadedMsg) HomeMsg() {}
+func (dashboardTickMsg) HomeMsg()   {}
+
+// Dashboard is the home screen: panels with scheduler and executor health,
+// the queue, running jobs, recent failures, a job history sparkline,
+// overdue RunTemplates and record counts. It reloads every
+// DashboardInterval, also in the background while other views are in
+// front, and opens the matching list for the selected panel.
+type Dashboard struct {
+	client cli.Client
+	data   *dashboardData
+
+	// Names of applications and companies, loaded once.
+	apps, companies map[int]string
+
+	loading bool
+	gen     int // generation of the last load; older results and ticks are dropped
+	cursor  int // index into targets()
+	width   int
+	height  int
+	zones   ui.Zones
+}
+
+// NewDashboard creates the dashboard; Init loads it.
+func NewDashboard(c cli.Client) *Dashboard {
+	return &Dashboard{client: c}
+}
+
+func (m *Dashboard) Init() tea.Cmd { return m.Refresh() }
+
+// Refresh satisfies ui.Refreshable — reloads every panel.
+func (m *Dashboard) Refresh() tea.Cmd {
+	m.gen++
+	m.loading = true
+	gen, client, names := m.gen, m.client, m.apps == nil
+	return func() tea.Msg {
+		msg := dashboardLoadedMsg{gen: gen, data: loadDashboard(client)}
+		if names {
+			msg.apps, msg.companies = loadNames(client)
+		}
+		return msg
+	}
+}
+
+// loadDashboard queries everything the panels show. A failing query only
+// blanks its panels.
+func loadDashboard(c cli.Client) *dashboardData {
+	d := &dashboardData{errs: map[string]error{}, at: now()}
+	var err error
+	if d.status, err = c.GetStatus(); err != nil {
+		d.errs[targetHealth] = err
+	}
+	if err := c.List("job", dashboardScan, 0, &d.jobs); err != nil {
+		d.errs[targetRunning], d.errs[targetFailed] = err, err
+	}
+	if err := c.List("queue", dashboardScan, 0, &d.queue); err != nil {
+		d.errs[targetQueue] = err
+	}
+	if err := c.List("runtemplate", dashboardTemplates, 0, &d.templates); err != nil {
+		d.errs[targetOverdue] = err
+	}
+	return d
+}
+
+// loadNames maps application and company IDs to names. Missing names fall
+// back to IDs, so errors are ignored.
+func loadNames(c cli.Client) (apps, companies map[int]string) {
+	apps, companies = map[int]string{}, map[int]string{}
+	var a []cli.Application
+	if c.List("application", dashboardNames, 0, &a) == nil {
+		for _, x := range a {
+			apps[x.ID] = x.Name
+		}
+	}
+	var co []cli.Company
+	if c.List("company", dashboardNames, 0, &co) == nil {
+		for _, x := range co {
+			companies[x.ID] = x.Name
+		}
+	}
+	return apps, companies
+}
+
+func (m *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		// Polls reach the dashboard in the background too, so the data is
+		// stale here only when no poll ran, e.g. before the first load.
+		if !m.loading && m.stale() {
+			return m, m.Refresh()
+		}
+		return m, nil
+
+	case dashboardLoadedMsg:
+		if msg.gen != m.gen {
+			return m, nil
+		}
+		m.data, m.loading = msg.data, false
+		if msg.apps != nil {
+			m.apps, m.companies = msg.apps, msg.companies
+		}
+		alerts := checkAlerts(msg.data)
+		if DashboardInterval <= 0 {
+			return m, alerts
+		}
+		gen := m.gen
+		return m, tea.Batch(alerts, tea.Tick(DashboardInterval, func(time.Time) tea.Msg { return dashboardTickMsg{gen: gen} }))
+
+	case dashboardTickMsg:
+		if msg.gen != m.gen || m.loading {
+			return m, nil
+		}
+		return m, m.Refresh()
+
+	case ui.ClickMsg:
+		id, ok := m.zones.Hit(msg.X, msg.Y)
+		if !ok {
+			return m, nil
+		}
+		for i, t := range m.targets() {
+			if t == id {
+				m.cursor = i
+			}
+		}
+		return m, m.open(id)
+
+	case tea.KeyMsg:
+		targets := m.targets()
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(targets)-1 {
+				m.cursor++
+			}
+		case "enter", " ":
+			if m.cursor < len(targets) {
+				return m, m.open(targets[m.cursor])
+			}
+		case "r":
+			if !m.loading {
+				return m, m.Refresh()
+			}
+		}
+	}
+	return m, nil
+}
+
+// stale reports whether the shown data is older than the refresh interval.
+func (m *Dashboard) stale() bool {
+	if m.data == nil {
+		return true
+	}
+	return DashboardInterval > 0 && now().Sub(m.data.at) >= DashboardInterval
+}
+
+// open navigates to the list behind a target.
+func (m *Dashboard) open(target string) tea.Cmd {
+	var view tea.Model
+	switch target {
+	case targetHealth:
+		v := ui.NewViewer("System Status")
+		v.SetContent("System Status", m.statusText())
+		view = v
+	case targetQueue:
+		view = NewListViewForEntity(m.client, QueueDef)
+	case targetRunning:
+		lv := NewListView(m.client, JobDef)
+		lv.SetWhere("running", func(r ui.TableRow) bool { return jobStatus(r.FullData.(cli.Job)) == "Running" })
+		view = lv
+	case targetFailed:
+		lv := NewListView(m.client, JobDef)
+		lv.SetWhere("failed", func(r ui.TableRow) bool { return jobStatus(r.FullData.(cli.Job)) == "Failed" })
+		view = lv
+	case targetHistory:
+		view = NewStatistics(m.client)
+	case targetOverdue:
+		at := now()
+		lv := NewListView(m.client, RunTemplateDef)
+		lv.SetWhere("overdue", func(r ui.TableRow) bool {
+			_, late := overdue(r.FullData.(cli.RunTemplate), at)
+			return late
+		})
+		view = lv
+	default:
+		def := Lookup(strings.TrimPrefix(target, targetCount))
+		if def == nil {
+			return nil
+		}
+		view = NewListViewForEntity(m.client, def)
+	}
+	return func() tea.Msg { return ui.NavigateToMsg{View: view} }
+}
+
+// dashboardMetrics returns the alert metrics of a snapshot. Metrics whose
+// data failed to load are left out.
+func dashboardMetrics(d *dashboardData) alert.Metrics {
+	m := alert.Metrics{}
+	if s := d.status; d.errs[targetHealth] == nil && s != nil {
+		m[alert.Scheduler], m[alert.Executor], m[alert.Database] = s.Scheduler, s.Executor, s.Database
+	}
+	if d.errs[targetQueue] == nil {
+		m[alert.Queue] = strconv.Itoa(len(d.queue))
+	}
+	if d.errs[targetRunning] == nil {
+		running, failed := 0, 0
+		hourAgo := d.at.Add(-time.Hour)
+		for _, j := range d.jobs {
+			switch jobStatus(j) {
+			case "Running":
+				running++
+			case "Failed":
+				t, ok := parseTime(j.End)
+				if !ok {
+					t, ok = jobTime(j)
+				}
+				if ok && t.After(hourAgo) {
+					failed++
+				}
+			}
+		}
+		m[alert.Running], m[alert.FailedLastHour] = strconv.Itoa(running), strconv.Itoa(failed)
+	}
+	if d.errs[targetOverdue] == nil {
+		n := 0
+		for _, rt := range d.templates {
+			if _, late := overdue(rt, d.at); late {
+				n++
+			}
+		}
+		m[alert.Overdue] = strconv.Itoa(n)
+	}
+	return m
+}
+
+// checkAlerts evaluates the alert rules against a snapshot. Rules that
+// start or stop breaching are announced and reported in the footer.
+func checkAlerts(d *dashboardData) tea.Cmd {
+	events := Alerts.Evaluate(dashboardMetrics(d), d.at)
+	if len(events) == 0 {
+		return nil
+	}
+	texts := make([]string, len(events))
+	for i, e := range events {
+		texts[i] = alertText(e)
+	}
+	text := strings.Join(texts, " • ")
+	return func() tea.Msg { return ui.NotifyMsg{Text: text} }
+}
+
+// alertText announces an event: "Alert: Queue backed up (queue 120 > 50)".
+func alertText(e alert.Event) string {
+	if e.Breached {
+		return i18n.Tf("Alert: %s (%s)", i18n.T(e.Rule), e.Detail())
+	}
+	return i18n.Tf("Resolved: %s (%s)", i18n.T(e.Rule), e.Detail())
+}
+
+// overdue reports how late an active RunTemplate's next run is at t.
+func overdue(rt cli.RunTemplate, t time.Time) (time.Duration, bool) {
+	if rt.Active == 0 || rt.NextSchedule == nil {
+		return 0, false
+	}
+	next, ok := parseTime(*rt.NextSchedule)
+	if !ok || !next.Before(t) {
+		return 0, false
+	}
+	return t.Sub(next), true
+}
+
+// parseTime reads the timestamps multiflexi-cli prints, in local time.
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02 15:04:05", time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// shortDuration renders d as its largest unit: 45s, 12m, 3h or 2d.
+func shortDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// entryText describes a listed record: its ID and the non-empty parts.
+func entryText(id int, parts ...string) string {
+	text := fmt.Sprintf("#%d", id)
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		if i == 0 {
+			text += " " + p
+		} else {
+			text += "  " + p
+		}
+	}
+	return text
+}
+
+// healthStyle colours service states: active green, inactive or failed dim.
+func healthStyle(v string) lipgloss.Style {
+	v = strings.ToLower(v)
+	switch {
+	case strings.HasPrefix(v, "active"):
+		return ui.ActiveStatusStyle()
+	case v == "inactive" || v == "disabled" || v == "failed":
+		return ui.DisabledStatusStyle()
+	}
+	return ui.DescriptionStyle()
+}
+
+// appCompany names a job's application and company.
+func (m *Dashboard) appCompany(appID, companyID int) string {
+	app, ok := m.apps[appID]
+	if !ok || app == "" {
+		app = fmt.Sprintf("#%d", appID)
+	}
+	company, ok := m.companies[companyID]
+	if !ok || company == "" {
+		company = fmt.Sprintf("#%d", companyID)
+	}
+	return app + " / " + company
+}
+
+// dashboardLine is a line of a panel: a label and a value in its own style.
+// Lines with a target can be selected and opened.
+type dashboardLine struct {
+	target string
+	label  string
+	value  string
+	style  lipgloss.Style
+	align  bool // pad the label to the widest aligned label of the panel
+}
+
+// dashboardPanel is a titled box; the title opens its target.
+type dashboardPanel struct {
+	target string
+	title  string
+	lines  []dashboardLine
+}
+
+// panels builds the panels from the loaded data, listing at most max
+// entries in each.
+func (m *Dashboard) panels(max int) []dashboardPanel {
+	d := m.data
+	desc := ui.DescriptionStyle()
+	errLine := func(err error) dashboardLine {
+		return dashboardLine{value: i18n.Tf("Error: %v", err), style: ui.ErrorStyle()}
+	}
+	more := func(lines []dashboardLine, n int) []dashboardLine {
+		if len(lines) > max {
+			extra := n - max + 1
+			lines = append(lines[:max-1], dashboardLine{value: i18n.Tf("… and %d more", extra), style: desc})
+		}
+		return lines
+	}
+	atLeast := func(n, scanned int) string {
+		if n >= scanned {
+			return fmt.Sprintf("%d+", n)
+		}
+		return fmt.Sprintf("%d", n)
+	}
+
+	// System health
+	health := dashboardPanel{target: targetHealth, title: "System"}
+	if err := d.errs[targetHealth]; err != nil {
+		health.lines = []dashboardLine{errLine(err)}
+	} else {
+		s := d.status
+		for _, l := range []struct{ label, value string }{
+			{"Scheduler", s.Scheduler}, {"Executor", s.Executor}, {"Database", s.Database}, {"CLI Version", s.VersionCli},
+		} {
+			if l.value != "" {
+				health.lines = append(health.lines, dashboardLine{label: l.label, value: l.value, style: healthStyle(l.value)})
+			}
+		}
+	}
+	health.lines = append(health.lines, dashboardLine{label: "Updated", value: d.at.Format("15:04:05"), style: desc})
+
+	// Queue depth and the next entries
+	queue := dashboardPanel{target: targetQueue, title: "Queue"}
+	if err := d.errs[targetQueue]; err != nil {
+		queue.lines = []dashboardLine{errLine(err)}
+	} else {
+		queue.lines = append(queue.lines, dashboardLine{label: "Waiting", value: atLeast(len(d.queue), dashboardScan), style: ui.ActiveMenuStyle()})
+		var entries []dashboardLine
+		for _, q := range d.queue {
+			entries = append(entries, dashboardLine{value: entryText(q.ID, q.After, q.AppName+" / "+q.CompanyName), style: desc})
+		}
+		queue.lines = append(queue.lines, more(entries, len(entries))...)
+	}
+
+	// Running jobs and recent failures among the newest jobs
+	running := dashboardPanel{target: targetRunning, title: "Running now"}
+	failed := dashboardPanel{target: targetFailed, title: "Recent failures"}
+	if err := d.errs[targetRunning]; err != nil {
+		running.lines = []dashboardLine{errLine(err)}
+		failed.lines = []dashboardLine{errLine(err)}
+	} else {
+		var run, fail []dashboardLine
+		for _, j := range d.jobs {
+			switch jobStatus(j) {
+			case "Running":
+				run = append(run, dashboardLine{value: entryText(j.ID, m.appCompany(j.AppID, j.CompanyID), j.Command, j.Begin), style: ui.ActiveStatusStyle()})
+			case "Failed":
+				fail = append(fail, dashboardLine{value: entryText(j.ID, m.appCompany(j.AppID, j.CompanyID), i18n.Tf("exit %d", j.Exitcode), j.End), style: ui.ErrorStyle()})
+			}
+		}
+		running.lines = append([]dashboardLine{{label: "Jobs", value: fmt.Sprintf("%d", len(run)), style: ui.ActiveMenuStyle()}}, more(run, len(run))...)
+		failed.lines = append([]dashboardLine{{label: "Failed", value: i18n.Tf("%d of the last %d jobs", len(fail), len(d.jobs)), style: ui.ActiveMenuStyle()}}, more(fail, len(fail))...)
+	}
+
+	// Outcomes of the newest jobs over the last 24 hours
+	history := dashboardPanel{target: targetHistory, title: "Job history"}
+	if err := d.errs[targetRunning]; err != nil {
+		history.lines = []dashboardLine{errLine(err)}
+	} else {
+		s := aggregateJobs(d.jobs, statsWindows[0], d.at)
+		history.lines = []dashboardLine{
+			{label: "Succeeded", value: ui.Sparkline(s.ok, len(s.ok), s.outcomeScale()), style: ui.ActiveStatusStyle(), align: true},
+			{label: "Failed", value: ui.Sparkline(s.failed, len(s.failed), s.outcomeScale()), style: ui.ErrorStyle(), align: true},
+		}
+	}
+
+	// RunTemplates whose next run is in the past
+	late := dashboardPanel{target: targetOverdue, title: "Overdue RunTemplates"}
+	if err := d.errs[targetOverdue]; err != nil {
+		late.lines = []dashboardLine{errLine(err)}
+	} else {
+		var entries []dashboardLine
+		for _, rt := range d.templates {
+			if by, ok := overdue(rt, d.at); ok {
+				entries = append(entries, dashboardLine{value: entryText(rt.ID, rt.Name, i18n.Tf("%s late", shortDuration(by))), style: ui.ErrorStyle()})
+			}
+		}
+		late.lines = append([]dashboardLine{{label: "Overdue", value: fmt.Sprintf("%d", len(entries)), style: ui.ActiveMenuStyle()}}, more(entries, len(entries))...)
+	}
+
+	// Record counts, each opening its list
+	counts := dashboardPanel{title: "Records"}
+	if s := d.status; s != nil {
+		for _, c := range []struct {
+			entity, label, value string
+		}{
+			{"company", "Companies", fmt.Sprintf("%d", s.Companies)},
+			{"application", "Applications", fmt.Sprintf("%d", s.Apps)},
+			{"runtemplate", "RunTemplates", fmt.Sprintf("%d", s.RunTemplates)},
+			{"credential", "Credentials", fmt.Sprintf("%d", s.Credentials)},
+			{"credtype", "Credential Types", fmt.Sprintf("%d", s.CredentialTypes)},
+			{"job", "Jobs", s.Jobs},
+		} {
+			if c.value == "" {
+				continue
+			}
+			counts.lines = append(counts.lines, dashboardLine{target: targetCount + c.entity, label: c.label, value: c.value, style: desc})
+		}
+	}
+	if len(counts.lines) > max+1 {
+		counts.lines = counts.lines[:max+1]
+	}
+
+	return []dashboardPanel{health, queue, running, failed, history, late, counts}
+}
+
+// targets lists the selectable targets in display order.
+func (m *Dashboard) targets() []string {
+	if m.data == nil {
+		return nil
+	}
+	var out []string
+	for _, p := range m.panels(m.panelLines()) {
+		if p.target != "" {
+			out = append(out, p.target)
+		}
+		for _, l := range p.lines {
+			if l.target != "" {
+				out = append(out, l.target)
+			}
+		}
+	}
+	return out
+}
+
+// columns returns how many panel columns fit the width.
+func (m *Dashboard) columns() int {
+	if m.width >= dashboardTwoColumns {
+		return 2
+	}
+	return 1
+}
+
+// panelLines is how many entries a panel lists so the dashboard fits the
+// height: each panel also takes a title and a blank line.
+func (m *Dashboard) panelLines() int {
+	if m.height == 0 {
+		return dashboardLines
+	}
+	perColumn := (dashboardPanels + m.columns() - 1) / m.columns()
+	n := (m.height-3)/perColumn - 2 // less title, help and blank lines
+	if n > dashboardLines {
+		n = dashboardLines
+	}
+	if n < 2 {
+		n = 2
+	}
+	return n
+}
+
+func (m *Dashboard) View() string {
+	var b strings.Builder
+	m.zones.Reset()
+	b.WriteString(ui.TitleStyle().Render(" " + i18n.T("MultiFlexi System Dashboard") + " "))
+	if m.loading && m.data != nil {
+		b.WriteString("  " + ui.DescriptionStyle().Render(i18n.T("refreshing…")))
+	}
+	b.WriteString("\n\n")
+	if m.data == nil {
+		b.WriteString(ui.DescriptionStyle().Render(i18n.T("Loading system status...")))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	targets := m.targets()
+	if m.cursor >= len(targets) {
+		m.cursor = len(targets) - 1
+	}
+	selected := ""
+	if m.cursor >= 0 && m.cursor < len(targets) {
+		selected = targets[m.cursor]
+	}
+
+	cols := m.columns()
+	width := m.width
+	if width == 0 {
+		width = 80
+	}
+	colW := (width - cols + 1) / cols
+	_, top := ui.Cursor(&b)
+	blocks := make([][]string, cols)
+	for i, p := range m.panels(m.panelLines()) {
+		c := i % cols
+		x := c * (colW + 1)
+		y := top + len(blocks[c])
+		blocks[c] = append(blocks[c], m.renderPanel(p, selected, x, y, colW)...)
+		blocks[c] = append(blocks[c], "")
+	}
+	rendered := make([]string, cols)
+	for c := range blocks {
+		rendered[c] = ui.FitBlock(strings.Join(blocks[c], "\n"), colW, 0)
+	}
+	if cols == 2 {
+		h := len(blocks[0])
+		if len(blocks[1]) > h {
+			h = len(blocks[1])
+		}
+		for c := range rendered {
+			rendered[c] = ui.FitBlock(rendered[c], colW, h)
+		}
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, rendered[0], " ", rendered[1]))
+	} else {
+		b.WriteString(rendered[0])
+	}
+	b.WriteString("\n")
+
+	help := i18n.T(dashboardHelp)
+	if DashboardInterval > 0 {
+		help += " • " + i18n.Tf("auto-refresh every %s", DashboardInterval)
+	}
+	b.WriteString(ui.FooterStyle().Render(help) + "\n")
+	return b.String()
+}
+
+// renderPanel renders p as lines of at most w columns placed at (x, y),
+// recording the zones of its targets.
+func (m *Dashboard) renderPanel(p dashboardPanel, selected string, x, y, w int) []string {
+	title := ui.Truncate(" "+i18n.T(p.title)+" ", w)
+	style := ui.TitleStyle()
+	if p.target != "" && p.target == selected {
+		style = ui.SelectedStyle()
+	}
+	if p.target != "" {
+		m.zones.Add(p.target, x, y, ui.Width(title))
+	}
+	lines := []string{style.Render(title)}
+	labelW := 0
+	for _, l := range p.lines {
+		if l.align && ui.Width(i18n.T(l.label)) > labelW {
+			labelW = ui.Width(i18n.T(l.label))
+		}
+	}
+	for i, l := range p.lines {
+		label := ""
+		if l.label != "" {
+			label = i18n.T(l.label) + ": "
+		}
+		if l.align {
+			label = ui.PadRight(label, labelW+2)
+		}
+		text := ui.Truncate("  "+label+l.value, w)
+		if l.target != "" {
+			m.zones.Add(l.target, x, y+1+i, ui.Width(text))
+			if l.target == selected {
+				lines = append(lines, ui.SelectedStyle().Render(text))
+				continue
+			}
+		}
+		if n := ui.Width("  " + label); n < ui.Width(text) {
+			lines = append(lines, "  "+label+l.style.Render(strings.TrimPrefix(text, "  "+label)))
+		} else {
+			lines = append(lines, text)
+		}
+	}
+	return lines
+}
+
+// statusText lists every field of the system status for the health view.
+func (m *Dashboard) statusText() string {
+	s := m.data.status
+	if s == nil {
+		return i18n.Tf("Error: %v", m.data.errs[targetHealth])
+	}
+	rows := []struct{ label, value string }{
+		{"CLI Version", s.VersionCli},
+		{"DB Migration", s.DbMigration},
+		{"User", s.User},
+		{"PHP", s.PHP},
+		{"OS", s.OS},
+		{"Memory", fmt.Sprintf("%d KB", s.Memory)},
+		{"Companies", fmt.Sprintf("%d", s.Companies)},
+		{"Applications", fmt.Sprintf("%d", s.Apps)},
+		{"RunTemplates", fmt.Sprintf("%d", s.RunTemplates)},
+		{"Topics", fmt.Sprintf("%d", s.Topics)},
+		{"Credentials", fmt.Sprintf("%d", s.Credentials)},
+		{"Credential Types", fmt.Sprintf("%d", s.CredentialTypes)},
+		{"Jobs", s.Jobs},
+		{"Executor", s.Executor},
+		{"Scheduler", s.Scheduler},
+		{"Encryption", s.Encryption},
+		{"Zabbix", s.Zabbix},
+		{"Telemetry", s.Telemetry},
+		{"Database", s.Database},
+		{"Timestamp", s.Timestamp},
+	}
+	var b strings.Builder
+	for _, r := range rows {
+		if r.value == "" {
+			continue
+		}
+		b.WriteString(ui.PadRight(i18n.T(r.label)+":", 20) + " " + healthStyle(r.value).Render(r.value) + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
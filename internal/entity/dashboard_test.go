@@ -0,0 +1,184 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/alert"
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// entityClient answers List with a fixed payload per entity.
+type entityClient struct {
+	fakeClient
+	lists  map[string]string
+	status *cli.StatusInfo
+}
+
+func (c *entityClient) List(entity string, limit, offset int, target interface{}) error {
+	if data, ok := c.lists[entity]; ok {
+		return json.Unmarshal([]byte(data), target)
+	}
+	return nil
+}
+
+func (c *entityClient) GetStatus() (*cli.StatusInfo, error) { return c.status, nil }
+
+func dashboardClient() *entityClient {
+	return &entityClient{
+		status: &cli.StatusInfo{Scheduler: "active", Executor: "inactive", VersionCli: "2.1", Companies: 3, Apps: 7},
+		lists: map[string]string{
+			"job": `[
+				{"id":9,"app_id":1,"company_id":2,"pid":4242,"exitcode":-1,"command":"sync"},
+				{"id":8,"app_id":1,"company_id":2,"exitcode":2,"end":"2026-10-18 09:00:00"},
+				{"id":7,"app_id":5,"company_id":2,"exitcode":0}
+			]`,
+			"queue":       `[{"id":1,"job":9,"app_name":"Importer","company_name":"Acme","after":"2026-10-18 10:00:00"}]`,
+			"runtemplate": `[{"id":4,"name":"Nightly","active":1,"next_schedule":"2026-10-18 08:00:00"},{"id":5,"name":"Later","active":1,"next_schedule":"2026-10-18 23:00:00"},{"id":6,"name":"Off","active":0,"next_schedule":"2026-10-01 08:00:00"}]`,
+			"application": `[{"id":1,"name":"Importer"}]`,
+			"company":     `[{"id":2,"name":"Acme"}]`,
+		},
+	}
+}
+
+func fixClock(t *testing.T, at string) {
+	saved := now
+	t.Cleanup(func() { now = saved })
+	tm, _ := parseTime(at)
+	now = func() time.Time { return tm }
+}
+
+func TestDashboardPanels(t *testing.T) {
+	fixClock(t, "2026-10-18 12:00:00")
+	d := NewDashboard(dashboardClient())
+	d.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	d.Update(d.Init()())
+	view := d.View()
+
+	for _, want := range []string{
+		"Scheduler: active", "Executor: inactive", // health
+		"Waiting: 1", "#1 2026-10-18 10:00:00  Importer / Acme", // queue
+		"Jobs: 1", "#9 Importer / Acme  sync", // running
+		"Failed: 1 of the last 3 jobs", "#8 Importer / Acme  exit 2", // failures
+		"Overdue: 1", "#4 Nightly  4h late", // overdue; inactive and future templates are not
+		"Companies: 3", "Applications: 7", // counts
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("dashboard is missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Later") || strings.Contains(view, "Off") {
+		t.Error("only active RunTemplates past their schedule are overdue")
+	}
+}
+
+func TestDashboardDrillDown(t *testing.T) {
+	fixClock(t, "2026-10-18 12:00:00")
+	c := dashboardClient()
+	d := NewDashboard(c)
+	d.Update(d.Init()())
+
+	for d.targets()[d.cursor] != targetFailed {
+		d.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	nav, ok := cmd().(ui.NavigateToMsg)
+	if !ok {
+		t.Fatal("enter should open a list")
+	}
+	lv, ok := nav.View.(*ListView)
+	if !ok || lv.def != JobDef {
+		t.Fatalf("failures should open the job list, got %T", nav.View)
+	}
+	lv.Update(lv.Init()())
+	if row := lv.table.SelectedRow(); row == nil || row.ID != 8 || len(lv.table.Filter()) != 0 {
+		t.Errorf("the list should show only the failed job, got %+v", row)
+	}
+	if !strings.Contains(lv.View(), "[failed]") {
+		t.Error("the list should name its restriction")
+	}
+}
+
+func TestDashboardScopedListKeepsSession(t *testing.T) {
+	saved := Session
+	defer func() { Session = saved }()
+	Session = session.New()
+	Session.List("job").Filter = "mine"
+
+	lv := NewListView(dashboardClient(), JobDef)
+	lv.SetWhere("running", func(r ui.TableRow) bool { return true })
+	lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if ls := Session.List("job"); ls.Filter != "mine" || ls.Sort != "" {
+		t.Errorf("a restricted list must not touch the saved list state: %+v", ls)
+	}
+}
+
+func TestDashboardRefreshGenerations(t *testing.T) {
+	fixClock(t, "2026-10-18 12:00:00")
+	d := NewDashboard(dashboardClient())
+	first := d.Init()
+	second := d.Refresh()
+
+	d.Update(first())
+	if d.data != nil {
+		t.Error("a superseded load must be dropped")
+	}
+	_, tick := d.Update(second())
+	if d.data == nil || tick == nil {
+		t.Fatal("the current load should be shown and schedule the next refresh")
+	}
+	if _, cmd := d.Update(dashboardTickMsg{gen: d.gen - 1}); cmd != nil {
+		t.Error("a tick from an earlier load must not refresh")
+	}
+	if _, cmd := d.Update(dashboardTickMsg{gen: d.gen}); cmd == nil {
+		t.Error("the current tick should refresh")
+	}
+}
+
+func TestDashboardCatchesUpWhenShownAgain(t *testing.T) {
+	fixClock(t, "2026-10-18 12:00:00")
+	d := NewDashboard(dashboardClient())
+	d.Update(d.Init()())
+	if _, cmd := d.Update(tea.WindowSizeMsg{Width: 80, Height: 30}); cmd != nil {
+		t.Error("fresh data should not reload")
+	}
+	fixClock(t, "2026-10-18 13:00:00")
+	if _, cmd := d.Update(tea.WindowSizeMsg{Width: 80, Height: 30}); cmd == nil {
+		t.Error("stale data should reload when the dashboard is shown again")
+	}
+}
+
+func TestDashboardRaisesAlerts(t *testing.T) {
+	fixClock(t, "2026-10-18 09:30:00")
+	savedAlerts, savedInterval := Alerts, DashboardInterval
+	defer func() { Alerts, DashboardInterval = savedAlerts, savedInterval }()
+	DashboardInterval = 0 // no tick, so the update returns just the alerts
+	Alerts = alert.NewMonitor([]alert.Rule{
+		{Name: "Executor down", Metric: alert.Executor, Op: "!=", Value: "active"},
+		{Name: "Jobs failing", Metric: alert.FailedLastHour, Op: ">", Value: 0},
+		{Name: "Queue backed up", Metric: alert.Queue, Op: ">", Value: 5},
+	})
+
+	d := NewDashboard(dashboardClient())
+	_, cmd := d.Update(d.Init()())
+	if cmd == nil {
+		t.Fatal("breaching rules should be reported")
+	}
+	status, ok := cmd().(ui.NotifyMsg)
+	if !ok || status.Text != "Alert: Executor down (executor inactive != active) • Alert: Jobs failing (failed_last_hour 1 > 0)" {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if _, cmd := d.Update(d.Refresh()()); cmd != nil {
+		t.Error("an ongoing breach is reported once")
+	}
+
+	rows, _ := AlertLogDef.Fetch(nil, 10, 0)
+	if len(rows) != 2 || rows[0].Values["rule"] != "Jobs failing" || rows[0].Values["state"] != "breached" {
+		t.Errorf("the alert log should list the breaches, newest first: %+v", rows)
+	}
+}
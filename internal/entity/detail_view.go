@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -22,6 +24,7 @@ type DetailView struct {
 	selectedAction int
 	height         int // available content-area height (set via WindowSizeMsg)
 	scroll         int // first visible field index
+	zones          ui.Zones
 }
 
 // NewDetailView creates a detail view for the given entity data.
@@ -42,6 +45,14 @@ func NewDetailView(c cli.Client, def *EntityDef, data interface{}) *DetailView {
 
 func (m *DetailView) Init() tea.Cmd { return nil }
 
+// HelpTopic satisfies ui.HelpTopic.
+func (m *DetailView) HelpTopic() string { return m.def.CLIEntity }
+
+// SessionRecord satisfies session.Recorder.
+func (m *DetailView) SessionRecord() session.Record {
+	return session.Record{Entity: m.def.CLIEntity, ID: m.def.GetID(m.data)}
+}
+
 func (m *DetailView) visibleFields() int {
 	v := m.height - detailOverhead
 	if v < 3 {
@@ -56,6 +67,13 @@ func (m *DetailView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case ui.ClickMsg:
+		// Clicking an action button selects and runs it.
+		if id, ok := m.zones.Hit(msg.X, msg.Y); ok {
+			fmt.Sscanf(id, "action:%d", &m.selectedAction)
+			return m.executeAction()
+		}
+
 	case tea.KeyMsg:
 		key := msg.String()
 		vis := m.visibleFields()
@@ -120,6 +138,11 @@ func (m *DetailView) executeActionByCommand(command string) (tea.Model, tea.Cmd)
 			editor := NewEditorView(m.client, m.def, m.data, false)
 			return m, func() tea.Msg { return ui.NavigateToMsg{View: editor} }
 		}
+	case "duplicate":
+		if m.def.Duplicate != nil {
+			editor := NewDuplicateView(m.client, m.def, m.data)
+			return m, func() tea.Msg { return ui.NavigateToMsg{View: editor} }
+		}
 	case "delete":
 		if m.def.GetID != nil && m.def.GetLabel != nil {
 			id := m.def.GetID(m.data)
@@ -127,15 +150,17 @@ func (m *DetailView) executeActionByCommand(command string) (tea.Model, tea.Cmd)
 			cliEntity := m.def.CLIEntity
 			deleteAction := m.def.DeleteAction
 			client := m.client
+			def, data := m.def, m.data
 			return m, func() tea.Msg {
 				return ui.ConfirmMsg{
-					Label: fmt.Sprintf("Delete %s?", label),
+					Label: i18n.Tf("Delete %s?", label),
 					Action: func() tea.Msg {
 						err := client.Delete(cliEntity, deleteAction, id)
 						if err != nil {
-							return ui.StatusMsg{Text: fmt.Sprintf("Error deleting %s: %v", label, err)}
+							return ui.StatusMsg{Text: i18n.Tf("Error deleting %s: %v", label, err)}
 						}
-						return ui.NavigateBackAndRefreshMsg{Status: fmt.Sprintf("Deleted %s", label)}
+						Journal.Record(def, undoDelete, data, nil)
+						return ui.NavigateBackAndRefreshMsg{Status: i18n.Tf("Deleted %s", label)}
 					},
 				}
 			}
@@ -166,7 +191,7 @@ func (m *DetailView) executeActionByCommand(command string) (tea.Model, tea.Cmd)
 			return m, action.Handler(m.client, m.data)
 		}
 		return m, func() tea.Msg {
-			return ui.StatusMsg{Text: fmt.Sprintf("Action '%s' not implemented", command)}
+			return ui.StatusMsg{Text: i18n.Tf("Action '%s' not implemented", command)}
 		}
 	}
 	return m, nil
@@ -174,8 +199,9 @@ func (m *DetailView) executeActionByCommand(command string) (tea.Model, tea.Cmd)
 
 func (m *DetailView) View() string {
 	var b strings.Builder
+	m.zones.Reset()
 
-	label := m.def.Name
+	label := i18n.T(m.def.Name)
 	if m.def.GetLabel != nil {
 		label = m.def.GetLabel(m.data)
 	}
@@ -185,8 +211,8 @@ func (m *DetailView) View() string {
 	// Fields (scrollable)
 	maxW := 0
 	for _, f := range m.fields {
-		if len(f.Label) > maxW {
-			maxW = len(f.Label)
+		if l := ui.Width(i18n.T(f.Label)); l > maxW {
+			maxW = l
 		}
 	}
 	vis := m.visibleFields()
@@ -199,7 +225,7 @@ func (m *DetailView) View() string {
 		end = len(m.fields)
 	}
 	for _, f := range m.fields[start:end] {
-		b.WriteString(fmt.Sprintf("%-*s: %s\n", maxW, f.Label, f.Value))
+		b.WriteString(ui.PadRight(i18n.T(f.Label), maxW) + ": " + f.Value + "\n")
 	}
 	if len(m.fields) > vis {
 		maxScroll := len(m.fields) - vis
@@ -207,26 +233,29 @@ func (m *DetailView) View() string {
 		if maxScroll > 0 {
 			pct = (m.scroll * 100) / maxScroll
 		}
-		b.WriteString(ui.FooterStyle().Render(fmt.Sprintf(" ↑/↓/PgUp/PgDn: scroll [%3d%%]", pct)))
+		b.WriteString(ui.FooterStyle().Render(i18n.Tf(" ↑/↓/PgUp/PgDn: scroll [%3d%%]", pct)))
 		b.WriteString("\n")
 	}
 	b.WriteString("\n")
 
 	// Action buttons
 	if len(m.actions) > 0 {
-		var buttons []string
 		for i, a := range m.actions {
-			btn := fmt.Sprintf("[%s] %s", a.Key, a.Label)
+			if i > 0 {
+				b.WriteString("   ")
+			}
+			btn := fmt.Sprintf("[%s] %s", a.Key, i18n.T(a.Label))
+			x, y := ui.Cursor(&b)
+			m.zones.Add(fmt.Sprintf("action:%d", i), x, y, ui.Width(btn))
 			if i == m.selectedAction {
-				buttons = append(buttons, ui.SelectedStyle().Render(btn))
+				b.WriteString(ui.SelectedStyle().Render(btn))
 			} else {
-				buttons = append(buttons, ui.UnselectedStyle().Render(btn))
+				b.WriteString(ui.UnselectedStyle().Render(btn))
 			}
 		}
-		b.WriteString(strings.Join(buttons, "   "))
 		b.WriteString("\n\n")
 	}
 
-	b.WriteString("ESC/q: Back\n")
+	b.WriteString(i18n.T("ESC/q: Back") + "\n")
 	return b.String()
 }
@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Drafts keeps the unsaved values of editors. main points it at the state
+// directory; the zero value keeps nothing.
+var Drafts session.Drafts
+
+// draftInterval is how often an editor saves its draft.
+var draftInterval = 5 * time.Second
+
+// draftTickMsg asks an editor to save its draft. Ticks of an older chain,
+// left behind when a key press restarts it, carry an older gen and are
+// dropped.
+type draftTickMsg struct{ gen int }
+
+// draftRestoreMsg puts the values of a draft back into an editor.
+type draftRestoreMsg struct {
+	values map[string]string
+}
+
+// leaveForm goes back, asking first when the form has unsaved changes.
+// discard, if set, runs when the form is left.
+func leaveForm(form *ui.Form, discard func()) tea.Cmd {
+	back := func() tea.Msg {
+		if discard != nil {
+			discard()
+		}
+		return ui.NavigateBackMsg{}
+	}
+	if !form.Dirty() {
+		return back
+	}
+	return func() tea.Msg {
+		return ui.ConfirmMsg{Label: "Discard unsaved changes?", Action: back}
+	}
+}
+
+// drafts is where the editor keeps its draft. A duplicate keeps none: its
+// values come from the record it copies, and a draft under ID 0 would mix
+// with the one of a plain create.
+func (m *EditorView) drafts() session.Drafts {
+	if m.duplicate {
+		return session.Drafts{}
+	}
+	return Drafts
+}
+
+// draftID is the ID the editor's draft is kept under, 0 for a new record.
+func (m *EditorView) draftID() int {
+	if m.isCreate || m.def.GetID == nil {
+		return 0
+	}
+	return m.def.GetID(m.data)
+}
+
+// offerDraft asks whether to restore a draft left by an earlier editor of
+// the same record. The draft is kept until answered: restored values are
+// saved again with the next autosave, and declining removes it.
+func (m *EditorView) offerDraft() tea.Cmd {
+	drafts, entity, id := m.drafts(), m.def.CLIEntity, m.draftID()
+	draft, err := drafts.Load(entity, id)
+	if err != nil || draft == nil || len(draft.Values) == 0 {
+		return nil
+	}
+	label := i18n.Tf("Restore unsaved changes from %s?", draft.Saved.Local().Format("2006-01-02 15:04"))
+	return func() tea.Msg {
+		return ui.ConfirmMsg{
+			Label:  label,
+			Action: func() tea.Msg { return draftRestoreMsg{values: draft.Values} },
+			Cancel: func() tea.Msg { drafts.Remove(entity, id); return nil },
+		}
+	}
+}
+
+// restoreDraft puts the values of a draft into the form.
+func (m *EditorView) restoreDraft(values map[string]string) {
+	for label, v := range values {
+		m.form.SetValue(label, v)
+	}
+}
+
+// draftTick schedules the next autosave, starting a new chain of ticks.
+func (m *EditorView) draftTick() tea.Cmd {
+	m.tickGen++
+	m.tickDue = now().Add(draftInterval)
+	gen := m.tickGen
+	return tea.Tick(draftInterval, func(time.Time) tea.Msg { return draftTickMsg{gen: gen} })
+}
+
+// autosave writes the changed values, secrets left out, when they differ
+// from the last draft; an editor without changes keeps no draft.
+func (m *EditorView) autosave() {
+	if m.saving {
+		return
+	}
+	values := map[string]string{}
+	for _, c := range m.form.Changes() {
+		if !c.Secret {
+			values[c.Label] = c.New
+		}
+	}
+	if reflect.DeepEqual(values, m.drafted) {
+		return
+	}
+	var err error
+	if len(values) == 0 {
+		err = m.drafts().Remove(m.def.CLIEntity, m.draftID())
+	} else {
+		err = m.drafts().Save(m.def.CLIEntity, m.draftID(), values)
+	}
+	if err == nil {
+		m.drafted = values
+	}
+}
@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func useDrafts(t *testing.T) {
+	saved := Drafts
+	Drafts = session.Drafts{Dir: t.TempDir()}
+	t.Cleanup(func() { Drafts = saved })
+}
+
+func TestEditorViewConfirmsLeavingDirtyForm(t *testing.T) {
+	ev := NewEditorView(&fakeClient{}, CompanyDef, cli.Company{ID: 1, Name: "Acme"}, false)
+	_, cmd := ev.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if msgs := cmdMsgs(cmd); len(msgs) != 1 || msgs[0] != (ui.NavigateBackMsg{}) {
+		t.Fatal("an unchanged form should be left at once")
+	}
+
+	ev.form.SetValue("Name", "Acme Corp")
+	_, cmd = ev.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	msgs := cmdMsgs(cmd)
+	confirm, ok := msgs[0].(ui.ConfirmMsg)
+	if len(msgs) != 1 || !ok {
+		t.Fatal("leaving a changed form should ask first")
+	}
+	if _, ok := confirm.Action().(ui.NavigateBackMsg); !ok {
+		t.Error("confirming should leave the form")
+	}
+}
+
+func TestEditorViewSavesAndRestoresDraft(t *testing.T) {
+	useDrafts(t)
+	user := cli.User{ID: 3, Login: "jane", Email: "jane@example.com"}
+	ev := NewEditorView(&fakeClient{}, UserDef, user, false)
+	ev.form.SetValue("Email", "jane@example.org")
+	ev.form.SetValue("Password", "hunter2")
+	ev.Update(draftTickMsg{gen: ev.tickGen})
+
+	draft, err := Drafts.Load("user", 3)
+	if err != nil || draft == nil {
+		t.Fatalf("autosave should write a draft: %v", err)
+	}
+	if draft.Values["Email"] != "jane@example.org" {
+		t.Errorf("draft = %v", draft.Values)
+	}
+	if _, ok := draft.Values["Password"]; ok {
+		t.Error("secrets should stay out of drafts")
+	}
+
+	reopened := NewEditorView(&fakeClient{}, UserDef, user, false)
+	confirm, ok := reopened.offerDraft()().(ui.ConfirmMsg)
+	if !ok {
+		t.Fatal("reopening the editor should offer the draft")
+	}
+	if draft, _ := Drafts.Load("user", 3); draft == nil {
+		t.Fatal("the draft should be kept until the offer is answered")
+	}
+	reopened.Update(confirm.Action())
+	if got := reopened.form.Values()["Email"]; got != "jane@example.org" {
+		t.Errorf("restored Email = %q", got)
+	}
+	if draft, _ := Drafts.Load("user", 3); draft == nil {
+		t.Error("a restored draft should be kept for the next autosave")
+	}
+
+	declined := NewEditorView(&fakeClient{}, UserDef, user, false)
+	confirm = declined.offerDraft()().(ui.ConfirmMsg)
+	confirm.Cancel()
+	if draft, _ := Drafts.Load("user", 3); draft != nil {
+		t.Error("declining the offer should remove the draft")
+	}
+	if other := NewEditorView(&fakeClient{}, UserDef, cli.User{ID: 4}, false); other.offerDraft() != nil {
+		t.Error("drafts are kept per record")
+	}
+}
+
+func TestEditorViewRemovesDraftWhenSaved(t *testing.T) {
+	useDrafts(t)
+	ev := NewEditorView(&fakeClient{}, CompanyDef, nil, true)
+	ev.form.SetValue("Name", "Acme")
+	ev.Update(draftTickMsg{gen: ev.tickGen})
+	if draft, _ := Drafts.Load("company", 0); draft == nil {
+		t.Fatal("the editor of a new record should keep a draft too")
+	}
+	_, cmd := ev.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	cmdMsgs(cmd)
+	if draft, _ := Drafts.Load("company", 0); draft != nil {
+		t.Error("a saved record should leave no draft")
+	}
+}
+
+func TestDuplicateViewKeepsNoDraft(t *testing.T) {
+	useDrafts(t)
+	if err := Drafts.Save("company", 0, map[string]string{"Name": "Unrelated"}); err != nil {
+		t.Fatal(err)
+	}
+	dup := NewDuplicateView(&fakeClient{}, CompanyDef, cli.Company{ID: 1, Name: "Acme"})
+	if dup.offerDraft() != nil {
+		t.Error("a duplicate should not offer the draft of a plain create")
+	}
+	dup.form.SetValue("Name", "Acme 2")
+	dup.Update(draftTickMsg{gen: dup.tickGen})
+	draft, err := Drafts.Load("company", 0)
+	if err != nil || draft == nil || draft.Values["Name"] != "Unrelated" {
+		t.Errorf("the draft of a plain create should be left alone, got %+v, %v", draft, err)
+	}
+}
+
+func TestEditorViewDropsStaleDraftTicks(t *testing.T) {
+	useDrafts(t)
+	ev := NewEditorView(&fakeClient{}, CompanyDef, nil, true)
+	ev.Init()
+	ev.form.SetValue("Name", "Acme")
+	stale := draftTickMsg{gen: ev.tickGen}
+	ev.tickDue = now().Add(-2 * draftInterval)
+	if _, cmd := ev.Update(tea.KeyMsg{Type: tea.KeyRight}); cmd == nil {
+		t.Fatal("a key press after lost ticks should restart them")
+	}
+	if _, cmd := ev.Update(stale); cmd != nil {
+		t.Error("a tick of the replaced chain should be dropped, not start another")
+	}
+	if draft, _ := Drafts.Load("company", 0); draft != nil {
+		t.Error("a stale tick should not autosave")
+	}
+	if _, cmd := ev.Update(draftTickMsg{gen: ev.tickGen}); cmd == nil {
+		t.Error("a tick of the current chain should schedule the next")
+	}
+	if draft, _ := Drafts.Load("company", 0); draft == nil {
+		t.Error("a tick of the current chain should autosave")
+	}
+}
@@ -1,26 +1,52 @@
 package entity
 
 import (
-	"fmt"
 	"strings"
+	"time"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
-	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// formFieldsTop is the line of the first field of EditorView and
+// ActionFormView, below the title and a blank line.
+const formFieldsTop = 2
+
+// formHelp translates a form's keys, adding those of the focused field.
+func formHelp(keys string, form *ui.Form) string {
+	if hint := form.Hint(); hint != "" {
+		return i18n.T(hint) + " • " + i18n.T(keys)
+	}
+	return i18n.T(keys)
+}
+
 // EditorView is a generic create/update form driven by an EntityDef.
 type EditorView struct {
-	client   cli.Client
-	def      *EntityDef
-	data     interface{} // original entity (nil for create)
-	isCreate bool
-	title    string
+	client    cli.Client
+	def       *EntityDef
+	data      interface{} // original entity (nil for create)
+	isCreate  bool
+	duplicate bool // a create prefilled from another record
+	title     string
+
+	fields []ui.EditorField
+	form   *ui.Form
+	pick   pickers
 
-	inputs []textinput.Model
-	labels []string
-	cursor int
+	drafted map[string]string // values of the last draft saved
+	tickDue time.Time         // when the next autosave is due
+	tickGen int               // generation of the current chain of ticks
+	saving  bool              // a save is running: drafts are left alone
+
+	conflict *conflict // shown in place of the form after a conflicting save
+	width    int
+}
+
+// saveFailedMsg reports a failed save back to its editor.
+type saveFailedMsg struct {
+	text string
 }
 
 // NewEditorView creates an editor for updating or creating an entity.
@@ -29,117 +55,264 @@ func NewEditorView(c cli.Client, def *EntityDef, data interface{}, isCreate bool
 	var title string
 
 	if isCreate {
-		title = fmt.Sprintf("New %s", def.Name)
+		title = i18n.Tf("New %s", i18n.T(def.Name))
 		if def.NewFields != nil {
 			fields = def.NewFields()
 		}
 	} else {
-		label := def.Name
+		label := i18n.T(def.Name)
 		if def.GetLabel != nil {
 			label = def.GetLabel(data)
 		}
-		title = fmt.Sprintf("Edit %s", label)
+		title = i18n.Tf("Edit %s", label)
 		if def.ToEditor != nil {
 			fields = def.ToEditor(data)
 		}
 	}
 
-	inputs := make([]textinput.Model, len(fields))
-	labels := make([]string, len(fields))
+	return newEditorView(c, def, data, isCreate, title, fields)
+}
+
+// NewDuplicateView creates an editor for a new record prefilled with the
+// values def.Duplicate copies from data.
+func NewDuplicateView(c cli.Client, def *EntityDef, data interface{}) *EditorView {
+	var fields []ui.EditorField
+	if def.NewFields != nil {
+		fields = def.NewFields()
+	}
+	values := def.Duplicate(data)
 	for i, f := range fields {
-		ti := textinput.New()
-		ti.Placeholder = f.Placeholder
-		ti.SetValue(f.Value)
-		if i == 0 {
-			ti.Focus()
+		if v, ok := values[f.Label]; ok {
+			fields[i].Value = v
 		}
-		inputs[i] = ti
-		labels[i] = f.Label
 	}
+	label := i18n.T(def.Name)
+	if def.GetLabel != nil {
+		label = def.GetLabel(data)
+	}
+	m := newEditorView(c, def, nil, true, i18n.Tf("Duplicate %s", label), fields)
+	m.duplicate = true
+	return m
+}
 
+func newEditorView(c cli.Client, def *EntityDef, data interface{}, isCreate bool, title string, fields []ui.EditorField) *EditorView {
+	form := ui.NewForm(fields)
 	return &EditorView{
 		client:   c,
 		def:      def,
 		data:     data,
 		isCreate: isCreate,
 		title:    title,
-		inputs:   inputs,
-		labels:   labels,
+		fields:   fields,
+		form:     form,
+		pick:     pickers{client: c, form: form},
 	}
 }
 
+// HelpTopic satisfies ui.HelpTopic.
+func (m *EditorView) HelpTopic() string { return m.def.CLIEntity }
+
 func (m *EditorView) Init() tea.Cmd {
-	return textinput.Blink
+	return tea.Batch(m.form.Init(), m.pick.init(m.fields), m.offerDraft(), m.draftTick())
 }
 
+// CapturingInput satisfies ui.InputCapturer: tab moves between fields and
+// q is text.
+func (m *EditorView) CapturingInput() bool { return true }
+
 func (m *EditorView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if click, ok := msg.(ui.ClickMsg); ok {
+		click.Y -= formFieldsTop
+		msg = click
+	}
+	if cmd, ok := m.pick.update(msg); ok {
+		return m, cmd
+	}
+	if m.conflict != nil {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			if msg.String() != "ctrl+c" {
+				return m.updateConflict(msg)
+			}
+		case ui.ClickMsg:
+			return m, nil
+		}
+	}
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.form.SetWidth(msg.Width)
+		return m, nil
+
+	case externalEditedMsg:
+		return m, applyEdited(m.form, msg)
+
+	case draftTickMsg:
+		if msg.gen != m.tickGen {
+			return m, nil
+		}
+		m.autosave()
+		return m, m.draftTick()
+
+	case draftRestoreMsg:
+		m.restoreDraft(msg.values)
+		return m, nil
+
+	case updateConfirmedMsg:
+		return m, m.update(msg.changes)
+
+	case conflictMsg:
+		m.saving = false
+		m.conflict = newConflict(m.def, m.data, msg.theirs, m.form)
+		return m, nil
+
+	case saveFailedMsg:
+		m.saving = false
+		return m, func() tea.Msg { return ui.StatusMsg{Text: msg.text} }
+
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c":
-			return m, tea.Quit
-		case "esc":
-			return m, func() tea.Msg { return ui.NavigateBackMsg{} }
-		case "tab", "down":
-			m.inputs[m.cursor].Blur()
-			m.cursor = (m.cursor + 1) % len(m.inputs)
-			m.inputs[m.cursor].Focus()
-			return m, textinput.Blink
-		case "shift+tab", "up":
-			m.inputs[m.cursor].Blur()
-			m.cursor = (m.cursor - 1 + len(m.inputs)) % len(m.inputs)
-			m.inputs[m.cursor].Focus()
-			return m, textinput.Blink
-		case "enter":
-			return m.save()
+		// Ticks are lost while another view is in front, e.g. a dialog.
+		var tick tea.Cmd
+		if !m.tickDue.IsZero() && now().After(m.tickDue.Add(draftInterval)) {
+			tick = m.draftTick()
 		}
+		model, cmd := m.updateKey(msg)
+		return model, tea.Batch(cmd, tick)
 	}
+	return m, m.form.Update(msg)
+}
 
-	var cmd tea.Cmd
-	if len(m.inputs) > 0 {
-		m.inputs[m.cursor], cmd = m.inputs[m.cursor].Update(msg)
+// updateKey handles a key press.
+func (m *EditorView) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		drafts, def, id := m.drafts(), m.def, m.draftID()
+		return m, leaveForm(m.form, func() { drafts.Remove(def.CLIEntity, id) })
+	case externalKey:
+		return m, editExternally(m.form)
+	case "ctrl+s":
+		return m.save()
+	case "enter":
+		if !m.form.WantsEnter() {
+			return m.save()
+		}
 	}
-	return m, cmd
+	return m, m.form.Update(msg)
 }
 
-func (m *EditorView) save() (tea.Model, tea.Cmd) {
-	fields := make(map[string]string, len(m.inputs))
-	for i, input := range m.inputs {
-		fields[m.labels[i]] = input.Value()
-	}
+// previewWidth is the width old and new values are cut to in the preview
+// of an update.
+const previewWidth = 30
 
-	client := m.client
-	def := m.def
-	data := m.data
-	isCreate := m.isCreate
+// updateConfirmedMsg tells an editor its changes were confirmed.
+type updateConfirmedMsg struct {
+	changes []ui.Change
+}
 
+// save checks every field, then creates the record or, once the changes
+// are confirmed, updates it.
+func (m *EditorView) save() (tea.Model, tea.Cmd) {
+	if !m.form.Validate() {
+		return m, nil
+	}
+	if m.isCreate {
+		return m, m.create(m.form.Values())
+	}
+	changes := m.form.Changes()
+	if len(changes) == 0 {
+		return m, func() tea.Msg { return ui.StatusMsg{Text: i18n.T("No changes to save")} }
+	}
+	preview := previewChanges(changes)
 	return m, func() tea.Msg {
-		var err error
-		var label string
-
-		if isCreate {
-			if def.CreateArgs != nil {
-				args := def.CreateArgs(fields)
-				_, err = client.Create(def.CLIEntity, args...)
-				label = fmt.Sprintf("New %s", def.Name)
-			}
+		return ui.ConfirmMsg{Label: preview, Action: func() tea.Msg { return updateConfirmedMsg{changes: changes} }}
+	}
+}
+
+// previewChanges lists the changes an update is about to make, old → new.
+// Secrets are not shown.
+func previewChanges(changes []ui.Change) string {
+	var b strings.Builder
+	b.WriteString(i18n.T("You are about to change:"))
+	for _, c := range changes {
+		b.WriteString("\n  " + i18n.T(c.Label) + ": ")
+		if c.Secret {
+			b.WriteString(i18n.T("(changed)"))
 		} else {
-			if def.UpdateArgs != nil {
-				args := def.UpdateArgs(data, fields)
-				err = client.Update(def.CLIEntity, args...)
-				if def.GetLabel != nil {
-					label = def.GetLabel(data)
-				} else {
-					label = def.Name
-				}
+			b.WriteString(previewValue(c.Old, previewWidth) + " → " + previewValue(c.New, previewWidth))
+		}
+	}
+	return b.String()
+}
+
+// previewValue flattens a value to one line of at most w columns.
+func previewValue(v string, w int) string {
+	v = strings.Join(strings.Fields(v), " ")
+	if v == "" {
+		return i18n.T("(empty)")
+	}
+	return ui.Truncate(v, w)
+}
+
+// create runs the create command and opens the new record, or goes back to
+// the list when the command's output names no record.
+func (m *EditorView) create(fields map[string]string) tea.Cmd {
+	m.saving = true
+	client, def, drafts, draftID := m.client, m.def, m.drafts(), m.draftID()
+	return func() tea.Msg {
+		label := i18n.Tf("New %s", i18n.T(def.Name))
+		var out []byte
+		if def.CreateArgs != nil {
+			var err error
+			if out, err = client.Create(def.CLIEntity, def.CreateArgs(fields)...); err != nil {
+				return saveFailedMsg{text: i18n.Tf("Error saving %s: %v", label, err)}
 			}
 		}
+		drafts.Remove(def.CLIEntity, draftID)
 
-		if err != nil {
-			return ui.StatusMsg{Text: fmt.Sprintf("Error saving %s: %v", label, err)}
+		created := def.Created(client, out)
+		if created == nil {
+			return ui.NavigateBackAndRefreshMsg{Status: i18n.Tf("Saved %s", label)}
 		}
+		if def.GetLabel != nil {
+			label = def.GetLabel(created)
+		}
+		return ui.NavigateBackAndOpenMsg{
+			Status: i18n.Tf("Saved %s", label),
+			Select: def.GetID(created),
+			View:   def.detailView(client, created),
+		}
+	}
+}
 
-		return ui.NavigateBackAndRefreshMsg{Status: fmt.Sprintf("Saved %s", label)}
+// update runs the update command with changes, unless the record was
+// changed elsewhere since the editor opened it.
+func (m *EditorView) update(changes []ui.Change) tea.Cmd {
+	m.saving = true
+	client, def, data, drafts, draftID := m.client, m.def, m.data, m.drafts(), m.draftID()
+	return func() tea.Msg {
+		label := i18n.T(def.Name)
+		if def.GetLabel != nil {
+			label = def.GetLabel(data)
+		}
+		// Someone else may have saved the record since it was opened.
+		if def.Record != nil {
+			theirs, err := def.Get(client, def.GetID(data))
+			if err != nil {
+				return saveFailedMsg{text: i18n.Tf("Error saving %s: %v", label, err)}
+			}
+			if version(theirs) != version(data) {
+				return conflictMsg{theirs: theirs}
+			}
+		}
+		if err := client.Update(def.CLIEntity, def.UpdateArgs(data, changes)...); err != nil {
+			return saveFailedMsg{text: i18n.Tf("Error saving %s: %v", label, err)}
+		}
+		Journal.Record(def, undoUpdate, data, changes)
+
+		drafts.Remove(def.CLIEntity, draftID)
+		return ui.NavigateBackAndRefreshMsg{Status: i18n.Tf("Saved %s", label)}
 	}
 }
 
@@ -149,20 +322,17 @@ func (m *EditorView) View() string {
 	b.WriteString(ui.TitleStyle().Render(m.title))
 	b.WriteString("\n\n")
 
-	for i, input := range m.inputs {
-		label := m.labels[i]
-		if i == m.cursor {
-			b.WriteString(ui.SelectedStyle().Render(fmt.Sprintf("%-15s", label+":")))
-		} else {
-			b.WriteString(fmt.Sprintf("%-15s", label+":"))
-		}
-		b.WriteString(" ")
-		b.WriteString(input.View())
-		b.WriteString("\n")
+	if picker := m.pick.view(); picker != "" {
+		b.WriteString(picker)
+		return b.String()
 	}
-
+	if m.conflict != nil {
+		b.WriteString(m.conflict.view(m.width))
+		return b.String()
+	}
+	b.WriteString(m.form.View())
 	b.WriteString("\n")
-	b.WriteString(ui.FooterStyle().Render("tab/↑↓: fields • enter: save • esc: cancel"))
+	b.WriteString(ui.FooterStyle().Render(formHelp("tab/↑↓: fields • enter: save • ctrl+e: editor • esc: cancel", m.form)))
 	b.WriteString("\n")
 
 	return b.String()
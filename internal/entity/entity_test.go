@@ -1,10 +1,15 @@
 package entity
 
 import (
+	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 )
 
@@ -19,21 +24,21 @@ func TestCompanyDetailAndEditor(t *testing.T) {
 	}
 
 	ef := CompanyDef.ToEditor(co)
-	if len(ef) != 4 {
-		t.Fatalf("expected 4 editor fields, got %d", len(ef))
+	if len(ef) != 5 {
+		t.Fatalf("expected 5 editor fields, got %d", len(ef))
 	}
 	if ef[0].Value != "Acme" {
 		t.Errorf("editor Name value = %q", ef[0].Value)
 	}
 
-	args := CompanyDef.UpdateArgs(co, map[string]string{"Name": "New", "Email": "x@y", "IC": "999", "Slug": "new"})
-	if len(args) < 8 {
-		t.Errorf("expected >= 8 update args, got %d: %v", len(args), args)
+	args := CompanyDef.UpdateArgs(co, []ui.Change{{Label: "Name", Old: "Acme", New: "New"}, {Label: "IC", Old: "", New: "999"}})
+	if want := []string{"--id", "1", "--name", "New", "--ic", "999"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("update args = %v, want %v", args, want)
 	}
 
 	nf := CompanyDef.NewFields()
-	if len(nf) != 4 {
-		t.Errorf("expected 4 new fields, got %d", len(nf))
+	if len(nf) != 5 {
+		t.Errorf("expected 5 new fields, got %d", len(nf))
 	}
 
 	cargs := CompanyDef.CreateArgs(map[string]string{"Name": "Test", "Email": "e", "IC": "", "Slug": ""})
@@ -101,6 +106,20 @@ func TestRunTemplateDetailAndEditor(t *testing.T) {
 	if len(ef) < 3 {
 		t.Fatalf("expected >=3 editor fields, got %d", len(ef))
 	}
+
+	config := "# credentials\nAPI_URL: https://example.com/api\nDEBUG: 'true'\n"
+	args := RunTemplateDef.UpdateArgs(rt, []ui.Change{{Label: "Config", New: config}})
+	if want := []string{"--id", "10", "--config", "API_URL=https://example.com/api", "--config", "DEBUG=true"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("update args = %q, want %q", args, want)
+	}
+	if err := validConfig(config); err != nil {
+		t.Errorf("validConfig(%q) = %v", config, err)
+	}
+	for _, bad := range []string{"API_URL", "nested:\n  key: value"} {
+		if validConfig(bad) == nil {
+			t.Errorf("validConfig(%q) passed, want an error", bad)
+		}
+	}
 }
 
 func TestAllEntitiesHaveGetIDAndLabel(t *testing.T) {
@@ -114,6 +133,12 @@ func TestAllEntitiesHaveGetIDAndLabel(t *testing.T) {
 		if e.Def.ToDetail == nil {
 			t.Errorf("%s: ToDetail is nil", e.Label)
 		}
+		if e.Def.Record == nil {
+			t.Errorf("%s: Record is nil", e.Label)
+		} else {
+			// GetID must accept what Record describes.
+			e.Def.GetID(reflect.ValueOf(e.Def.Record()).Elem().Interface())
+		}
 	}
 }
 
@@ -135,6 +160,54 @@ func TestAllEntitiesDeleteAction(t *testing.T) {
 	}
 }
 
+func TestAllEntitiesDuplicate(t *testing.T) {
+	for _, e := range All {
+		if e.Def.Duplicate == nil {
+			continue
+		}
+		labels := map[string]bool{}
+		for _, f := range e.Def.NewFields() {
+			labels[f.Label] = true
+		}
+		data := reflect.ValueOf(e.Def.Record()).Elem().Interface()
+		for label := range e.Def.Duplicate(data) {
+			if !labels[label] {
+				t.Errorf("%s: Duplicate copies %q, which is not a new field", e.Label, label)
+			}
+		}
+	}
+}
+
+func TestDuplicateRunTemplate(t *testing.T) {
+	rt := cli.RunTemplate{ID: 7, Name: "Daily import", AppID: 3, CompanyID: 2, Interv: "d", Executor: "Docker", DatSave: "2026-10-01 10:00:00"}
+	lv := NewListView(&fakeClient{}, RunTemplateDef)
+	lv.table.SetData([]ui.TableRow{{ID: 7, FullData: rt}})
+	msgs := cmdMsgs(lv.handleKey(duplicateKey))
+	nav, ok := msgs[0].(ui.NavigateToMsg)
+	if len(msgs) != 1 || !ok {
+		t.Fatalf("D = %v", msgs)
+	}
+	ev := nav.View.(*EditorView)
+	if !ev.isCreate || ev.data != nil || !strings.Contains(ev.title, "Daily import") {
+		t.Errorf("editor: create %v, data %v, title %q", ev.isCreate, ev.data, ev.title)
+	}
+	values := ev.form.Values()
+	for label, want := range map[string]string{"Name": "Daily import", "App ID": "3", "Company ID": "2", "Interval": "d", "Executor": "Docker"} {
+		if values[label] != want {
+			t.Errorf("%s = %q, want %q", label, values[label], want)
+		}
+	}
+
+	dv := NewDetailView(&fakeClient{}, RunTemplateDef, rt)
+	if _, cmd := dv.executeActionByCommand("duplicate"); cmd == nil {
+		t.Error("the detail view should duplicate the record")
+	}
+	args := RunTemplateDef.CreateArgs(values)
+	if strings.Contains(strings.Join(args, " "), "--id") {
+		t.Errorf("a duplicate should not carry the ID: %v", args)
+	}
+}
+
 func TestCompanyAppListActions(t *testing.T) {
 	if len(CompanyAppDef.ListActions) != 2 {
 		t.Fatalf("expected 2 ListActions (assign, unassign), got %d", len(CompanyAppDef.ListActions))
@@ -198,3 +271,357 @@ func TestViewerView(t *testing.T) {
 		t.Error("viewer view is empty")
 	}
 }
+
+// fakeClient is a cli.Client that answers List and Get with fixed JSON payloads.
+type fakeClient struct {
+	listJSON string
+	getJSON  string
+}
+
+func (f *fakeClient) RunRaw(args ...string) ([]byte, error) { return nil, nil }
+func (f *fakeClient) List(entity string, limit, offset int, target interface{}) error {
+	if f.listJSON == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(f.listJSON), target)
+}
+func (f *fakeClient) Get(entity string, id int, target interface{}) error {
+	if f.getJSON == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(f.getJSON), target)
+}
+func (f *fakeClient) Create(entity string, args ...string) ([]byte, error) {
+	return []byte("{}"), nil
+}
+func (f *fakeClient) Update(entity string, args ...string) error              { return nil }
+func (f *fakeClient) Delete(entity string, deleteAction string, id int) error { return nil }
+func (f *fakeClient) GetStatus() (*cli.StatusInfo, error)                     { return &cli.StatusInfo{}, nil }
+func (f *fakeClient) GetCommands() ([]cli.Command, error)                     { return nil, nil }
+func (f *fakeClient) GetCommandHelp(name string) (string, error)              { return "", nil }
+func (f *fakeClient) LastCmd() string                                         { return "" }
+
+func TestSplitViewPreviewFollowsCursor(t *testing.T) {
+	c := &fakeClient{listJSON: `[{"id":1,"name":"Alpha"},{"id":2,"name":"Beta"}]`}
+	sv := NewSplitView(c, CompanyDef, 0.5, 100)
+	sv.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	sv.Update(sv.Init()())
+
+	if sv.previewID != 1 {
+		t.Fatalf("expected preview of row 1, got %d", sv.previewID)
+	}
+	sv.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if sv.previewID != 2 {
+		t.Errorf("expected preview to follow cursor to row 2, got %d", sv.previewID)
+	}
+
+	sv.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if !sv.focusPreview {
+		t.Error("ctrl+o should focus the preview pane")
+	}
+	sv.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if sv.focusPreview {
+		t.Error("esc in the preview should return focus to the list")
+	}
+}
+
+func TestSplitViewNarrowFallback(t *testing.T) {
+	c := &fakeClient{listJSON: `[{"id":1,"name":"Alpha"}]`}
+	sv := NewSplitView(c, CompanyDef, 0.5, 100)
+	sv.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	sv.Update(sv.Init()())
+	if sv.split() {
+		t.Error("split layout should be disabled below MinWidth")
+	}
+	if sv.View() != sv.list.View() {
+		t.Error("narrow split view should render the list only")
+	}
+}
+
+func TestEntityDefGet(t *testing.T) {
+	data, err := JobDef.Get(&fakeClient{getJSON: `{"id":4711,"command":"sync"}`}, 4711)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if j := data.(cli.Job); j.ID != 4711 || j.Command != "sync" {
+		t.Errorf("loaded %+v", j)
+	}
+
+	if _, err := JobDef.Get(&fakeClient{getJSON: `{}`}, 4711); err == nil {
+		t.Error("an empty record should be reported as not found")
+	}
+	if Lookup("job") != JobDef || Lookup("nope") != nil {
+		t.Error("Lookup by CLI entity failed")
+	}
+}
+
+// createClient answers Create with a fixed output.
+type createClient struct {
+	fakeClient
+	created string
+}
+
+func (c *createClient) Create(entity string, args ...string) ([]byte, error) {
+	return []byte(c.created), nil
+}
+
+func TestEntityDefCreated(t *testing.T) {
+	c := &createClient{fakeClient: fakeClient{getJSON: `{"id":9,"name":"Acme","email":"info@acme.cz"}`}}
+	for _, out := range []string{`{"id":9}`, `[{"id":9,"name":"Acme"}]`} {
+		co, ok := CompanyDef.Created(c, []byte(out)).(cli.Company)
+		if !ok || co.ID != 9 || co.Email != "info@acme.cz" {
+			t.Errorf("Created(%s) = %+v, want the loaded record", out, co)
+		}
+	}
+	for _, out := range []string{``, `{}`, `"created"`, `[]`} {
+		if data := CompanyDef.Created(c, []byte(out)); data != nil {
+			t.Errorf("Created(%q) = %+v, want nil", out, data)
+		}
+	}
+	if co := CompanyDef.Created(&createClient{}, []byte(`{"id":9,"name":"Acme"}`)).(cli.Company); co.Name != "Acme" {
+		t.Errorf("a record that cannot be loaded should come from the output, got %+v", co)
+	}
+}
+
+func TestEditorViewOpensCreatedRecord(t *testing.T) {
+	c := &createClient{fakeClient: fakeClient{getJSON: `{"id":9,"name":"Acme"}`}, created: `{"id":9}`}
+	ev := NewEditorView(c, CompanyDef, nil, true)
+	ev.form.SetValue("Name", "Acme")
+	_, cmd := ev.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msgs := cmdMsgs(cmd)
+	open, ok := msgs[0].(ui.NavigateBackAndOpenMsg)
+	if len(msgs) != 1 || !ok {
+		t.Fatalf("create = %v", msgs)
+	}
+	if open.Select != 9 || !strings.Contains(open.Status, "Acme") {
+		t.Errorf("open = %+v", open)
+	}
+	if d, ok := open.View.(*DetailView); !ok || d.def != CompanyDef {
+		t.Errorf("opened %T, want the company's detail", open.View)
+	}
+
+	c.created = "Company created"
+	ev = NewEditorView(c, CompanyDef, nil, true)
+	ev.form.SetValue("Name", "Acme")
+	_, cmd = ev.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := cmdMsgs(cmd)[0].(ui.NavigateBackAndRefreshMsg); !ok {
+		t.Error("output without a record should go back to the list")
+	}
+}
+
+func TestListViewSelect(t *testing.T) {
+	lv := NewListView(&fakeClient{listJSON: `[{"id":3},{"id":2},{"id":1}]`}, CompanyDef)
+	lv.Select(2)
+	feed(lv, lv.Refresh()())
+	if row := lv.table.SelectedRow(); row == nil || row.ID != 2 {
+		t.Errorf("selected %+v, want record 2", row)
+	}
+	lv.table.HandleKey("up")
+	feed(lv, lv.Refresh()())
+	if row := lv.table.SelectedRow(); row == nil || row.ID != 3 {
+		t.Errorf("a later refresh should not select again, got %+v", row)
+	}
+}
+
+func TestListViewSessionState(t *testing.T) {
+	saved := Session
+	defer func() { Session = saved }()
+	Session = session.New()
+	ls := Session.List("company")
+	ls.Filter = "beta"
+	ls.Sort, ls.Desc = "name", true
+	ls.Hidden = []string{"email"}
+
+	c := &fakeClient{listJSON: `[{"id":1,"name":"Alpha"},{"id":2,"name":"Beta"},{"id":3,"name":"Betamax"}]`}
+	lv := NewListView(c, CompanyDef)
+	lv.Update(lv.Init()())
+
+	if row := lv.table.SelectedRow(); row == nil || row.ID != 3 {
+		t.Fatalf("filter and descending sort should select Betamax first, got %+v", row)
+	}
+	if strings.Contains(lv.View(), "Email") {
+		t.Error("hidden column is still rendered")
+	}
+
+	lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if got := Session.List("company").Sort; got != "ic" {
+		t.Errorf("sort should move to the next visible column and be recorded, got %q", got)
+	}
+}
+
+func TestListViewColumnLayoutState(t *testing.T) {
+	saved := Session
+	defer func() { Session = saved }()
+	Session = session.New()
+	ls := Session.List("company")
+	ls.Widths = map[string]int{"name": 12}
+	ls.Extra = []string{"slug"}
+
+	c := &fakeClient{listJSON: `[{"id":1,"name":"Alpha","slug":"alpha"}]`}
+	lv := NewListView(c, CompanyDef)
+	lv.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	lv.Update(lv.Init()())
+	if !strings.Contains(lv.View(), "alpha") {
+		t.Error("the restored extra column should show the slug")
+	}
+
+	lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	lv.Update(tea.KeyMsg{Type: tea.KeyDown})
+	lv.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if got := Session.List("company").Widths["name"]; got != 13 {
+		t.Errorf("widening Name should be recorded, width = %d", got)
+	}
+}
+
+func TestListViewRestoredOffsetPastEnd(t *testing.T) {
+	saved := Session
+	defer func() { Session = saved }()
+	Session = session.New()
+	Session.List("company").Offset = 50
+
+	lv := NewListView(&fakeClient{listJSON: `[]`}, CompanyDef)
+	msg := lv.Init()()
+	_, cmd := lv.Update(msg)
+	if cmd == nil || lv.table.Offset() != 0 {
+		t.Fatalf("an empty restored page should reload from the start (offset %d)", lv.table.Offset())
+	}
+	if Session.List("company").Offset != 0 {
+		t.Error("reset offset was not recorded in the session")
+	}
+}
+
+func TestDetailViewButtonClick(t *testing.T) {
+	dv := NewDetailView(&fakeClient{}, CompanyDef, cli.Company{ID: 1, Name: "Acme"})
+	lines := strings.Split(dv.View(), "\n")
+	for y, l := range lines {
+		if x := strings.Index(l, "[e] "); x >= 0 {
+			_, cmd := dv.Update(ui.ClickMsg{X: len([]rune(l[:x])) + 1, Y: y})
+			if cmd == nil {
+				t.Fatal("clicking Edit returned no command")
+			}
+			if nav, ok := cmd().(ui.NavigateToMsg); !ok {
+				t.Errorf("clicking Edit should open the editor, got %T", nav.View)
+			}
+			return
+		}
+	}
+	t.Fatal("Edit button not rendered")
+}
+
+func TestEditorViewClickFocusesField(t *testing.T) {
+	ev := NewEditorView(&fakeClient{}, CompanyDef, cli.Company{ID: 1, Name: "Acme"}, false)
+	ev.Update(ui.ClickMsg{X: 20, Y: formFieldsTop + 2})
+	if ev.form.Focused() != 2 {
+		t.Errorf("cursor = %d, want 2", ev.form.Focused())
+	}
+	ev.Update(ui.ClickMsg{X: 20, Y: 0})
+	if ev.form.Focused() != 2 {
+		t.Error("clicking the title should keep the focus")
+	}
+}
+
+func TestEditorViewBlocksInvalidSave(t *testing.T) {
+	ev := NewEditorView(&fakeClient{}, CompanyDef, cli.Company{ID: 1, Name: "Acme"}, false)
+	ev.form.SetValue("Name", "")
+	ev.form.SetValue("Email", "not an email")
+	ev.form.Focus(3)
+	if _, cmd := ev.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Fatal("an invalid form should not be saved")
+	}
+	if ev.form.Focused() != 0 || ev.form.Err("Email") == nil {
+		t.Errorf("focus = %d, email error = %v", ev.form.Focused(), ev.form.Err("Email"))
+	}
+
+	ev.form.SetValue("Name", "Acme")
+	ev.form.SetValue("Email", "info@acme.cz")
+	if _, cmd := ev.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Error("a valid form should be saved")
+	}
+}
+
+// updateClient records the args of the updates it runs.
+type updateClient struct {
+	fakeClient
+	updated [][]string
+}
+
+func (c *updateClient) Update(entity string, args ...string) error {
+	c.updated = append(c.updated, args)
+	return nil
+}
+
+func TestEditorViewUpdatesChangedFields(t *testing.T) {
+	src := cli.EventSource{ID: 3, Name: "Shop", AdapterType: "mysql", DbPassword: "secret", Enabled: 1}
+	stored, _ := json.Marshal(src)
+	c := &updateClient{fakeClient: fakeClient{getJSON: string(stored)}}
+	ev := NewEditorView(c, EventSourceDef, src, false)
+
+	_, cmd := ev.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msgs := cmdMsgs(cmd); len(msgs) != 1 || msgs[0] != (ui.StatusMsg{Text: "No changes to save"}) {
+		t.Fatalf("saving without changes = %v", msgs)
+	}
+
+	ev.form.SetValue("Name", "Eshop")
+	ev.form.SetValue("DB Host", "db.local")
+	_, cmd = ev.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msgs := cmdMsgs(cmd)
+	confirm, ok := msgs[0].(ui.ConfirmMsg)
+	if len(msgs) != 1 || !ok {
+		t.Fatalf("save = %v, want a preview", msgs)
+	}
+	for _, want := range []string{"You are about to change:", "Name: Shop → Eshop", "DB Host: (empty) → db.local"} {
+		if !strings.Contains(confirm.Label, want) {
+			t.Errorf("preview %q lacks %q", confirm.Label, want)
+		}
+	}
+	if len(c.updated) != 0 {
+		t.Fatal("nothing should be updated before the preview is confirmed")
+	}
+
+	_, cmd = ev.Update(confirm.Action())
+	if _, ok := cmdMsgs(cmd)[0].(ui.NavigateBackAndRefreshMsg); !ok {
+		t.Error("a saved editor should go back")
+	}
+	want := [][]string{{"--id", "3", "--name", "Eshop", "--db_host", "db.local"}}
+	if !reflect.DeepEqual(c.updated, want) {
+		t.Errorf("updated %v, want %v", c.updated, want)
+	}
+}
+
+func TestPreviewChangesHidesSecrets(t *testing.T) {
+	preview := previewChanges([]ui.Change{{Label: "DB Password", Old: "old", New: "new", Secret: true}})
+	if strings.Contains(preview, "old") || strings.Contains(preview, "new") || !strings.Contains(preview, "DB Password: (changed)") {
+		t.Errorf("preview = %q", preview)
+	}
+}
+
+func TestValidIC(t *testing.T) {
+	for v, ok := range map[string]bool{"27082440": true, "25596641": true, "27082441": false, "2708244": false, "2708244x": false} {
+		if got := validIC(v) == nil; got != ok {
+			t.Errorf("validIC(%q) ok = %v, want %v", v, got, ok)
+		}
+	}
+}
+
+func TestDetailViewAlignsLocalisedLabels(t *testing.T) {
+	i18n.SetLanguage("cs")
+	defer i18n.SetLanguage("en")
+
+	dv := NewDetailView(&fakeClient{}, CompanyDef, cli.Company{ID: 1, Name: "Účetní s.r.o."})
+	lines := strings.Split(dv.View(), "\n")[2:] // below the title
+	col := -1
+	for _, l := range lines {
+		i := strings.Index(l, ": ")
+		if i < 0 || strings.ContainsAny(l, "[/") {
+			continue
+		}
+		if w := ui.Width(l[:i]); col < 0 {
+			col = w
+		} else if w != col {
+			t.Errorf("value column at %d, want %d: %q", w, col, l)
+		}
+	}
+	if col < 0 {
+		t.Fatal("no fields rendered")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 )
 
@@ -11,7 +12,7 @@ var EventRuleDef = &EntityDef{
 	Name: "📌 Event Rules", CLIEntity: "eventrule", DeleteAction: "remove", Limit: 10,
 	Columns: []ui.TableColumn{
 		{Header: "ID", Width: 5, Field: "id"}, {Header: "Source", Width: 8, Field: "source"},
-		{Header: "Evidence", Width: 20, Field: "evidence"}, {Header: "Operation", Width: 10, Field: "op"},
+		{Header: "Evidence", Width: 20, Field: "evidence", Flex: 1}, {Header: "Operation", Width: 10, Field: "op"},
 		{Header: "Template", Width: 8, Field: "template"}, {Header: "Enabled", Width: 8, Field: "enabled"},
 	},
 	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
@@ -49,38 +50,23 @@ var EventRuleDef = &EntityDef{
 	ToEditor: func(data interface{}) []ui.EditorField {
 		er := data.(cli.EventRule)
 		return []ui.EditorField{
-			{Label: "Evidence", Placeholder: "e.g. faktura-vydana", Value: er.Evidence},
-			{Label: "Operation", Placeholder: "any|create|update|delete", Value: er.Operation},
-			{Label: "RunTemplate ID", Placeholder: "RunTemplate ID", Value: fmt.Sprintf("%d", er.RunTemplateID)},
-			{Label: "Priority", Placeholder: "0", Value: fmt.Sprintf("%d", er.Priority)},
-			{Label: "Enabled", Placeholder: "1 or 0", Value: fmt.Sprintf("%d", er.Enabled)},
-			{Label: "Env Mapping", Placeholder: `{"KEY":"value"}`, Value: er.EnvMapping},
+			{Label: "Evidence", Flag: "--evidence", Placeholder: "e.g. faktura-vydana", Value: er.Evidence, Required: true},
+			{Label: "Operation", Flag: "--operation", Value: er.Operation, Kind: ui.SelectField, Options: operationOptions},
+			{Label: "RunTemplate ID", Flag: "--runtemplate_id", Placeholder: "RunTemplate ID", Value: fmt.Sprintf("%d", er.RunTemplateID), Kind: ui.PickerField, Ref: RunTemplateDef.CLIEntity},
+			{Label: "Priority", Flag: "--priority", Placeholder: "0", Value: fmt.Sprintf("%d", er.Priority), Kind: ui.IntField},
+			{Label: "Enabled", Flag: "--enabled", Value: fmt.Sprintf("%d", er.Enabled), Kind: ui.ToggleField},
+			{Label: "Env Mapping", Flag: "--env_mapping", Placeholder: `{"KEY":"value"}`, Value: er.EnvMapping, Kind: ui.TextAreaField, Ext: ".json"},
 		}
 	},
-	UpdateArgs: func(data interface{}, fields map[string]string) []string {
-		er := data.(cli.EventRule)
-		args := []string{
-			"--id", fmt.Sprintf("%d", er.ID),
-			"--evidence", fields["Evidence"],
-			"--operation", fields["Operation"],
-			"--runtemplate_id", fields["RunTemplate ID"],
-			"--priority", fields["Priority"],
-			"--enabled", fields["Enabled"],
-		}
-		if v := fields["Env Mapping"]; v != "" {
-			args = append(args, "--env_mapping", v)
-		}
-		return args
-	},
 	NewFields: func() []ui.EditorField {
 		return []ui.EditorField{
-			{Label: "Event Source ID", Placeholder: "Event Source ID", Required: true},
+			{Label: "Event Source ID", Placeholder: "Event Source ID", Required: true, Kind: ui.PickerField, Ref: EventSourceDef.CLIEntity},
 			{Label: "Evidence", Placeholder: "e.g. faktura-vydana", Required: true},
-			{Label: "RunTemplate ID", Placeholder: "RunTemplate ID", Required: true},
-			{Label: "Operation", Placeholder: "any", Value: "any"},
-			{Label: "Priority", Placeholder: "0", Value: "0"},
-			{Label: "Enabled", Placeholder: "1 or 0", Value: "1"},
-			{Label: "Env Mapping", Placeholder: `{"KEY":"value"}`},
+			{Label: "RunTemplate ID", Placeholder: "RunTemplate ID", Required: true, Kind: ui.PickerField, Ref: RunTemplateDef.CLIEntity},
+			{Label: "Operation", Value: "any", Kind: ui.SelectField, Options: operationOptions},
+			{Label: "Priority", Placeholder: "0", Value: "0", Kind: ui.IntField},
+			{Label: "Enabled", Value: "1", Kind: ui.ToggleField},
+			{Label: "Env Mapping", Placeholder: `{"KEY":"value"}`, Kind: ui.TextAreaField, Ext: ".json"},
 		}
 	},
 	CreateArgs: func(fields map[string]string) []string {
@@ -97,10 +83,24 @@ var EventRuleDef = &EntityDef{
 		}
 		return args
 	},
+	Duplicate: func(data interface{}) map[string]string {
+		r := data.(cli.EventRule)
+		return map[string]string{
+			"Event Source ID": fmt.Sprintf("%d", r.EventSourceID),
+			"Evidence":        r.Evidence,
+			"RunTemplate ID":  fmt.Sprintf("%d", r.RunTemplateID),
+			"Operation":       r.Operation,
+			"Priority":        fmt.Sprintf("%d", r.Priority),
+			"Enabled":         fmt.Sprintf("%d", r.Enabled),
+			"Env Mapping":     r.EnvMapping,
+		}
+	},
+	Record:   func() interface{} { return &cli.EventRule{} },
 	GetID:    func(data interface{}) int { return data.(cli.EventRule).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("EventRule %d", data.(cli.EventRule).ID) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("EventRule %d", data.(cli.EventRule).ID) },
 	Actions: []ui.ActionDef{
 		{Label: "Edit", Key: "e", Command: "edit"},
+		{Label: "Duplicate", Key: "D", Command: "duplicate"},
 		{Label: "Delete", Key: "d", Command: "delete"},
 	},
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -11,8 +12,8 @@ import (
 var EventSourceDef = &EntityDef{
 	Name: "📡 Event Sources", CLIEntity: "eventsource", DeleteAction: "remove", Limit: 10,
 	Columns: []ui.TableColumn{
-		{Header: "ID", Width: 5, Field: "id"}, {Header: "Name", Width: 25, Field: "name"},
-		{Header: "Adapter", Width: 30, Field: "adapter"}, {Header: "Enabled", Width: 8, Field: "enabled"},
+		{Header: "ID", Width: 5, Field: "id"}, {Header: "Name", Width: 25, Field: "name", Flex: 2},
+		{Header: "Adapter", Width: 30, Field: "adapter", Flex: 1}, {Header: "Enabled", Width: 8, Field: "enabled"},
 	},
 	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
 		var items []cli.EventSource
@@ -48,46 +49,30 @@ var EventSourceDef = &EntityDef{
 	ToEditor: func(data interface{}) []ui.EditorField {
 		es := data.(cli.EventSource)
 		return []ui.EditorField{
-			{Label: "Name", Placeholder: "Source name", Value: es.Name},
-			{Label: "Adapter Type", Placeholder: "abraflexi-webhook-acceptor", Value: es.AdapterType},
-			{Label: "DB Connection", Placeholder: "mysql|pgsql|sqlite", Value: es.DbConnection},
-			{Label: "DB Host", Placeholder: "localhost", Value: es.DbHost},
-			{Label: "DB Port", Placeholder: "3306", Value: es.DbPort},
-			{Label: "DB Database", Placeholder: "database name", Value: es.DbDatabase},
-			{Label: "DB Username", Placeholder: "username", Value: es.DbUsername},
-			{Label: "DB Password", Placeholder: "password", Value: es.DbPassword},
-			{Label: "Poll Interval", Placeholder: "60", Value: fmt.Sprintf("%d", es.PollInterval)},
-			{Label: "Enabled", Placeholder: "1 or 0", Value: fmt.Sprintf("%d", es.Enabled)},
-		}
-	},
-	UpdateArgs: func(data interface{}, fields map[string]string) []string {
-		es := data.(cli.EventSource)
-		return []string{
-			"--id", fmt.Sprintf("%d", es.ID),
-			"--name", fields["Name"],
-			"--adapter_type", fields["Adapter Type"],
-			"--db_connection", fields["DB Connection"],
-			"--db_host", fields["DB Host"],
-			"--db_port", fields["DB Port"],
-			"--db_database", fields["DB Database"],
-			"--db_username", fields["DB Username"],
-			"--db_password", fields["DB Password"],
-			"--poll_interval", fields["Poll Interval"],
-			"--enabled", fields["Enabled"],
+			{Label: "Name", Flag: "--name", Placeholder: "Source name", Value: es.Name, Required: true},
+			{Label: "Adapter Type", Flag: "--adapter_type", Placeholder: "abraflexi-webhook-acceptor", Value: es.AdapterType},
+			{Label: "DB Connection", Flag: "--db_connection", Value: es.DbConnection, Kind: ui.SelectField, Options: dbConnectionOptions},
+			{Label: "DB Host", Flag: "--db_host", Placeholder: "localhost", Value: es.DbHost},
+			{Label: "DB Port", Flag: "--db_port", Placeholder: "3306", Value: es.DbPort, Kind: ui.IntField, Min: 1, Max: 65535},
+			{Label: "DB Database", Flag: "--db_database", Placeholder: "database name", Value: es.DbDatabase},
+			{Label: "DB Username", Flag: "--db_username", Placeholder: "username", Value: es.DbUsername},
+			{Label: "DB Password", Flag: "--db_password", Placeholder: "password", Value: es.DbPassword, Kind: ui.SecretField},
+			{Label: "Poll Interval", Flag: "--poll_interval", Placeholder: "60", Value: fmt.Sprintf("%d", es.PollInterval), Kind: ui.IntField, Min: 1, Max: 86400},
+			{Label: "Enabled", Flag: "--enabled", Value: fmt.Sprintf("%d", es.Enabled), Kind: ui.ToggleField},
 		}
 	},
 	NewFields: func() []ui.EditorField {
 		return []ui.EditorField{
 			{Label: "Name", Placeholder: "Source name", Required: true},
 			{Label: "Adapter Type", Placeholder: "abraflexi-webhook-acceptor", Required: true},
-			{Label: "DB Connection", Placeholder: "mysql", Value: "mysql"},
+			{Label: "DB Connection", Value: "mysql", Kind: ui.SelectField, Options: dbConnectionOptions},
 			{Label: "DB Host", Placeholder: "localhost", Value: "localhost"},
-			{Label: "DB Port", Placeholder: "3306", Value: "3306"},
+			{Label: "DB Port", Placeholder: "3306", Value: "3306", Kind: ui.IntField, Min: 1, Max: 65535},
 			{Label: "DB Database", Placeholder: "database name"},
 			{Label: "DB Username", Placeholder: "username"},
-			{Label: "DB Password", Placeholder: "password"},
-			{Label: "Poll Interval", Placeholder: "60", Value: "60"},
-			{Label: "Enabled", Placeholder: "1 or 0", Value: "1"},
+			{Label: "DB Password", Placeholder: "password", Kind: ui.SecretField},
+			{Label: "Poll Interval", Placeholder: "60", Value: "60", Kind: ui.IntField, Min: 1, Max: 86400},
+			{Label: "Enabled", Value: "1", Kind: ui.ToggleField},
 		}
 	},
 	CreateArgs: func(fields map[string]string) []string {
@@ -117,10 +102,26 @@ var EventSourceDef = &EntityDef{
 		}
 		return args
 	},
+	Duplicate: func(data interface{}) map[string]string {
+		s := data.(cli.EventSource)
+		return map[string]string{
+			"Name":          s.Name,
+			"Adapter Type":  s.AdapterType,
+			"DB Connection": s.DbConnection,
+			"DB Host":       s.DbHost,
+			"DB Port":       s.DbPort,
+			"DB Database":   s.DbDatabase,
+			"DB Username":   s.DbUsername,
+			"Poll Interval": fmt.Sprintf("%d", s.PollInterval),
+			"Enabled":       fmt.Sprintf("%d", s.Enabled),
+		}
+	},
+	Record:   func() interface{} { return &cli.EventSource{} },
 	GetID:    func(data interface{}) int { return data.(cli.EventSource).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("EventSource: %s", data.(cli.EventSource).Name) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("EventSource: %s", data.(cli.EventSource).Name) },
 	Actions: []ui.ActionDef{
 		{Label: "Edit", Key: "e", Command: "edit"},
+		{Label: "Duplicate", Key: "D", Command: "duplicate"},
 		{
 			Label:   "Test",
 			Key:     "t",
@@ -130,11 +131,11 @@ var EventSourceDef = &EntityDef{
 				return func() tea.Msg {
 					output, err := c.RunRaw("eventsource", "test", "--format=json",
 						"--id", fmt.Sprintf("%d", es.ID))
-					viewer := ui.NewViewer(fmt.Sprintf("Test: %s", es.Name))
+					viewer := ui.NewViewer(i18n.Tf("Test: %s", es.Name))
 					if err != nil {
-						viewer.SetContent(fmt.Sprintf("Test: %s", es.Name), fmt.Sprintf("Error: %v\n\n%s", err, string(output)))
+						viewer.SetContent(i18n.Tf("Test: %s", es.Name), i18n.Tf("Error: %v\n\n%s", err, string(output)))
 					} else {
-						viewer.SetContent(fmt.Sprintf("Test: %s", es.Name), string(output))
+						viewer.SetContent(i18n.Tf("Test: %s", es.Name), string(output))
 					}
 					return ui.NavigateToMsg{View: viewer}
 				}
@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// externalKey opens the focused form field in the user's editor.
+const externalKey = "ctrl+e"
+
+// externalEditedMsg carries a value edited in the external editor back to
+// its form field.
+type externalEditedMsg struct {
+	label string
+	value string
+	err   error
+}
+
+// editorCommand returns the user's editor with its arguments: $VISUAL,
+// then $EDITOR, then vi.
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if args := strings.Fields(os.Getenv(env)); len(args) > 0 {
+			return args
+		}
+	}
+	return []string{"vi"}
+}
+
+// editExternally suspends the program and opens the value of the focused
+// form field in the user's editor, in a temporary file named with the
+// field's extension. Toggles, selects, pickers and secrets are not edited
+// as text; for them it returns nil.
+func editExternally(form *ui.Form) tea.Cmd {
+	field, ok := form.FocusedField()
+	if !ok {
+		return nil
+	}
+	switch field.Kind {
+	case ui.TextField, ui.TextAreaField, ui.IntField, ui.DateTimeField:
+	default:
+		return nil
+	}
+	ext := field.Ext
+	if ext == "" {
+		ext = ".txt"
+	}
+	f, err := os.CreateTemp("", "multiflexi-*"+ext)
+	if err != nil {
+		return func() tea.Msg { return externalEditedMsg{label: field.Label, err: err} }
+	}
+	path := f.Name()
+	_, err = f.WriteString(field.Value)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return externalEditedMsg{label: field.Label, err: err} }
+	}
+	args := append(editorCommand(), path)
+	multiline := field.Kind == ui.TextAreaField
+	return tea.ExecProcess(exec.Command(args[0], args[1:]...), func(err error) tea.Msg {
+		return readEdited(field.Label, path, multiline, err)
+	})
+}
+
+// readEdited reads the file the editor exited from and removes it. The
+// trailing newline editors add is dropped, and single-line fields get their
+// lines joined.
+func readEdited(label, path string, multiline bool, runErr error) tea.Msg {
+	defer os.Remove(path)
+	if runErr != nil {
+		return externalEditedMsg{label: label, err: runErr}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return externalEditedMsg{label: label, err: err}
+	}
+	value := strings.TrimRight(string(data), "\r\n")
+	if !multiline {
+		value = strings.NewReplacer("\r\n", " ", "\n", " ").Replace(value)
+	}
+	return externalEditedMsg{label: label, value: value}
+}
+
+// applyEdited puts an edited value into its field and reports the outcome.
+func applyEdited(form *ui.Form, msg externalEditedMsg) tea.Cmd {
+	var text string
+	switch {
+	case msg.err != nil:
+		text = i18n.Tf("Editor failed: %v", msg.err)
+	case form.SetEdited(msg.label, msg.value) != nil:
+		text = i18n.Tf("Edited %s is not valid", i18n.T(msg.label))
+	default:
+		text = i18n.Tf("Updated %s", i18n.T(msg.label))
+	}
+	return func() tea.Msg { return ui.StatusMsg{Text: text} }
+}
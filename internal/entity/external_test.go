@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+)
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	if got := editorCommand(); !reflect.DeepEqual(got, []string{"code", "--wait"}) {
+		t.Errorf("editorCommand() = %q", got)
+	}
+	t.Setenv("VISUAL", "nano")
+	if got := editorCommand(); !reflect.DeepEqual(got, []string{"nano"}) {
+		t.Errorf("$VISUAL should win, got %q", got)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if got := editorCommand(); got[0] != "vi" {
+		t.Errorf("editorCommand() = %q, want vi", got)
+	}
+}
+
+func TestReadEditedValue(t *testing.T) {
+	form := ui.NewForm([]ui.EditorField{
+		{Label: "Name"},
+		{Label: "Env Mapping", Kind: ui.TextAreaField, Ext: ".json"},
+	})
+	path := filepath.Join(t.TempDir(), "value.json")
+
+	os.WriteFile(path, []byte("{\n  \"KEY\": \"value\"\n}\n"), 0o600)
+	msg := readEdited("Env Mapping", path, true, nil).(externalEditedMsg)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("the temporary file should be removed")
+	}
+	applyEdited(form, msg)
+	if got := form.Values()["Env Mapping"]; got != "{\n  \"KEY\": \"value\"\n}" {
+		t.Errorf("Env Mapping = %q", got)
+	}
+
+	os.WriteFile(path, []byte("{KEY: value}\n"), 0o600)
+	applyEdited(form, readEdited("Env Mapping", path, true, nil).(externalEditedMsg))
+	if form.Err("Env Mapping") == nil {
+		t.Error("invalid JSON from the editor should be flagged")
+	}
+
+	os.WriteFile(path, []byte("Acme\nCorp\n"), 0o600)
+	applyEdited(form, readEdited("Name", path, false, nil).(externalEditedMsg))
+	if got := form.Values()["Name"]; got != "Acme Corp" {
+		t.Errorf("a single-line field should get the lines joined, got %q", got)
+	}
+}
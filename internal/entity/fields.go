@@ -0,0 +1,47 @@
+package entity
+
+import "github.com/VitexSoftware/multiflexi-tui/internal/ui"
+
+// Choices of the select fields of several entity forms.
+var (
+	// executorOptions are the executors multiflexi can run jobs with.
+	executorOptions = []ui.FieldOption{
+		{Value: "Native"}, {Value: "Docker"}, {Value: "Podman"}, {Value: "Kubernetes"}, {Value: "Azure"},
+	}
+
+	// intervalOptions are the RunTemplate interval codes.
+	intervalOptions = []ui.FieldOption{
+		{Value: "n", Label: "disabled"},
+		{Value: "i", Label: "every minute"},
+		{Value: "h", Label: "hourly"},
+		{Value: "d", Label: "daily"},
+		{Value: "w", Label: "weekly"},
+		{Value: "m", Label: "monthly"},
+		{Value: "y", Label: "yearly"},
+		{Value: "c", Label: "custom (cron)"},
+	}
+
+	// scheduleTypeOptions are the ways a job can have been scheduled.
+	scheduleTypeOptions = []ui.FieldOption{
+		{Value: "adhoc"}, {Value: "hourly"}, {Value: "daily"}, {Value: "weekly"},
+		{Value: "monthly"}, {Value: "yearly"}, {Value: "custom"},
+	}
+
+	// operationOptions are the record operations an event rule reacts to.
+	operationOptions = []ui.FieldOption{
+		{Value: "any"}, {Value: "create"}, {Value: "update"}, {Value: "delete"},
+	}
+
+	// dbConnectionOptions are the database drivers of an event source.
+	dbConnectionOptions = []ui.FieldOption{
+		{Value: "mysql", Label: "MySQL"}, {Value: "pgsql", Label: "PostgreSQL"}, {Value: "sqlite", Label: "SQLite"},
+	}
+)
+
+// Validators of fields of several entity forms.
+var (
+	validURL     = ui.Matches(`^https?://\S+$`, "Must be an http:// or https:// URL")
+	validUUID    = ui.Matches(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`, "Must be a UUID")
+	validVersion = ui.Matches(`^\d+(\.\d+){0,2}([-+][0-9A-Za-z.-]+)?$`, "Must be a version such as 1.0.0")
+	validSlug    = ui.Matches(`^[a-z0-9]+(-[a-z0-9]+)*$`, "Must be lowercase letters, digits and dashes")
+)
@@ -0,0 +1,419 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	helpBrowserHelp = "↑/↓: command • pgup/pgdn: scroll help • /: search • esc: back"
+	helpSearchHelp  = "type to search names and help texts • enter: done • esc: clear"
+	helpListTop     = 2 // title and search lines above the panes
+)
+
+type helpCommandsMsg struct {
+	commands []cli.Command
+	err      error
+}
+
+type helpTextMsg struct {
+	name string
+	text string
+	err  error
+}
+
+// helpAllMsg carries the help texts loaded for a search.
+type helpAllMsg struct {
+	texts map[string]string
+}
+
+// HelpBrowser lists every multiflexi-cli command next to the help of the
+// selected one. Help texts are loaded when a command is first selected, or
+// all at once when a search needs them.
+type HelpBrowser struct {
+	client   cli.Client
+	topic    string // command to select once the list is loaded
+	commands []cli.Command
+	err      error
+	loaded   bool
+
+	help    map[string]string
+	helpErr map[string]error
+	pending map[string]bool
+
+	query      string
+	typing     bool // the search line has the keyboard
+	searchAll  bool // help texts were requested for searching
+	searchDone bool
+
+	visible []int // indexes into commands matching the query
+	cursor  int   // index into visible
+	top     int   // first visible command shown
+	scroll  int   // first help line shown
+	width   int
+	height  int
+}
+
+// NewHelpBrowser creates the help browser; topic, when it names a command,
+// is selected first.
+func NewHelpBrowser(c cli.Client, topic string) *HelpBrowser {
+	return &HelpBrowser{
+		client:  c,
+		topic:   topic,
+		help:    map[string]string{},
+		helpErr: map[string]error{},
+		pending: map[string]bool{},
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m *HelpBrowser) Init() tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		commands, err := client.GetCommands()
+		return helpCommandsMsg{commands: commands, err: err}
+	}
+}
+
+// CapturingInput satisfies ui.InputCapturer: while a search is typed or
+// shown, esc ends it instead of leaving the browser.
+func (m *HelpBrowser) CapturingInput() bool { return m.typing || m.query != "" }
+
+// selected returns the selected command, or nil when none matches.
+func (m *HelpBrowser) selected() *cli.Command {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return nil
+	}
+	return &m.commands[m.visible[m.cursor]]
+}
+
+// matches reports whether command i contains the query in its name,
+// description or loaded help text.
+func (m *HelpBrowser) matches(i int) bool {
+	q := strings.ToLower(strings.TrimSpace(m.query))
+	if q == "" {
+		return true
+	}
+	c := m.commands[i]
+	return strings.Contains(strings.ToLower(c.Name+"\n"+c.Description+"\n"+m.help[c.Name]), q)
+}
+
+// filter recomputes the visible commands, keeping the selection (at first
+// the topic) when it still matches.
+func (m *HelpBrowser) filter() tea.Cmd {
+	keep := m.topic
+	if c := m.selected(); c != nil {
+		keep = c.Name
+	}
+	m.visible = m.visible[:0]
+	m.cursor = 0
+	for i := range m.commands {
+		if m.matches(i) {
+			if m.commands[i].Name == keep {
+				m.cursor = len(m.visible)
+			}
+			m.visible = append(m.visible, i)
+		}
+	}
+	if c := m.selected(); c == nil || c.Name != keep {
+		m.scroll = 0
+	}
+	m.scrollToCursor()
+	return m.loadSelected()
+}
+
+// loadSelected loads the help of the selected command unless it is known.
+func (m *HelpBrowser) loadSelected() tea.Cmd {
+	c := m.selected()
+	if c == nil {
+		return nil
+	}
+	name := c.Name
+	if _, ok := m.help[name]; ok || m.pending[name] || m.helpErr[name] != nil {
+		return nil
+	}
+	m.pending[name] = true
+	client := m.client
+	return func() tea.Msg {
+		text, err := client.GetCommandHelp(name)
+		return helpTextMsg{name: name, text: text, err: err}
+	}
+}
+
+// loadAll loads every missing help text so the search can look into them.
+func (m *HelpBrowser) loadAll() tea.Cmd {
+	if m.searchAll {
+		return nil
+	}
+	m.searchAll = true
+	var names []string
+	for _, c := range m.commands {
+		if _, ok := m.help[c.Name]; !ok {
+			names = append(names, c.Name)
+		}
+	}
+	client := m.client
+	return func() tea.Msg {
+		texts := map[string]string{}
+		for _, name := range names {
+			if text, err := client.GetCommandHelp(name); err == nil {
+				texts[name] = text
+			}
+		}
+		return helpAllMsg{texts: texts}
+	}
+}
+
+// selectCommand moves the cursor to visible command i.
+func (m *HelpBrowser) selectCommand(i int) tea.Cmd {
+	if i < 0 || i >= len(m.visible) || i == m.cursor {
+		return nil
+	}
+	m.cursor = i
+	m.scroll = 0
+	m.scrollToCursor()
+	return m.loadSelected()
+}
+
+// paneHeight is the number of lines of each pane.
+func (m *HelpBrowser) paneHeight() int {
+	h := m.height - helpListTop - 2
+	if h < 3 {
+		h = 3
+	}
+	return h
+}
+
+// listWidth is the width of the command pane; the separator takes a column.
+func (m *HelpBrowser) listWidth() int {
+	w := m.width * 2 / 5
+	if w < 20 {
+		w = 20
+	}
+	return w
+}
+
+func (m *HelpBrowser) scrollToCursor() {
+	h := m.paneHeight()
+	if m.cursor < m.top {
+		m.top = m.cursor
+	}
+	if m.cursor >= m.top+h {
+		m.top = m.cursor - h + 1
+	}
+}
+
+// helpLines returns the lines of the selected command's help pane.
+func (m *HelpBrowser) helpLines() []string {
+	c := m.selected()
+	if c == nil {
+		return nil
+	}
+	switch {
+	case m.helpErr[c.Name] != nil:
+		return []string{ui.ErrorStyle().Render(i18n.Tf("Error: %v", m.helpErr[c.Name]))}
+	case m.pending[c.Name]:
+		return []string{ui.DescriptionStyle().Render(i18n.T("Loading help..."))}
+	}
+	return strings.Split(m.help[c.Name], "\n")
+}
+
+func (m *HelpBrowser) scrollHelp(delta int) {
+	max := len(m.helpLines()) - m.paneHeight() + 2
+	m.scroll += delta
+	if m.scroll > max {
+		m.scroll = max
+	}
+	if m.scroll < 0 {
+		m.scroll = 0
+	}
+}
+
+func (m *HelpBrowser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scrollToCursor()
+		return m, nil
+
+	case helpCommandsMsg:
+		m.loaded = true
+		m.commands, m.err = msg.commands, msg.err
+		return m, m.filter()
+
+	case helpTextMsg:
+		delete(m.pending, msg.name)
+		if msg.err != nil {
+			m.helpErr[msg.name] = msg.err
+		} else {
+			m.help[msg.name] = msg.text
+		}
+		return m, nil
+
+	case helpAllMsg:
+		for name, text := range msg.texts {
+			if _, ok := m.help[name]; !ok {
+				m.help[name] = text
+			}
+		}
+		m.searchDone = true
+		return m, m.filter()
+
+	case ui.ClickMsg:
+		if msg.X < m.listWidth() {
+			return m, m.selectCommand(m.top + msg.Y - helpListTop)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.typing {
+			return m, m.typeSearch(msg)
+		}
+		switch msg.String() {
+		case "up", "k":
+			return m, m.selectCommand(m.cursor - 1)
+		case "down", "j":
+			return m, m.selectCommand(m.cursor + 1)
+		case "home", "g":
+			return m, m.selectCommand(0)
+		case "end", "G":
+			return m, m.selectCommand(len(m.visible) - 1)
+		case "pgdown", "ctrl+d", " ":
+			m.scrollHelp(m.paneHeight() / 2)
+		case "pgup", "ctrl+u":
+			m.scrollHelp(-m.paneHeight() / 2)
+		case "/":
+			m.typing = true
+		case "esc":
+			m.query = ""
+			return m, m.filter()
+		}
+	}
+	return m, nil
+}
+
+// typeSearch edits the search query.
+func (m *HelpBrowser) typeSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.typing = false
+		return nil
+	case tea.KeyEsc:
+		m.typing = false
+		m.query = ""
+	case tea.KeyBackspace:
+		if r := []rune(m.query); len(r) > 0 {
+			m.query = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(msg.Runes)
+	case tea.KeyUp, tea.KeyDown:
+		m.typing = false
+		_, cmd := m.Update(msg)
+		return cmd
+	default:
+		return nil
+	}
+	cmd := m.filter()
+	if m.query != "" {
+		cmd = tea.Batch(cmd, m.loadAll())
+	}
+	return cmd
+}
+
+func (m *HelpBrowser) View() string {
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle().Render(" " + i18n.T("Help") + " "))
+	if m.loaded && m.err == nil {
+		b.WriteString("  " + ui.DescriptionStyle().Render(i18n.N("commands", len(m.visible))))
+	}
+	b.WriteString("\n")
+
+	switch {
+	case m.typing:
+		b.WriteString("/" + m.query + "█")
+	case m.query != "":
+		b.WriteString(ui.DescriptionStyle().Render("/" + m.query))
+	}
+	if m.query != "" && m.searchAll && !m.searchDone {
+		b.WriteString("  " + ui.DescriptionStyle().Render(i18n.T("searching help texts…")))
+	}
+	b.WriteString("\n")
+
+	h := m.paneHeight()
+	switch {
+	case m.err != nil:
+		b.WriteString(ui.ErrorStyle().Render(i18n.Tf("Error: %v", m.err)) + "\n")
+	case !m.loaded:
+		b.WriteString(ui.DescriptionStyle().Render(i18n.T("Loading commands...")) + "\n")
+	default:
+		lw := m.listWidth()
+		rw := m.width - lw - 1
+		sep := ui.DescriptionStyle().Render(strings.TrimSuffix(strings.Repeat("│\n", h), "\n"))
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+			ui.FitBlock(m.renderList(lw, h), lw, h),
+			sep,
+			ui.FitBlock(m.renderHelp(rw, h), rw, h),
+		))
+		b.WriteString("\n")
+	}
+
+	help := helpBrowserHelp
+	if m.typing {
+		help = helpSearchHelp
+	}
+	b.WriteString("\n" + ui.FooterStyle().Render(i18n.T(help)) + "\n")
+	return b.String()
+}
+
+// renderList renders the visible commands with their descriptions.
+func (m *HelpBrowser) renderList(w, h int) string {
+	if len(m.visible) == 0 {
+		return ui.DescriptionStyle().Render(i18n.T("No matching commands."))
+	}
+	nameW := 0
+	for _, i := range m.visible {
+		if n := ui.Width(m.commands[i].Name); n > nameW {
+			nameW = n
+		}
+	}
+	if nameW > w/2 {
+		nameW = w / 2
+	}
+	var lines []string
+	for i := m.top; i < len(m.visible) && i < m.top+h; i++ {
+		c := m.commands[m.visible[i]]
+		line := ui.Fit(" "+ui.Fit(c.Name, nameW)+"  "+c.Description, w)
+		if i == m.cursor {
+			line = ui.SelectedStyle().Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderHelp renders the selected command's description and help text.
+func (m *HelpBrowser) renderHelp(w, h int) string {
+	c := m.selected()
+	if c == nil {
+		return ""
+	}
+	lines := []string{ui.TitleStyle().Render(" " + c.Name + " ")}
+	body := m.helpLines()
+	if m.scroll < len(body) {
+		body = body[m.scroll:]
+	}
+	for _, line := range body {
+		if len(lines) >= h {
+			break
+		}
+		lines = append(lines, " "+ui.Truncate(line, w-1))
+	}
+	return strings.Join(lines, "\n")
+}
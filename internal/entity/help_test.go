@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// helpClient serves a command list and help texts, counting help loads.
+type helpClient struct {
+	fakeClient
+	commands []cli.Command
+	texts    map[string]string
+	loads    []string
+}
+
+func (c *helpClient) GetCommands() ([]cli.Command, error) { return c.commands, nil }
+func (c *helpClient) GetCommandHelp(name string) (string, error) {
+	c.loads = append(c.loads, name)
+	return c.texts[name], nil
+}
+
+func newHelpClient() *helpClient {
+	return &helpClient{
+		commands: []cli.Command{
+			{Name: "company", Description: "Manage companies"},
+			{Name: "job", Description: "Manage jobs"},
+			{Name: "user", Description: "Manage users"},
+		},
+		texts: map[string]string{
+			"company": "Usage: company <action>",
+			"job":     "Usage: job <action>\n  --runtemplate_id  RunTemplate of the job",
+			"user":    "Usage: user <action>",
+		},
+	}
+}
+
+// feed feeds the message and the results of its commands back into m.
+func feed(m tea.Model, msg tea.Msg) {
+	_, cmd := m.Update(msg)
+	for _, next := range cmdMsgs(cmd) {
+		feed(m, next)
+	}
+}
+
+// cmdMsgs runs cmd and flattens batches into their messages.
+func cmdMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, cmdMsgs(c)...)
+		}
+		return msgs
+	}
+	if msg == nil {
+		return nil
+	}
+	return []tea.Msg{msg}
+}
+
+func TestHelpBrowserOpensAtTopicAndLoadsLazily(t *testing.T) {
+	c := newHelpClient()
+	m := NewHelpBrowser(c, "job")
+	for _, msg := range cmdMsgs(m.Init()) {
+		feed(m, msg)
+	}
+	if got := m.selected(); got == nil || got.Name != "job" {
+		t.Fatalf("selected = %v, want the topic", got)
+	}
+	if len(c.loads) != 1 || c.loads[0] != "job" {
+		t.Errorf("loaded %q, want only the selected command's help", c.loads)
+	}
+	if !strings.Contains(m.View(), "--runtemplate_id") {
+		t.Error("the help pane should show the loaded help")
+	}
+
+	feed(m, tea.KeyMsg{Type: tea.KeyDown})
+	feed(m, tea.KeyMsg{Type: tea.KeyUp})
+	if strings.Join(c.loads, ",") != "job,user" {
+		t.Errorf("loaded %q, want each help text once", c.loads)
+	}
+}
+
+func TestHelpBrowserSearchesHelpTexts(t *testing.T) {
+	c := newHelpClient()
+	m := NewHelpBrowser(c, "")
+	for _, msg := range cmdMsgs(m.Init()) {
+		feed(m, msg)
+	}
+	feed(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if !m.CapturingInput() {
+		t.Fatal("typing a search should capture the keyboard")
+	}
+	feed(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("runtemplate")})
+	if len(m.visible) != 1 || m.selected().Name != "job" {
+		t.Fatalf("a search for help text should find job, got %v", m.visible)
+	}
+
+	feed(m, tea.KeyMsg{Type: tea.KeyEnter})
+	feed(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.visible) != 3 || m.CapturingInput() {
+		t.Error("esc should clear the search and release the keyboard")
+	}
+	if m.selected().Name != "job" {
+		t.Error("clearing the search should keep the selection")
+	}
+}
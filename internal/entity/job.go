@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -25,9 +26,9 @@ var JobDef = &EntityDef{
 	Name: "💼 Jobs", CLIEntity: "job", DeleteAction: "delete", Limit: 10,
 	Columns: []ui.TableColumn{
 		{Header: "ID", Width: 8, Field: "id"},
-		{Header: "Command", Width: 25, Field: "command"},
+		{Header: "Command", Width: 25, Field: "command", Flex: 2},
 		{Header: "Status", Width: 12, Field: "status"},
-		{Header: "Schedule", Width: 20, Field: "schedule"},
+		{Header: "Schedule", Width: 20, Field: "schedule", Flex: 1},
 	},
 	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
 		var items []cli.Job
@@ -68,20 +69,16 @@ var JobDef = &EntityDef{
 	ToEditor: func(data interface{}) []ui.EditorField {
 		j := data.(cli.Job)
 		return []ui.EditorField{
-			{Label: "Executor", Placeholder: "Executor", Value: j.Executor},
-			{Label: "Schedule Type", Placeholder: "Schedule type", Value: j.ScheduleType},
+			{Label: "Executor", Flag: "--executor", Value: j.Executor, Kind: ui.SelectField, Options: executorOptions},
+			{Label: "Schedule Type", Flag: "--schedule_type", Value: j.ScheduleType, Kind: ui.SelectField, Options: scheduleTypeOptions},
 		}
 	},
-	UpdateArgs: func(data interface{}, fields map[string]string) []string {
-		j := data.(cli.Job)
-		return []string{"--id", fmt.Sprintf("%d", j.ID), "--executor", fields["Executor"], "--schedule_type", fields["Schedule Type"]}
-	},
 	NewFields: func() []ui.EditorField {
 		return []ui.EditorField{
-			{Label: "RunTemplate ID", Placeholder: "RunTemplate ID", Required: true},
-			{Label: "Scheduled", Placeholder: "YYYY-MM-DD HH:MM:SS or 'now'", Required: true},
-			{Label: "Executor", Placeholder: "Native", Value: "Native"},
-			{Label: "Schedule Type", Placeholder: "adhoc", Value: "adhoc"},
+			{Label: "RunTemplate ID", Placeholder: "RunTemplate ID", Required: true, Kind: ui.PickerField, Ref: RunTemplateDef.CLIEntity},
+			{Label: "Scheduled", Placeholder: "YYYY-MM-DD HH:MM:SS or 'now'", Value: "now", Required: true, Kind: ui.DateTimeField},
+			{Label: "Executor", Value: "Native", Kind: ui.SelectField, Options: executorOptions},
+			{Label: "Schedule Type", Value: "adhoc", Kind: ui.SelectField, Options: scheduleTypeOptions},
 		}
 	},
 	CreateArgs: func(fields map[string]string) []string {
@@ -94,10 +91,20 @@ var JobDef = &EntityDef{
 		}
 		return args
 	},
+	Duplicate: func(data interface{}) map[string]string {
+		j := data.(cli.Job)
+		return map[string]string{
+			"RunTemplate ID": fmt.Sprintf("%d", j.RunTemplateID),
+			"Executor":       j.Executor,
+			"Schedule Type":  j.ScheduleType,
+		}
+	},
+	Record:   func() interface{} { return &cli.Job{} },
 	GetID:    func(data interface{}) int { return data.(cli.Job).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("Job %d", data.(cli.Job).ID) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("Job %d", data.(cli.Job).ID) },
 	Actions: []ui.ActionDef{
 		{Label: "Edit", Key: "e", Command: "edit"},
+		{Label: "Duplicate", Key: "D", Command: "duplicate"},
 		{
 			Label:   "Stdout",
 			Key:     "o",
@@ -109,8 +116,8 @@ var JobDef = &EntityDef{
 					if content == "" {
 						content = "(empty)"
 					}
-					viewer := ui.NewViewer(fmt.Sprintf("Job %d — Stdout", j.ID))
-					viewer.SetContent(fmt.Sprintf("Job %d — Stdout", j.ID), content)
+					viewer := ui.NewViewer(i18n.Tf("Job %d — Stdout", j.ID))
+					viewer.SetContent(i18n.Tf("Job %d — Stdout", j.ID), content)
 					return ui.NavigateToMsg{View: viewer}
 				}
 			},
@@ -126,8 +133,8 @@ var JobDef = &EntityDef{
 					if content == "" {
 						content = "(empty)"
 					}
-					viewer := ui.NewViewer(fmt.Sprintf("Job %d — Stderr", j.ID))
-					viewer.SetContent(fmt.Sprintf("Job %d — Stderr", j.ID), content)
+					viewer := ui.NewViewer(i18n.Tf("Job %d — Stderr", j.ID))
+					viewer.SetContent(i18n.Tf("Job %d — Stderr", j.ID), content)
 					return ui.NavigateToMsg{View: viewer}
 				}
 			},
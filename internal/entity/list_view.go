@@ -2,18 +2,35 @@ package entity
 
 import (
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/session"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-const defaultHelp = "r: refresh • enter: detail • e: edit • n: new"
+const defaultHelp = "r: refresh • enter: detail • e: edit • n: new • D: duplicate • /: filter • o/O: sort • c: columns"
+
+// duplicateKey opens a create form prefilled from the selected record.
+const duplicateKey = "D"
+
+// whereScan is how many of the newest records a list restricted by SetWhere
+// searches for matches.
+const whereScan = 200
+
+// Session holds the list state that survives restarts. Lists restore their
+// page, filter, sort and column layout from it and record changes back;
+// main replaces it with the session loaded from the state file.
+var Session = session.New()
 
 // ListView is a generic list view driven by an EntityDef.
 type ListView struct {
-	client cli.Client
-	def    *EntityDef
-	table  *ui.TableWidget
-	height int // available content area height (updated by WindowSizeMsg)
+	client   cli.Client
+	def      *EntityDef
+	table    *ui.TableWidget
+	height   int  // available content area height (updated by WindowSizeMsg)
+	restored bool // offset came from the session and may point past the end
+	selectID int  // record to put the cursor on once loaded; 0 = none
+
+	where func(ui.TableRow) bool // only rows it accepts are listed; nil = all
 }
 
 // NewListView creates a new ListView for the given entity.
@@ -22,13 +39,60 @@ func NewListView(c cli.Client, def *EntityDef) *ListView {
 	if limit == 0 {
 		limit = 10
 	}
-	return &ListView{
+	m := &ListView{
 		client: c,
 		def:    def,
 		table:  ui.NewTableWidget(def.Name, def.Columns, limit, defaultHelp),
 	}
+	m.restoreState()
+	return m
 }
 
+// restoreState applies the list state saved in the session.
+func (m *ListView) restoreState() {
+	ls := Session.List(m.def.CLIEntity)
+	m.table.SetOffset(ls.Offset)
+	m.table.SetExtraColumns(ls.Extra)
+	m.table.SetHiddenColumns(ls.Hidden)
+	m.table.SetColumnWidths(ls.Widths)
+	m.table.SetFilter(ls.Filter)
+	m.table.SetSort(ls.Sort, ls.Desc)
+	m.restored = ls.Offset > 0
+}
+
+// SetWhere restricts the list to the rows where accepts, searched among
+// the newest whereScan records, and names the restriction next to the
+// title. Such a list starts on its first page without a filter and does not
+// record its state in the session: it is a view into the entity's list, not
+// the list itself.
+func (m *ListView) SetWhere(label string, where func(ui.TableRow) bool) {
+	m.where = where
+	m.restored = false
+	m.table.SetScope(label)
+	m.table.SetOffset(0)
+	m.table.SetFilter("")
+}
+
+// saveState records the list state in the session.
+func (m *ListView) saveState() {
+	if m.where != nil {
+		return
+	}
+	ls := Session.List(m.def.CLIEntity)
+	ls.Offset = m.table.Offset()
+	ls.Filter = m.table.Filter()
+	ls.Sort, ls.Desc = m.table.Sort()
+	ls.Hidden = m.table.HiddenColumns()
+	ls.Widths = m.table.ColumnWidths()
+	ls.Extra = m.table.ExtraColumns()
+}
+
+// CapturingInput satisfies ui.InputCapturer.
+func (m *ListView) CapturingInput() bool { return m.table.Capturing() }
+
+// HelpTopic satisfies ui.HelpTopic.
+func (m *ListView) HelpTopic() string { return m.def.CLIEntity }
+
 func (m *ListView) Init() tea.Cmd {
 	m.table.SetLoading(true)
 	return m.fetchCmd()
@@ -40,10 +104,14 @@ func (m *ListView) Refresh() tea.Cmd {
 	return m.fetchCmd()
 }
 
+// Select satisfies ui.Selector.
+func (m *ListView) Select(id int) { m.selectID = id }
+
 func (m *ListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.height = msg.Height
+		m.table.SetWidth(msg.Width)
 		// Resize table; re-fetch if the row limit changed
 		if m.table.SetContentHeight(msg.Height) {
 			m.table.SetLoading(true)
@@ -53,68 +121,111 @@ func (m *ListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case ui.DataLoadedMsg:
 		rows := msg.Data.([]ui.TableRow)
+		// A restored page can be gone when records were deleted meanwhile.
+		if m.restored && len(rows) == 0 && m.table.Offset() > 0 {
+			m.restored = false
+			m.table.SetOffset(0)
+			m.saveState()
+			return m, m.fetchCmd()
+		}
+		m.restored = false
 		m.table.SetData(rows)
+		if m.selectID != 0 {
+			m.table.SelectID(m.selectID)
+			m.selectID = 0
+		}
 		return m, nil
 
 	case ui.DataErrorMsg:
 		m.table.SetError(msg.Err)
 		return m, nil
 
-	case tea.KeyMsg:
-		// List-level actions defined on the entity
-		for _, la := range m.def.ListActions {
-			if la.Key != msg.String() {
-				continue
-			}
-			if la.Confirm != "" {
-				handler := la.Handler
-				client := m.client
-				label := la.Confirm
-				return m, func() tea.Msg {
-					return ui.ConfirmMsg{
-						Label: label,
-						Action: func() tea.Msg {
-							cmd := handler(client)
-							if cmd != nil {
-								return cmd()
-							}
-							return nil
-						},
-					}
-				}
-			}
-			return m, la.Handler(m.client)
+	case ui.ClickMsg:
+		key := m.table.Click(msg.X, msg.Y, msg.Double)
+		m.saveState()
+		if key == "" {
+			return m, nil
 		}
+		return m, m.handleKey(key)
 
-		refresh, nextPage, prevPage, openDetail, openEditor, openCreate := m.table.HandleKey(msg.String())
+	case tea.KeyMsg:
+		return m, m.handleKey(msg.String())
+	}
+	return m, nil
+}
 
-		if openDetail {
-			row := m.table.SelectedRow()
-			if row != nil && row.FullData != nil {
-				detail := NewDetailView(m.client, m.def, row.FullData)
-				return m, func() tea.Msg { return ui.NavigateToMsg{View: detail} }
+// handleKey runs a key press, or the key a click stands for.
+func (m *ListView) handleKey(key string) tea.Cmd {
+	// Filter typing and the column chooser own the keyboard.
+	if m.table.Capturing() {
+		m.table.HandleKey(key)
+		m.saveState()
+		return nil
+	}
+
+	// List-level actions defined on the entity
+	for _, la := range m.def.ListActions {
+		if la.Key != key {
+			continue
+		}
+		if la.Confirm != "" {
+			handler := la.Handler
+			client := m.client
+			label := la.Confirm
+			return func() tea.Msg {
+				return ui.ConfirmMsg{
+					Label: label,
+					Action: func() tea.Msg {
+						cmd := handler(client)
+						if cmd != nil {
+							return cmd()
+						}
+						return nil
+					},
+				}
 			}
 		}
+		return la.Handler(m.client)
+	}
 
-		if openEditor && m.def.ToEditor != nil {
-			row := m.table.SelectedRow()
-			if row != nil && row.FullData != nil {
-				editor := NewEditorView(m.client, m.def, row.FullData, false)
-				return m, func() tea.Msg { return ui.NavigateToMsg{View: editor} }
-			}
+	if key == duplicateKey && m.def.Duplicate != nil {
+		row := m.table.SelectedRow()
+		if row != nil && row.FullData != nil {
+			editor := NewDuplicateView(m.client, m.def, row.FullData)
+			return func() tea.Msg { return ui.NavigateToMsg{View: editor} }
 		}
+		return nil
+	}
+
+	refresh, nextPage, prevPage, openDetail, openEditor, openCreate := m.table.HandleKey(key)
+	m.saveState()
 
-		if openCreate && m.def.NewFields != nil {
-			editor := NewEditorView(m.client, m.def, nil, true)
-			return m, func() tea.Msg { return ui.NavigateToMsg{View: editor} }
+	if openDetail {
+		row := m.table.SelectedRow()
+		if row != nil && row.FullData != nil {
+			view := m.def.detailView(m.client, row.FullData)
+			return func() tea.Msg { return ui.NavigateToMsg{View: view} }
 		}
+	}
 
-		if refresh || nextPage || prevPage {
-			m.table.SetLoading(true)
-			return m, m.fetchCmd()
+	if openEditor && m.def.ToEditor != nil {
+		row := m.table.SelectedRow()
+		if row != nil && row.FullData != nil {
+			editor := NewEditorView(m.client, m.def, row.FullData, false)
+			return func() tea.Msg { return ui.NavigateToMsg{View: editor} }
 		}
 	}
-	return m, nil
+
+	if openCreate && m.def.NewFields != nil {
+		editor := NewEditorView(m.client, m.def, nil, true)
+		return func() tea.Msg { return ui.NavigateToMsg{View: editor} }
+	}
+
+	if refresh || nextPage || prevPage {
+		m.table.SetLoading(true)
+		return m.fetchCmd()
+	}
+	return nil
 }
 
 func (m *ListView) View() string {
@@ -125,6 +236,9 @@ func (m *ListView) fetchCmd() tea.Cmd {
 	limit := m.table.Limit()
 	offset := m.table.Offset()
 	fetch := m.def.Fetch
+	if m.where != nil {
+		fetch = fetchWhere(fetch, m.where)
+	}
 	client := m.client
 	return func() tea.Msg {
 		rows, err := fetch(client, limit, offset)
@@ -134,3 +248,28 @@ func (m *ListView) fetchCmd() tea.Cmd {
 		return ui.DataLoadedMsg{Data: rows}
 	}
 }
+
+// fetchWhere pages through the rows of the newest whereScan records that
+// where accepts.
+func fetchWhere(fetch func(cli.Client, int, int) ([]ui.TableRow, error), where func(ui.TableRow) bool) func(cli.Client, int, int) ([]ui.TableRow, error) {
+	return func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
+		rows, err := fetch(c, whereScan, 0)
+		if err != nil {
+			return nil, err
+		}
+		var matched []ui.TableRow
+		for _, r := range rows {
+			if where(r) {
+				matched = append(matched, r)
+			}
+		}
+		if offset >= len(matched) {
+			return nil, nil
+		}
+		matched = matched[offset:]
+		if len(matched) > limit {
+			matched = matched[:limit]
+		}
+		return matched, nil
+	}
+}
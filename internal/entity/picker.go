@@ -0,0 +1,193 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// pickerRows is the number of records a picker popup shows at once.
+	pickerRows = 10
+	// pickerScan is how many of the newest records a picker loads; typing
+	// searches among them.
+	pickerScan = 500
+)
+
+const pickerHelp = "type: search • ↑/↓: move • enter: choose • esc: cancel"
+
+// pickerLoadedMsg carries the records of an open picker.
+type pickerLoadedMsg struct {
+	label string
+	rows  []ui.TableRow
+	err   error
+}
+
+// pickerNameMsg carries the name of the record a picker field holds.
+type pickerNameMsg struct {
+	label, id, name string
+}
+
+// recordPicker is the popup table a PickerField chooses its record from.
+type recordPicker struct {
+	def   *EntityDef
+	label string // the form field the record is chosen for
+	table *ui.TableWidget
+}
+
+// pickers opens record pickers for the PickerFields of a form, hands the
+// chosen records back to it and looks up the names of the records the form
+// starts with. Views embedding a form route their messages through update
+// and show view in place of the form while a picker is open.
+type pickers struct {
+	client cli.Client // nil = pickers stay closed
+	form   *ui.Form
+	open   *recordPicker
+	width  int
+}
+
+// init looks up the names of the records the picker fields start with.
+func (p *pickers) init(fields []ui.EditorField) tea.Cmd {
+	if p.client == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, f := range fields {
+		def := Lookup(f.Ref)
+		id, err := strconv.Atoi(f.Value)
+		if f.Kind != ui.PickerField || def == nil || def.Record == nil || def.GetLabel == nil || err != nil || id <= 0 {
+			continue
+		}
+		c, label := p.client, f.Label
+		cmds = append(cmds, func() tea.Msg {
+			data, err := def.Get(c, id)
+			if err != nil {
+				return nil // the bare ID is all there is to show
+			}
+			return pickerNameMsg{label: label, id: strconv.Itoa(id), name: def.GetLabel(data)}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// update handles the messages of the pickers and, while one is open, the
+// keys and clicks. It reports whether msg was handled.
+func (p *pickers) update(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case ui.PickMsg:
+		return p.pick(msg), true
+	case pickerLoadedMsg:
+		if p.open != nil && p.open.label == msg.label {
+			if msg.err != nil {
+				p.open.table.SetError(msg.err)
+			} else {
+				p.open.table.SetData(msg.rows)
+				p.open.table.SetHasMore(false)
+			}
+		}
+		return nil, true
+	case pickerNameMsg:
+		if p.form.Values()[msg.label] == msg.id {
+			p.form.SetPicked(msg.label, msg.id, msg.name)
+		}
+		return nil, true
+	case tea.WindowSizeMsg:
+		p.width = msg.Width - 2 // less the popup border
+		if p.open != nil {
+			p.open.table.SetWidth(p.width)
+		}
+		return nil, false
+	}
+	if p.open == nil {
+		return nil, false
+	}
+	switch msg := msg.(type) {
+	case ui.ClickMsg:
+		// The table starts below the popup border and the prompt.
+		if p.open.table.Click(msg.X-1, msg.Y-2, msg.Double) == "enter" {
+			p.choose()
+		}
+		return nil, true
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return nil, false
+		}
+		p.key(msg)
+		return nil, true
+	}
+	return nil, false
+}
+
+// pick opens the picker of a PickMsg and loads its records.
+func (p *pickers) pick(msg ui.PickMsg) tea.Cmd {
+	def := Lookup(msg.Ref)
+	if p.client == nil || def == nil || def.Fetch == nil {
+		return nil
+	}
+	table := ui.NewTableWidget(def.Name, def.Columns, pickerRows, pickerHelp)
+	table.SetWidth(p.width)
+	table.SetFilter(msg.Filter)
+	p.open = &recordPicker{def: def, label: msg.Label, table: table}
+	c, label := p.client, msg.Label
+	return func() tea.Msg {
+		rows, err := def.Fetch(c, pickerScan, 0)
+		return pickerLoadedMsg{label: label, rows: rows, err: err}
+	}
+}
+
+// key searches, moves through and chooses from the open picker.
+func (p *pickers) key(msg tea.KeyMsg) {
+	table := p.open.table
+	switch key := msg.String(); key {
+	case "esc":
+		p.open = nil
+	case "enter":
+		p.choose()
+	case "up", "down":
+		table.HandleKey(key)
+	case "pgup", "pgdown":
+		step := map[string]string{"pgup": "up", "pgdown": "down"}[key]
+		for i := 0; i < pickerRows; i++ {
+			table.HandleKey(step)
+		}
+	case "backspace":
+		if f := []rune(table.Filter()); len(f) > 0 {
+			table.SetFilter(string(f[:len(f)-1]))
+		}
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			table.SetFilter(table.Filter() + string(msg.Runes))
+		}
+	}
+}
+
+// choose hands the selected record to the form and closes the picker.
+func (p *pickers) choose() {
+	row := p.open.table.SelectedRow()
+	if row == nil {
+		return
+	}
+	name := ""
+	if p.open.def.GetLabel != nil {
+		name = p.open.def.GetLabel(row.FullData)
+	}
+	p.form.SetPicked(p.open.label, strconv.Itoa(row.ID), name)
+	p.open = nil
+}
+
+// view renders the open picker in a box, or "" when none is open.
+func (p *pickers) view() string {
+	if p.open == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(ui.DescriptionStyle().Render(i18n.Tf("Choose %s", i18n.T(p.open.label))))
+	b.WriteString("\n")
+	b.WriteString(strings.TrimRight(p.open.table.View(), "\n"))
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(b.String()) + "\n"
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEditorViewPicksRecord(t *testing.T) {
+	c := &fakeClient{listJSON: `[{"id":1,"name":"Alpha"},{"id":2,"name":"Beta"},{"id":3,"name":"Gamma"}]`}
+	ev := NewEditorView(c, CredentialDef, nil, true)
+	ev.Update(tea.KeyMsg{Type: tea.KeyTab}) // not fed: the cursor blinks forever
+	feed(ev, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("et")})
+	if ev.pick.open == nil || ev.pick.open.def != CompanyDef {
+		t.Fatal("typing on the Company ID field should open the company picker")
+	}
+	if !strings.Contains(ev.View(), "Beta") || strings.Contains(ev.View(), "Gamma") {
+		t.Errorf("the picker should show the companies matching the search:\n%s", ev.View())
+	}
+
+	feed(ev, tea.KeyMsg{Type: tea.KeyEnter})
+	if ev.pick.open != nil {
+		t.Fatal("enter should close the picker")
+	}
+	if got := ev.form.Values()["Company ID"]; got != "2" {
+		t.Errorf("Company ID = %q, want the chosen record", got)
+	}
+	if !strings.Contains(ev.View(), "Company: Beta") {
+		t.Errorf("the field should show the record's name:\n%s", ev.View())
+	}
+}
+
+func TestEditorViewNamesPickedRecords(t *testing.T) {
+	c := &fakeClient{getJSON: `{"id":4,"name":"Nightly sync"}`}
+	fields := []ui.EditorField{{Label: "RunTemplate ID", Value: "4", Kind: ui.PickerField, Ref: RunTemplateDef.CLIEntity}}
+	form := NewActionFormView("Test", fields, nil).WithPickers(c)
+	for _, msg := range cmdMsgs(form.pick.init(fields)) {
+		feed(form, msg)
+	}
+	if !strings.Contains(form.View(), "RunTemplate: Nightly sync") {
+		t.Errorf("the form should name the record it starts with:\n%s", form.View())
+	}
+}
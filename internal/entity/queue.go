@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -13,7 +14,7 @@ var QueueDef = &EntityDef{
 	Columns: []ui.TableColumn{
 		{Header: "ID", Width: 8, Field: "id"}, {Header: "Job", Width: 8, Field: "job"},
 		{Header: "Type", Width: 12, Field: "type"}, {Header: "After", Width: 20, Field: "after"},
-		{Header: "App", Width: 20, Field: "app"}, {Header: "Company", Width: 20, Field: "company"},
+		{Header: "App", Width: 20, Field: "app", Flex: 1}, {Header: "Company", Width: 20, Field: "company", Flex: 1},
 	},
 	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
 		var items []cli.Queue
@@ -41,8 +42,9 @@ var QueueDef = &EntityDef{
 			{Label: "After", Value: q.After},
 		}
 	},
+	Record:   func() interface{} { return &cli.Queue{} },
 	GetID:    func(data interface{}) int { return data.(cli.Queue).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("Queue %d", data.(cli.Queue).ID) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("Queue %d", data.(cli.Queue).ID) },
 	Actions:  []ui.ActionDef{},
 	ListActions: []ui.ListActionDef{
 		{
@@ -53,7 +55,7 @@ var QueueDef = &EntityDef{
 				return func() tea.Msg {
 					output, err := c.RunRaw("queue", "fix", "--format=json")
 					if err != nil {
-						return ui.StatusMsg{Text: fmt.Sprintf("Queue fix failed: %v", err)}
+						return ui.StatusMsg{Text: i18n.Tf("Queue fix failed: %v", err)}
 					}
 					viewer := ui.NewViewer("Queue Fix Result")
 					viewer.RefreshOnBack = true
@@ -70,7 +72,7 @@ var QueueDef = &EntityDef{
 				return func() tea.Msg {
 					_, err := c.RunRaw("queue", "truncate", "--format=json")
 					if err != nil {
-						return ui.StatusMsg{Text: fmt.Sprintf("Queue truncate failed: %v", err)}
+						return ui.StatusMsg{Text: i18n.Tf("Queue truncate failed: %v", err)}
 					}
 					return ui.RefreshCurrentMsg{Status: "Queue truncated"}
 				}
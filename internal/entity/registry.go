@@ -1,6 +1,10 @@
 package entity
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
@@ -25,15 +29,21 @@ type EntityDef struct {
 	// Editor fields from a row's FullData (nil = read-only entity).
 	ToEditor func(data interface{}) []ui.EditorField
 
-	// Build CLI args for update from edited fields. Returns args like ["--name", "foo", "--id", "5"].
-	UpdateArgs func(data interface{}, fields map[string]string) []string
-
 	// Editor fields for creating a new entity (nil = create not supported).
 	NewFields func() []ui.EditorField
 
 	// Build CLI args for create from editor fields.
 	CreateArgs func(fields map[string]string) []string
 
+	// Duplicate returns the values of NewFields copied from a record, keyed
+	// by label; fields left out, such as unique names, UUIDs and secrets,
+	// start as for a new record (nil = duplicate not supported).
+	Duplicate func(data interface{}) map[string]string
+
+	// Record returns a pointer to an empty FullData value, used to load
+	// single records with Client.Get.
+	Record func() interface{}
+
 	// GetID extracts the entity ID from FullData.
 	GetID func(data interface{}) int
 
@@ -45,6 +55,9 @@ type EntityDef struct {
 
 	// ListActions are global actions available from the list view (not per-row).
 	ListActions []ui.ListActionDef
+
+	// Open, if set, builds the view a row opens instead of the detail view.
+	Open func(c cli.Client, data interface{}) tea.Model
 }
 
 // Entry is a menu-compatible wrapper around an EntityDef.
@@ -62,7 +75,94 @@ func Register(e Entry) {
 	All = append(All, e)
 }
 
-// NewListViewForEntity creates a ListView tea.Model for the given entity definition.
+// Lookup finds a registered entity by its CLI name.
+func Lookup(cliEntity string) *EntityDef {
+	for _, e := range All {
+		if e.Def.CLIEntity == cliEntity {
+			return e.Def
+		}
+	}
+	return nil
+}
+
+// Get loads one record by ID and returns it as FullData. A record that the
+// CLI returns empty is reported as not found.
+func (d *EntityDef) Get(c cli.Client, id int) (interface{}, error) {
+	if d.Record == nil {
+		return nil, fmt.Errorf("%s records cannot be loaded by ID", d.CLIEntity)
+	}
+	ptr := d.Record()
+	if err := c.Get(d.CLIEntity, id, ptr); err != nil {
+		return nil, err
+	}
+	data := reflect.ValueOf(ptr).Elem().Interface()
+	if d.GetID(data) != id {
+		return nil, fmt.Errorf("%s %d not found", d.CLIEntity, id)
+	}
+	return data, nil
+}
+
+// Created returns the record a create command reported, as FullData, or nil
+// when its output names no record. The record is loaded again with Get when
+// possible, since the output may hold only some of its fields.
+func (d *EntityDef) Created(c cli.Client, out []byte) interface{} {
+	if d.Record == nil || d.GetID == nil {
+		return nil
+	}
+	ptr := d.Record()
+	if err := json.Unmarshal(out, ptr); err != nil {
+		// Some commands answer with a list of the records they created.
+		var list []json.RawMessage
+		if json.Unmarshal(out, &list) != nil || len(list) == 0 || json.Unmarshal(list[0], ptr) != nil {
+			return nil
+		}
+	}
+	data := reflect.ValueOf(ptr).Elem().Interface()
+	id := d.GetID(data)
+	if id <= 0 {
+		return nil
+	}
+	if full, err := d.Get(c, id); err == nil {
+		return full
+	}
+	return data
+}
+
+// detailView builds the view a record opens: Open's, or a DetailView.
+func (d *EntityDef) detailView(c cli.Client, data interface{}) tea.Model {
+	if d.Open != nil {
+		return d.Open(c, data)
+	}
+	return NewDetailView(c, d, data)
+}
+
+// UpdateArgs builds the CLI args that update a record with the changed
+// fields of its editor: the record's ID, then the Flag of each changed
+// field with its new value, or the field's Args. Fields without a Flag are
+// left out, so values nobody touched are never sent back.
+func (d *EntityDef) UpdateArgs(data interface{}, changes []ui.Change) []string {
+	fields := map[string]ui.EditorField{}
+	for _, f := range d.ToEditor(data) {
+		fields[f.Label] = f
+	}
+	args := []string{"--id", fmt.Sprintf("%d", d.GetID(data))}
+	for _, c := range changes {
+		switch f := fields[c.Label]; {
+		case f.Flag == "":
+		case f.Args != nil:
+			args = append(args, f.Args(c.New)...)
+		default:
+			args = append(args, f.Flag, c.New)
+		}
+	}
+	return args
+}
+
+// NewListViewForEntity creates the list tea.Model for the given entity definition.
+// With Layout.Split enabled the list is wrapped in a master-detail SplitView.
 func NewListViewForEntity(c cli.Client, def *EntityDef) tea.Model {
+	if Layout.Split {
+		return NewSplitView(c, def, Layout.SplitRatio, Layout.MinWidth)
+	}
 	return NewListView(c, def)
 }
@@ -1,17 +1,73 @@
 package entity
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// configLine splits a "KEY: value" line of a RunTemplate config, unquoting
+// the value. Blank lines and comments hold no entry.
+func configLine(line string) (key, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+	key, value, ok = strings.Cut(line, ":")
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if n := len(value); n >= 2 && (value[0] == '"' || value[0] == '\'') && value[n-1] == value[0] {
+		value = value[1 : n-1]
+	}
+	return key, value, ok && key != ""
+}
+
+// validConfig accepts a RunTemplate config: a flat YAML mapping, one
+// unindented "KEY: value" per line.
+func validConfig(v string) error {
+	for _, line := range strings.Split(v, "\n") {
+		body := strings.TrimSpace(line)
+		if body == "" || strings.HasPrefix(body, "#") {
+			continue
+		}
+		if _, _, ok := configLine(line); !ok || line[0] == ' ' || line[0] == '\t' {
+			return errors.New("Must be one KEY: value per line")
+		}
+	}
+	return nil
+}
+
+// configArgs passes a RunTemplate config as the repeatable
+// --config KEY=VALUE option.
+func configArgs(v string) []string {
+	var args []string
+	for _, line := range strings.Split(v, "\n") {
+		if key, value, ok := configLine(line); ok {
+			args = append(args, "--config", key+"="+value)
+		}
+	}
+	return args
+}
+
+// configField edits the config of a RunTemplate as YAML. The record does
+// not carry its config, so the field starts empty and only the keys
+// entered are sent.
+func configField() ui.EditorField {
+	return ui.EditorField{
+		Label: "Config", Flag: "--config", Placeholder: "KEY: value, one per line",
+		Kind: ui.TextAreaField, Ext: ".yaml", Args: configArgs,
+		Validators: []ui.Validator{validConfig},
+	}
+}
+
 var RunTemplateDef = &EntityDef{
 	Name: "📋 Run Templates", CLIEntity: "runtemplate", DeleteAction: "delete", Limit: 10,
 	Columns: []ui.TableColumn{
-		{Header: "ID", Width: 5, Field: "id"}, {Header: "Name", Width: 25, Field: "name"},
+		{Header: "ID", Width: 5, Field: "id"}, {Header: "Name", Width: 25, Field: "name", Flex: 2},
 		{Header: "App ID", Width: 8, Field: "app_id"}, {Header: "Company", Width: 10, Field: "company"},
 		{Header: "Status", Width: 8, Field: "status"}, {Header: "Executor", Width: 12, Field: "executor"},
 	},
@@ -65,31 +121,23 @@ var RunTemplateDef = &EntityDef{
 	ToEditor: func(data interface{}) []ui.EditorField {
 		t := data.(cli.RunTemplate)
 		return []ui.EditorField{
-			{Label: "Name", Placeholder: "Template name", Value: t.Name},
-			{Label: "Interval", Placeholder: "d/w/m/n/y", Value: t.Interv},
-			{Label: "Cron", Placeholder: "*/5 * * * *", Value: t.Cron},
-			{Label: "Executor", Placeholder: "Native", Value: t.Executor},
-			{Label: "Active", Placeholder: "1 or 0", Value: fmt.Sprintf("%d", t.Active)},
+			{Label: "Name", Flag: "--name", Placeholder: "Template name", Value: t.Name, Required: true},
+			{Label: "Interval", Flag: "--interv", Value: t.Interv, Kind: ui.SelectField, Options: intervalOptions},
+			{Label: "Cron", Flag: "--cron", Placeholder: "*/5 * * * *", Value: t.Cron, Validators: []ui.Validator{ui.IsCron}},
+			{Label: "Executor", Flag: "--executor", Value: t.Executor, Kind: ui.SelectField, Options: executorOptions},
+			{Label: "Active", Flag: "--active", Value: fmt.Sprintf("%d", t.Active), Kind: ui.ToggleField},
+			configField(),
 		}
 	},
-	UpdateArgs: func(data interface{}, fields map[string]string) []string {
-		t := data.(cli.RunTemplate)
-		args := []string{"--id", fmt.Sprintf("%d", t.ID), "--name", fields["Name"],
-			"--interv", fields["Interval"], "--executor", fields["Executor"],
-			"--active", fields["Active"]}
-		if v := fields["Cron"]; v != "" {
-			args = append(args, "--cron", v)
-		}
-		return args
-	},
 	NewFields: func() []ui.EditorField {
 		return []ui.EditorField{
 			{Label: "Name", Placeholder: "Template name", Required: true},
-			{Label: "App ID", Placeholder: "Application ID", Required: true},
-			{Label: "Company ID", Placeholder: "Company ID", Required: true},
-			{Label: "Interval", Placeholder: "d/w/m/n/y"},
-			{Label: "Cron", Placeholder: "*/5 * * * *"},
-			{Label: "Executor", Placeholder: "Native", Value: "Native"},
+			{Label: "App ID", Placeholder: "Application ID", Required: true, Kind: ui.PickerField, Ref: ApplicationDef.CLIEntity},
+			{Label: "Company ID", Placeholder: "Company ID", Required: true, Kind: ui.PickerField, Ref: CompanyDef.CLIEntity},
+			{Label: "Interval", Kind: ui.SelectField, Options: intervalOptions},
+			{Label: "Cron", Placeholder: "*/5 * * * *", Validators: []ui.Validator{ui.IsCron}},
+			{Label: "Executor", Value: "Native", Kind: ui.SelectField, Options: executorOptions},
+			configField(),
 		}
 	},
 	CreateArgs: func(fields map[string]string) []string {
@@ -101,12 +149,25 @@ var RunTemplateDef = &EntityDef{
 		if v := fields["Cron"]; v != "" {
 			args = append(args, "--cron", v)
 		}
-		return args
+		return append(args, configArgs(fields["Config"])...)
+	},
+	Duplicate: func(data interface{}) map[string]string {
+		t := data.(cli.RunTemplate)
+		return map[string]string{
+			"Name":       t.Name,
+			"App ID":     fmt.Sprintf("%d", t.AppID),
+			"Company ID": fmt.Sprintf("%d", t.CompanyID),
+			"Interval":   t.Interv,
+			"Cron":       t.Cron,
+			"Executor":   t.Executor,
+		}
 	},
+	Record:   func() interface{} { return &cli.RunTemplate{} },
 	GetID:    func(data interface{}) int { return data.(cli.RunTemplate).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("RunTemplate: %s", data.(cli.RunTemplate).Name) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("RunTemplate: %s", data.(cli.RunTemplate).Name) },
 	Actions: []ui.ActionDef{
 		{Label: "Edit", Key: "e", Command: "edit"},
+		{Label: "Duplicate", Key: "D", Command: "duplicate"},
 		{
 			Label:   "Schedule",
 			Key:     "s",
@@ -114,10 +175,10 @@ var RunTemplateDef = &EntityDef{
 			Handler: func(c cli.Client, data interface{}) tea.Cmd {
 				rt := data.(cli.RunTemplate)
 				form := NewActionFormView(
-					fmt.Sprintf("Schedule: %s", rt.Name),
+					i18n.Tf("Schedule: %s", rt.Name),
 					[]ui.EditorField{
-						{Label: "Schedule Time", Placeholder: "YYYY-MM-DD HH:MM:SS", Value: "now"},
-						{Label: "Executor", Placeholder: "Native", Value: rt.Executor},
+						{Label: "Schedule Time", Placeholder: "YYYY-MM-DD HH:MM:SS", Value: "now", Kind: ui.DateTimeField},
+						{Label: "Executor", Value: rt.Executor, Kind: ui.SelectField, Options: executorOptions},
 					},
 					func(fields map[string]string) tea.Cmd {
 						return func() tea.Msg {
@@ -128,7 +189,7 @@ var RunTemplateDef = &EntityDef{
 							}
 							_, err := c.RunRaw(append([]string{"runtemplate", "schedule", "--format=json"}, args...)...)
 							if err != nil {
-								return ui.StatusMsg{Text: fmt.Sprintf("Schedule failed: %v", err)}
+								return ui.StatusMsg{Text: i18n.Tf("Schedule failed: %v", err)}
 							}
 							return ui.NavigateBackMsg{}
 						}
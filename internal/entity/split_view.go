@@ -0,0 +1,193 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LayoutConfig controls how entity lists are laid out.
+type LayoutConfig struct {
+	Split      bool    // show a live DetailView preview next to the list
+	SplitRatio float64 // fraction of the width given to the list pane
+	MinWidth   int     // narrower terminals fall back to a single pane
+}
+
+// Layout is the layout used by NewListViewForEntity; main sets it from flags.
+var Layout = LayoutConfig{SplitRatio: 0.5, MinWidth: 100}
+
+const (
+	minSplitRatio = 0.2
+	maxSplitRatio = 0.8
+	splitFocusKey = "ctrl+o"
+)
+
+// SplitView shows a ListView in the left pane and a live DetailView preview
+// of the selected row in the right pane.
+type SplitView struct {
+	client cli.Client
+	def    *EntityDef
+	list   *ListView
+
+	preview   *DetailView
+	previewID int
+
+	focusPreview bool
+	ratio        float64
+	minWidth     int
+	width        int
+	height       int
+}
+
+// NewSplitView creates a master-detail view for the given entity.
+func NewSplitView(c cli.Client, def *EntityDef, ratio float64, minWidth int) *SplitView {
+	if ratio < minSplitRatio {
+		ratio = minSplitRatio
+	}
+	if ratio > maxSplitRatio {
+		ratio = maxSplitRatio
+	}
+	return &SplitView{
+		client:    c,
+		def:       def,
+		list:      NewListView(c, def),
+		previewID: -1,
+		ratio:     ratio,
+		minWidth:  minWidth,
+	}
+}
+
+func (m *SplitView) Init() tea.Cmd { return m.list.Init() }
+
+// Refresh satisfies ui.Refreshable.
+func (m *SplitView) Refresh() tea.Cmd { return m.list.Refresh() }
+
+// Select satisfies ui.Selector.
+func (m *SplitView) Select(id int) { m.list.Select(id) }
+
+// HelpTopic satisfies ui.HelpTopic.
+func (m *SplitView) HelpTopic() string { return m.def.CLIEntity }
+
+// CapturingInput satisfies ui.InputCapturer. The focused preview keeps esc
+// so it can hand focus back to the list.
+func (m *SplitView) CapturingInput() bool {
+	return m.focusPreview || m.list.CapturingInput()
+}
+
+// split reports whether the terminal is wide enough for two panes.
+func (m *SplitView) split() bool {
+	return m.width > 0 && m.width >= m.minWidth
+}
+
+// paneWidths returns the list and preview widths; one column is reserved
+// for the separator.
+func (m *SplitView) paneWidths() (int, int) {
+	lw := int(float64(m.width) * m.ratio)
+	return lw, m.width - lw - 1
+}
+
+func (m *SplitView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.split() {
+			m.focusPreview = false
+			_, cmd := m.list.Update(msg)
+			return m, cmd
+		}
+		lw, rw := m.paneWidths()
+		_, cmd := m.list.Update(tea.WindowSizeMsg{Width: lw, Height: msg.Height})
+		if m.preview != nil {
+			m.preview.Update(tea.WindowSizeMsg{Width: rw, Height: msg.Height})
+		}
+		return m, cmd
+
+	case ui.ClickMsg:
+		// Clicks focus the pane they land in; the separator column is inert.
+		if m.split() {
+			lw, _ := m.paneWidths()
+			if msg.X > lw && m.preview != nil {
+				m.focusPreview = true
+				msg.X -= lw + 1
+				_, cmd := m.preview.Update(msg)
+				return m, cmd
+			}
+			if msg.X == lw {
+				return m, nil
+			}
+			m.focusPreview = false
+		}
+
+	case tea.KeyMsg:
+		key := msg.String()
+		if key == splitFocusKey && m.split() && m.preview != nil {
+			m.focusPreview = !m.focusPreview
+			return m, nil
+		}
+		if m.focusPreview && m.preview != nil {
+			// Leaving the preview returns focus to the list instead of popping the view.
+			if key == "esc" || key == "q" {
+				m.focusPreview = false
+				return m, nil
+			}
+			_, cmd := m.preview.Update(msg)
+			return m, cmd
+		}
+	}
+
+	_, cmd := m.list.Update(msg)
+	m.syncPreview()
+	return m, cmd
+}
+
+// syncPreview rebuilds the preview when the selected row changes.
+func (m *SplitView) syncPreview() {
+	row := m.list.table.SelectedRow()
+	if row == nil || row.FullData == nil {
+		m.preview = nil
+		m.previewID = -1
+		m.focusPreview = false
+		return
+	}
+	if m.preview != nil && row.ID == m.previewID {
+		return
+	}
+	m.preview = NewDetailView(m.client, m.def, row.FullData)
+	m.previewID = row.ID
+	if m.split() {
+		_, rw := m.paneWidths()
+		m.preview.Update(tea.WindowSizeMsg{Width: rw, Height: m.height})
+	}
+}
+
+func (m *SplitView) View() string {
+	if !m.split() {
+		return m.list.View()
+	}
+	lw, rw := m.paneWidths()
+	h := m.height
+	if h <= 0 {
+		h = strings.Count(m.list.View(), "\n")
+	}
+
+	right := ui.DescriptionStyle().Render(i18n.T("(no selection)"))
+	if m.preview != nil {
+		right = m.preview.View()
+	}
+
+	sepStyle := ui.DescriptionStyle()
+	if m.focusPreview {
+		sepStyle = ui.ActiveMenuStyle()
+	}
+	sep := sepStyle.Render(strings.TrimSuffix(strings.Repeat("│\n", h), "\n"))
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		ui.FitBlock(m.list.View(), lw, h),
+		sep,
+		ui.FitBlock(right, rw, h),
+	) + "\n"
+}
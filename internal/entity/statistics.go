@@ -0,0 +1,375 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	statsPage       = 200  // jobs fetched per request
+	statsMaxJobs    = 5000 // jobs read at most when walking back through history
+	statsLabelWidth = 12   // width of the sparkline labels
+	statisticsHelp  = "1/2/3, ←/→: time window • r: reload"
+)
+
+// statsWindow is a selectable time window: the span ending now, cut into
+// buckets of equal length.
+type statsWindow struct {
+	label  string
+	span   time.Duration
+	bucket time.Duration
+	format string // layout of the axis timestamps
+}
+
+var statsWindows = []statsWindow{
+	{label: "24 hours", span: 24 * time.Hour, bucket: time.Hour, format: "01-02 15:04"},
+	{label: "7 days", span: 7 * 24 * time.Hour, bucket: 24 * time.Hour, format: "01-02"},
+	{label: "30 days", span: 30 * 24 * time.Hour, bucket: 24 * time.Hour, format: "01-02"},
+}
+
+// jobHistory is the newest part of the job list, read page by page until
+// it reaches back far enough.
+type jobHistory struct {
+	jobs     []cli.Job // newest first
+	since    time.Time // the history holds every job started after since
+	complete bool      // every job was read
+	capped   bool      // reading stopped at statsMaxJobs
+	at       time.Time // when it was read
+}
+
+// covers reports whether the history holds every job started after from.
+func (h *jobHistory) covers(from time.Time) bool {
+	return h.complete || !h.since.After(from)
+}
+
+// loadJobHistory walks the job list, newest first, until a page reaches
+// before from, the list ends or statsMaxJobs were read.
+func loadJobHistory(c cli.Client, from time.Time) (*jobHistory, error) {
+	h := &jobHistory{at: now()}
+	for offset := 0; offset < statsMaxJobs; offset += statsPage {
+		var page []cli.Job
+		if err := c.List("job", statsPage, offset, &page); err != nil {
+			return nil, err
+		}
+		h.jobs = append(h.jobs, page...)
+		if len(page) < statsPage {
+			h.complete = true
+			return h, nil
+		}
+		if t, ok := jobTime(page[len(page)-1]); ok {
+			h.since = t
+			if t.Before(from) {
+				return h, nil
+			}
+		}
+	}
+	h.capped = true
+	return h, nil
+}
+
+// jobTime is when a job started, or ended when its start is not recorded.
+func jobTime(j cli.Job) (time.Time, bool) {
+	if t, ok := parseTime(j.Begin); ok {
+		return t, true
+	}
+	return parseTime(j.End)
+}
+
+// jobDuration is how long a finished job ran.
+func jobDuration(j cli.Job) (time.Duration, bool) {
+	begin, ok := parseTime(j.Begin)
+	if !ok {
+		return 0, false
+	}
+	end, ok := parseTime(j.End)
+	if !ok || end.Before(begin) {
+		return 0, false
+	}
+	return end.Sub(begin), true
+}
+
+// jobStats aggregates the jobs of a time window. The per-bucket slices are
+// oldest first.
+type jobStats struct {
+	from, to  time.Time
+	ok        []float64      // successful jobs per bucket
+	failed    []float64      // failed jobs per bucket
+	duration  []float64      // average seconds of the jobs finished in each bucket
+	executors map[string]int // jobs per executor; "" when none is recorded
+
+	total, succeeded, failures, running int
+	avg                                 time.Duration // over all finished jobs
+}
+
+// aggregateJobs counts the jobs started in window w ending at at.
+func aggregateJobs(jobs []cli.Job, w statsWindow, at time.Time) jobStats {
+	n := int(w.span / w.bucket)
+	s := jobStats{
+		from:      at.Add(-w.span),
+		to:        at,
+		ok:        make([]float64, n),
+		failed:    make([]float64, n),
+		duration:  make([]float64, n),
+		executors: map[string]int{},
+	}
+	finished := make([]int, n)
+	var total time.Duration
+	for _, j := range jobs {
+		t, ok := jobTime(j)
+		if !ok || t.Before(s.from) || t.After(at) {
+			continue
+		}
+		i := int(t.Sub(s.from) / w.bucket)
+		if i >= n {
+			i = n - 1
+		}
+		s.total++
+		s.executors[j.Executor]++
+		switch jobStatus(j) {
+		case "Success":
+			s.succeeded++
+			s.ok[i]++
+		case "Failed":
+			s.failures++
+			s.failed[i]++
+		case "Running":
+			s.running++
+		}
+		if d, ok := jobDuration(j); ok {
+			s.duration[i] += d.Seconds()
+			finished[i]++
+			total += d
+		}
+	}
+	done := 0
+	for i, f := range finished {
+		if f > 0 {
+			s.duration[i] /= float64(f)
+			done += f
+		}
+	}
+	if done > 0 {
+		s.avg = total / time.Duration(done)
+	}
+	return s
+}
+
+// outcomeScale is the largest per-bucket count of successes or failures, the
+// shared scale of their sparklines.
+func (s jobStats) outcomeScale() float64 {
+	scale := 0.0
+	for i := range s.ok {
+		if s.ok[i] > scale {
+			scale = s.ok[i]
+		}
+		if s.failed[i] > scale {
+			scale = s.failed[i]
+		}
+	}
+	return scale
+}
+
+type statsLoadedMsg struct {
+	gen     int
+	history *jobHistory
+	err     error
+}
+
+// Statistics charts the job history of a selectable time window: successes
+// and failures over time, average durations and jobs per executor.
+type Statistics struct {
+	client  cli.Client
+	history *jobHistory
+	err     error
+	window  int // index into statsWindows
+	loading bool
+	gen     int
+	width   int
+	zones   ui.Zones
+}
+
+// NewStatistics creates the statistics view; Init loads the last 24 hours.
+func NewStatistics(c cli.Client) *Statistics {
+	return &Statistics{client: c}
+}
+
+func (m *Statistics) Init() tea.Cmd { return m.Refresh() }
+
+// Refresh satisfies ui.Refreshable — rereads the history of the window.
+func (m *Statistics) Refresh() tea.Cmd {
+	m.gen++
+	m.loading = true
+	gen, client := m.gen, m.client
+	from := now().Add(-statsWindows[m.window].span)
+	return func() tea.Msg {
+		h, err := loadJobHistory(client, from)
+		return statsLoadedMsg{gen: gen, history: h, err: err}
+	}
+}
+
+// setWindow selects window i, reading further back when the loaded history
+// does not reach its start.
+func (m *Statistics) setWindow(i int) tea.Cmd {
+	if i < 0 || i >= len(statsWindows) || i == m.window {
+		return nil
+	}
+	m.window = i
+	h := m.history
+	if h == nil || h.capped || h.covers(h.at.Add(-statsWindows[i].span)) {
+		return nil
+	}
+	return m.Refresh()
+}
+
+func (m *Statistics) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+	case statsLoadedMsg:
+		if msg.gen != m.gen {
+			return m, nil
+		}
+		m.loading = false
+		m.history, m.err = msg.history, msg.err
+
+	case ui.ClickMsg:
+		if id, ok := m.zones.Hit(msg.X, msg.Y); ok {
+			i, _ := strconv.Atoi(id)
+			return m, m.setWindow(i)
+		}
+
+	case tea.KeyMsg:
+		switch key := msg.String(); key {
+		case "1", "2", "3":
+			return m, m.setWindow(int(key[0] - '1'))
+		case "right", "l":
+			return m, m.setWindow((m.window + 1) % len(statsWindows))
+		case "left", "h":
+			return m, m.setWindow((m.window + len(statsWindows) - 1) % len(statsWindows))
+		case "r":
+			if !m.loading {
+				return m, m.Refresh()
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m *Statistics) View() string {
+	var b strings.Builder
+	m.zones.Reset()
+	w := statsWindows[m.window]
+	b.WriteString(ui.TitleStyle().Render(" " + i18n.T("Job Statistics") + " "))
+	if m.loading && m.history != nil {
+		b.WriteString("  " + ui.DescriptionStyle().Render(i18n.T("refreshing…")))
+	}
+	b.WriteString("\n")
+
+	// Window selector
+	b.WriteString(" ")
+	for i, sw := range statsWindows {
+		label := fmt.Sprintf(" %d: %s ", i+1, i18n.T(sw.label))
+		x, y := ui.Cursor(&b)
+		m.zones.Add(strconv.Itoa(i), x, y, ui.Width(label))
+		style := ui.DescriptionStyle()
+		if i == m.window {
+			style = ui.SelectedStyle()
+		}
+		b.WriteString(style.Render(label) + " ")
+	}
+	b.WriteString("\n\n")
+
+	switch {
+	case m.err != nil:
+		b.WriteString(ui.ErrorStyle().Render(i18n.Tf("Error: %v", m.err)) + "\n")
+	case m.history == nil:
+		b.WriteString(ui.DescriptionStyle().Render(i18n.T("Loading job history...")) + "\n")
+	default:
+		m.renderStats(&b, aggregateJobs(m.history.jobs, w, m.history.at), w)
+	}
+
+	b.WriteString("\n" + ui.FooterStyle().Render(i18n.T(statisticsHelp)) + "\n")
+	return b.String()
+}
+
+// renderStats writes the totals, the sparklines and the executor chart.
+func (m *Statistics) renderStats(b *strings.Builder, s jobStats, w statsWindow) {
+	width := m.width
+	if width == 0 {
+		width = 80
+	}
+	desc := ui.DescriptionStyle()
+
+	totals := []string{
+		i18n.N("jobs", s.total),
+		i18n.T("Succeeded") + ": " + strconv.Itoa(s.succeeded),
+		i18n.T("Failed") + ": " + strconv.Itoa(s.failures),
+		i18n.T("Running") + ": " + strconv.Itoa(s.running),
+	}
+	if s.avg > 0 {
+		totals = append(totals, i18n.T("Average duration")+": "+shortDuration(s.avg))
+	}
+	b.WriteString(" " + strings.Join(totals, " • ") + "\n")
+	if h := m.history; h.capped && !h.covers(s.from) {
+		b.WriteString(" " + ui.ErrorStyle().Render(i18n.Tf("Only the newest %d jobs were read; older ones are missing.", len(h.jobs))) + "\n")
+	}
+	b.WriteString("\n")
+
+	// Successes and failures share a scale so their heights compare.
+	chartW := width - statsLabelWidth - 2
+	if chartW < len(s.ok) {
+		chartW = len(s.ok)
+	}
+	scale := s.outcomeScale()
+	for _, line := range []struct {
+		label  string
+		values []float64
+		max    float64
+		style  func() lipgloss.Style
+	}{
+		{"Succeeded", s.ok, scale, ui.ActiveStatusStyle},
+		{"Failed", s.failed, scale, ui.ErrorStyle},
+		{"Duration", s.duration, 0, ui.ActiveMenuStyle},
+	} {
+		b.WriteString(" " + ui.Fit(i18n.T(line.label), statsLabelWidth) + " " + line.style().Render(ui.Sparkline(line.values, chartW, line.max)) + "\n")
+	}
+	from, to := s.from.Format(w.format), s.to.Format(w.format)
+	gap := chartW - ui.Width(from) - ui.Width(to)
+	if gap < 1 {
+		gap = 1
+	}
+	b.WriteString(" " + strings.Repeat(" ", statsLabelWidth+1) + desc.Render(from+strings.Repeat(" ", gap)+to) + "\n\n")
+
+	// Jobs per executor, busiest first
+	b.WriteString(" " + ui.TitleStyle().Render(" "+i18n.T("Jobs per executor")+" ") + "\n")
+	if len(s.executors) == 0 {
+		b.WriteString(" " + desc.Render(i18n.T("No jobs in this time window.")) + "\n")
+		return
+	}
+	bars := make([]ui.Bar, 0, len(s.executors))
+	for name, n := range s.executors {
+		if name == "" {
+			name = i18n.T("(none)")
+		}
+		bars = append(bars, ui.Bar{Label: name, Value: float64(n), Text: strconv.Itoa(n)})
+	}
+	sort.Slice(bars, func(i, j int) bool {
+		if bars[i].Value != bars[j].Value {
+			return bars[i].Value > bars[j].Value
+		}
+		return bars[i].Label < bars[j].Label
+	})
+	for _, line := range strings.Split(ui.BarChart(bars, width-2), "\n") {
+		b.WriteString(" " + line + "\n")
+	}
+}
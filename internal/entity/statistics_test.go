@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// historyClient serves n jobs, one started every hour before at, newest
+// first, and counts the pages read.
+type historyClient struct {
+	fakeClient
+	jobs  []cli.Job
+	pages int
+}
+
+func newHistoryClient(at time.Time, n int) *historyClient {
+	c := &historyClient{}
+	for i := 0; i < n; i++ {
+		begin := at.Add(-time.Duration(i)*time.Hour - 30*time.Minute)
+		j := cli.Job{ID: n - i, Begin: begin.Format("2006-01-02 15:04:05"), Executor: "Native"}
+		switch {
+		case i%4 == 3:
+			j.Exitcode = 1
+			j.Executor = "Docker"
+		case i == 0:
+			j.PID = 99
+		}
+		if i > 0 {
+			j.End = begin.Add(time.Duration(i%3+1) * time.Minute).Format("2006-01-02 15:04:05")
+		}
+		c.jobs = append(c.jobs, j)
+	}
+	return c
+}
+
+func (c *historyClient) List(entity string, limit, offset int, target interface{}) error {
+	c.pages++
+	page := []cli.Job{}
+	if offset < len(c.jobs) {
+		end := offset + limit
+		if end > len(c.jobs) {
+			end = len(c.jobs)
+		}
+		page = c.jobs[offset:end]
+	}
+	*target.(*[]cli.Job) = page
+	return nil
+}
+
+func TestAggregateJobs(t *testing.T) {
+	at, _ := parseTime("2026-10-18 12:00:00")
+	jobs := newHistoryClient(at, 30).jobs
+	s := aggregateJobs(jobs, statsWindows[0], at)
+
+	if s.total != 24 || s.running != 1 || s.failures != 6 || s.succeeded != 17 {
+		t.Errorf("unexpected totals: %d jobs, %d running, %d failed, %d ok", s.total, s.running, s.failures, s.succeeded)
+	}
+	if len(s.ok) != 24 || s.ok[23] != 0 || s.ok[22] != 1 || s.failed[20] != 1 {
+		t.Errorf("jobs should land in their hour, newest last: ok %v failed %v", s.ok, s.failed)
+	}
+	if s.executors["Native"] != 18 || s.executors["Docker"] != 6 {
+		t.Errorf("unexpected executor counts: %v", s.executors)
+	}
+	if s.avg < time.Minute || s.avg > 3*time.Minute || s.duration[22] != 120 {
+		t.Errorf("durations should average the finished jobs: avg %v, buckets %v", s.avg, s.duration)
+	}
+}
+
+func TestLoadJobHistoryStopsAtWindowStart(t *testing.T) {
+	at, _ := parseTime("2026-10-18 12:00:00")
+	c := newHistoryClient(at, 450)
+	h, err := loadJobHistory(c, at.Add(-24*time.Hour))
+	if err != nil || c.pages != 1 || h.complete || !h.covers(at.Add(-24*time.Hour)) {
+		t.Fatalf("one page reaches back a day: %d pages, %+v, %v", c.pages, h, err)
+	}
+	if h.covers(at.Add(-30 * 24 * time.Hour)) {
+		t.Error("a page of 200 hours does not cover 30 days")
+	}
+
+	c.pages = 0
+	if h, _ = loadJobHistory(c, at.Add(-30*24*time.Hour)); c.pages != 3 || !h.complete || len(h.jobs) != 450 {
+		t.Errorf("the whole list should be read: %d pages, %d jobs", c.pages, len(h.jobs))
+	}
+}
+
+func TestStatisticsWindows(t *testing.T) {
+	fixClock(t, "2026-10-18 12:00:00")
+	at := now()
+	c := newHistoryClient(at, 450)
+	m := NewStatistics(c)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	m.Update(m.Init()())
+
+	view := m.View()
+	for _, want := range []string{"24 jobs", "Succeeded: 17", "Failed: 6", "Running: 1", "Average duration: 2m", "Jobs per executor", "Native", "Docker"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("statistics are missing %q:\n%s", want, view)
+		}
+	}
+
+	// The loaded page covers a week; a month needs more of the history.
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}}); cmd != nil {
+		t.Error("7 days are already loaded")
+	}
+	if !strings.Contains(m.View(), "168 jobs") {
+		t.Errorf("7 days should count a week of jobs:\n%s", m.View())
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if cmd == nil {
+		t.Fatal("30 days should read further back")
+	}
+	m.Update(cmd())
+	if !strings.Contains(m.View(), "450 jobs") {
+		t.Errorf("30 days should count every job:\n%s", m.View())
+	}
+}
+
+func TestDashboardJobHistory(t *testing.T) {
+	fixClock(t, "2026-10-18 12:00:00")
+	c := dashboardClient()
+	jobs, _ := json.Marshal(newHistoryClient(now(), 30).jobs)
+	c.lists["job"] = string(jobs)
+	d := NewDashboard(c)
+	d.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	d.Update(d.Init()())
+	if !strings.Contains(d.View(), "Job history") {
+		t.Fatal("the dashboard should show the job history panel")
+	}
+	for d.targets()[d.cursor] != targetHistory {
+		d.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := cmd().(ui.NavigateToMsg).View.(*Statistics); !ok {
+		t.Error("the job history panel should open the statistics")
+	}
+}
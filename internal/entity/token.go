@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -11,8 +12,8 @@ import (
 var TokenDef = &EntityDef{
 	Name: "🎟️ Tokens", CLIEntity: "token", DeleteAction: "delete", Limit: 10,
 	Columns: []ui.TableColumn{
-		{Header: "ID", Width: 8, Field: "id"}, {Header: "User", Width: 20, Field: "user"},
-		{Header: "Token", Width: 45, Field: "token"},
+		{Header: "ID", Width: 8, Field: "id"}, {Header: "User", Width: 20, Field: "user", Flex: 1},
+		{Header: "Token", Width: 45, Field: "token", Flex: 1},
 	},
 	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
 		var items []cli.Token
@@ -38,18 +39,14 @@ var TokenDef = &EntityDef{
 	ToEditor: func(data interface{}) []ui.EditorField {
 		t := data.(cli.Token)
 		return []ui.EditorField{
-			{Label: "User ID", Placeholder: "User ID", Value: t.User},
-			{Label: "Token", Placeholder: "Token value", Value: t.Token},
+			{Label: "User ID", Flag: "--user", Placeholder: "User ID", Value: t.User},
+			{Label: "Token", Flag: "--token", Placeholder: "Token value", Value: t.Token, Kind: ui.SecretField},
 		}
 	},
-	UpdateArgs: func(data interface{}, fields map[string]string) []string {
-		t := data.(cli.Token)
-		return []string{"--id", fmt.Sprintf("%d", t.ID), "--user", fields["User ID"], "--token", fields["Token"]}
-	},
 	NewFields: func() []ui.EditorField {
 		return []ui.EditorField{
-			{Label: "User ID", Placeholder: "User ID", Required: true},
-			{Label: "Token", Placeholder: "Token value (leave blank to generate)", Value: ""},
+			{Label: "User ID", Placeholder: "User ID", Required: true, Kind: ui.IntField},
+			{Label: "Token", Placeholder: "Token value (leave blank to generate)", Kind: ui.SecretField},
 		}
 	},
 	CreateArgs: func(fields map[string]string) []string {
@@ -59,8 +56,9 @@ var TokenDef = &EntityDef{
 		}
 		return args
 	},
+	Record:   func() interface{} { return &cli.Token{} },
 	GetID:    func(data interface{}) int { return data.(cli.Token).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("Token %d", data.(cli.Token).ID) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("Token %d", data.(cli.Token).ID) },
 	Actions: []ui.ActionDef{
 		{Label: "Edit", Key: "e", Command: "edit"},
 		{
@@ -72,9 +70,9 @@ var TokenDef = &EntityDef{
 				return func() tea.Msg {
 					output, err := c.RunRaw("token", "generate", "--format=json",
 						"--user", t.User)
-					viewer := ui.NewViewer(fmt.Sprintf("Generate Token for User %s", t.User))
+					viewer := ui.NewViewer(i18n.Tf("Generate Token for User %s", t.User))
 					if err != nil {
-						viewer.SetContent("Generate Token", fmt.Sprintf("Error: %v\n\n%s", err, string(output)))
+						viewer.SetContent("Generate Token", i18n.Tf("Error: %v\n\n%s", err, string(output)))
 					} else {
 						viewer.SetContent("Generated Token", string(output))
 					}
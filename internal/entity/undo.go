@@ -0,0 +1,330 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// undoLimit is how many changes the journal keeps.
+const undoLimit = 100
+
+// Operations recorded in the journal.
+const (
+	undoDelete = "delete"
+	undoUpdate = "update"
+)
+
+// Journal records the records deleted and updated in this session, as they
+// were before, so the changes can be undone.
+var Journal = &UndoJournal{}
+
+// UndoEntry is one change in the journal.
+type UndoEntry struct {
+	Seq     int
+	At      time.Time
+	Def     *EntityDef
+	Op      string      // undoDelete or undoUpdate
+	Before  interface{} // FullData before the change
+	Changes []ui.Change // the fields an update changed
+	Undone  bool
+
+	undoing bool // an undo is running
+}
+
+// label names the changed record.
+func (e UndoEntry) label() string {
+	if e.Def.GetLabel != nil {
+		return e.Def.GetLabel(e.Before)
+	}
+	return fmt.Sprintf("%s %d", i18n.T(e.Def.Name), e.Def.GetID(e.Before))
+}
+
+// summary describes the change in a few words.
+func (e UndoEntry) summary() string {
+	if e.Op == undoDelete {
+		return i18n.Tf("deleted %s", e.label())
+	}
+	return i18n.Tf("updated %s", e.label())
+}
+
+// UndoJournal is safe for use by the commands that record changes.
+type UndoJournal struct {
+	mu      sync.Mutex
+	entries []*UndoEntry
+	seq     int
+}
+
+// Record adds a change made to the record before.
+func (j *UndoJournal) Record(def *EntityDef, op string, before interface{}, changes []ui.Change) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.seq++
+	j.entries = append(j.entries, &UndoEntry{Seq: j.seq, At: now(), Def: def, Op: op, Before: before, Changes: changes})
+	if len(j.entries) > undoLimit {
+		j.entries = j.entries[len(j.entries)-undoLimit:]
+	}
+}
+
+// Entries returns copies of the entries, newest first.
+func (j *UndoJournal) Entries() []UndoEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entries := make([]UndoEntry, 0, len(j.entries))
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		entries = append(entries, *j.entries[i])
+	}
+	return entries
+}
+
+// last returns the newest entry not undone yet, or nil.
+func (j *UndoJournal) last() *UndoEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		if !j.entries[i].Undone {
+			return j.entries[i]
+		}
+	}
+	return nil
+}
+
+// find returns the entry with the given sequence number, or nil.
+func (j *UndoJournal) find(seq int) *UndoEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, e := range j.entries {
+		if e.Seq == seq {
+			return e
+		}
+	}
+	return nil
+}
+
+// Undo asks to undo the newest change not undone yet; ctrl+z runs it. The
+// outcome ends up in the footer and the view in front is refreshed.
+func (j *UndoJournal) Undo(c cli.Client) tea.Cmd {
+	e := j.last()
+	if e == nil {
+		return func() tea.Msg { return ui.StatusMsg{Text: i18n.T("Nothing to undo")} }
+	}
+	label := i18n.Tf("Undo: %s?", e.summary())
+	return func() tea.Msg {
+		return ui.ConfirmMsg{Label: label, Action: func() tea.Msg {
+			status, err := j.undo(c, e)
+			if err != nil {
+				return ui.StatusMsg{Text: i18n.Tf("Undo failed: %v", err)}
+			}
+			return ui.RefreshCurrentMsg{Status: status}
+		}}
+	}
+}
+
+// undo reverts e through the CLI and marks it undone. The status names what
+// could not be restored, such as the ID of a re-created record or secrets
+// the journal never saw.
+func (j *UndoJournal) undo(c cli.Client, e *UndoEntry) (string, error) {
+	// Claimed under the lock, so a second undo of e is refused while the
+	// first one runs.
+	j.mu.Lock()
+	switch {
+	case e.Undone:
+		j.mu.Unlock()
+		return "", errors.New(i18n.Tf("%s was undone already", e.summary()))
+	case e.undoing:
+		j.mu.Unlock()
+		return "", errors.New(i18n.Tf("%s is being undone", e.summary()))
+	}
+	e.undoing = true
+	j.mu.Unlock()
+
+	var lost []string
+	var err error
+	switch e.Op {
+	case undoUpdate:
+		lost, err = undoUpdateEntry(c, e)
+	case undoDelete:
+		lost, err = undoDeleteEntry(c, e)
+	}
+	j.mu.Lock()
+	e.undoing = false
+	e.Undone = err == nil
+	j.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	status := i18n.Tf("Restored %s", e.label())
+	if len(lost) > 0 {
+		status += "; " + i18n.Tf("not restored: %s", strings.Join(lost, ", "))
+	}
+	return status, nil
+}
+
+// undoUpdateEntry updates the record back to its old values. Secrets the
+// editor did not show are lost.
+func undoUpdateEntry(c cli.Client, e *UndoEntry) ([]string, error) {
+	var back []ui.Change
+	var lost []string
+	for _, ch := range e.Changes {
+		if ch.Secret && ch.Old == "" {
+			lost = append(lost, i18n.T(ch.Label))
+			continue
+		}
+		back = append(back, ui.Change{Label: ch.Label, Old: ch.New, New: ch.Old, Secret: ch.Secret})
+	}
+	if len(back) == 0 {
+		return nil, errors.New(i18n.Tf("none of the changes to %s can be restored", e.label()))
+	}
+	return lost, c.Update(e.Def.CLIEntity, e.Def.UpdateArgs(e.Before, back)...)
+}
+
+// undoDeleteEntry creates the record again from the fields of a new record
+// it had: those Duplicate copies and those its editor shows. The record
+// gets a new ID; required fields the record lacks, such as passwords, make
+// it impossible to re-create.
+func undoDeleteEntry(c cli.Client, e *UndoEntry) ([]string, error) {
+	def := e.Def
+	if def.NewFields == nil || def.CreateArgs == nil {
+		return nil, errors.New(i18n.Tf("%s cannot be created again", e.label()))
+	}
+	values := map[string]string{}
+	if def.ToEditor != nil {
+		for _, f := range def.ToEditor(e.Before) {
+			values[f.Label] = f.Value
+		}
+	}
+	if def.Duplicate != nil {
+		for label, v := range def.Duplicate(e.Before) {
+			values[label] = v
+		}
+	}
+	var missing, lost []string
+	fields := map[string]string{}
+	for _, f := range def.NewFields() {
+		v, ok := values[f.Label]
+		switch {
+		case ok:
+			fields[f.Label] = v
+		case f.Required:
+			missing = append(missing, i18n.T(f.Label))
+		case f.Kind == ui.SecretField:
+			lost = append(lost, i18n.T(f.Label))
+		default:
+			fields[f.Label] = f.Value
+		}
+	}
+	if len(missing) > 0 {
+		return nil, errors.New(i18n.Tf("%s cannot be created again without %s", e.label(), strings.Join(missing, ", ")))
+	}
+
+	out, err := c.Create(def.CLIEntity, def.CreateArgs(fields)...)
+	if err != nil {
+		return nil, err
+	}
+	oldID := def.GetID(e.Before)
+	created := def.Created(c, out)
+	switch {
+	case created == nil:
+		lost = append(lost, i18n.Tf("ID %d", oldID))
+	case def.GetID(created) != oldID:
+		lost = append(lost, i18n.Tf("ID %d (now %d)", oldID, def.GetID(created)))
+	}
+	return lost, nil
+}
+
+// UndoDef lists the Journal, newest first; a row opens the change with an
+// Undo action. Like AlertLogDef it is not registered; main adds its menu
+// item.
+var UndoDef = &EntityDef{
+	Name: "↶ Undo History", CLIEntity: "undo", Limit: 10,
+	Columns: []ui.TableColumn{
+		{Header: "Time", Width: 19, Field: "time"},
+		{Header: "Change", Width: 10, Field: "op"},
+		{Header: "Record", Width: 30, Field: "record", Flex: 2},
+		{Header: "State", Width: 10, Field: "state"},
+	},
+	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
+		entries := Journal.Entries()
+		if offset >= len(entries) {
+			return nil, nil
+		}
+		entries = entries[offset:]
+		if len(entries) > limit {
+			entries = entries[:limit]
+		}
+		rows := make([]ui.TableRow, len(entries))
+		for i := range entries {
+			e := entries[i]
+			rows[i] = ui.TableRow{ID: e.Seq, FullData: e, Values: map[string]string{
+				"time": e.At.Format("2006-01-02 15:04:05"), "op": undoOpLabel(e.Op),
+				"record": e.label(), "state": undoStateLabel(e.Undone),
+			}}
+		}
+		return rows, nil
+	},
+	ToDetail: func(data interface{}) []ui.DetailField {
+		e := data.(UndoEntry)
+		fields := []ui.DetailField{
+			{Label: "Time", Value: e.At.Format("2006-01-02 15:04:05")},
+			{Label: "Change", Value: undoOpLabel(e.Op)},
+			{Label: "Record", Value: e.label()},
+			{Label: "State", Value: undoStateLabel(e.Undone)},
+		}
+		for _, ch := range e.Changes {
+			v := i18n.T("(changed)")
+			if !ch.Secret {
+				v = previewValue(ch.Old, previewWidth) + " → " + previewValue(ch.New, previewWidth)
+			}
+			fields = append(fields, ui.DetailField{Label: ch.Label, Value: v})
+		}
+		if e.Op == undoDelete && e.Def.ToDetail != nil {
+			fields = append(fields, e.Def.ToDetail(e.Before)...)
+		}
+		return fields
+	},
+	GetID:    func(data interface{}) int { return data.(UndoEntry).Seq },
+	GetLabel: func(data interface{}) string { return i18n.Tf("Undo: %s", data.(UndoEntry).summary()) },
+	Actions: []ui.ActionDef{
+		{
+			Label:   "Undo",
+			Key:     "u",
+			Command: "undo",
+			Confirm: "Undo this change?",
+			Handler: func(c cli.Client, data interface{}) tea.Cmd {
+				seq := data.(UndoEntry).Seq
+				return func() tea.Msg {
+					e := Journal.find(seq)
+					if e == nil {
+						return ui.StatusMsg{Text: i18n.T("The change is no longer in the journal")}
+					}
+					status, err := Journal.undo(c, e)
+					if err != nil {
+						return ui.StatusMsg{Text: i18n.Tf("Undo failed: %v", err)}
+					}
+					return ui.NavigateBackAndRefreshMsg{Status: status}
+				}
+			},
+		},
+	},
+}
+
+func undoOpLabel(op string) string {
+	if op == undoDelete {
+		return i18n.T("Deleted")
+	}
+	return i18n.T("Updated")
+}
+
+func undoStateLabel(undone bool) string {
+	if undone {
+		return i18n.T("undone")
+	}
+	return ""
+}
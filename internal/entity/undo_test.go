@@ -0,0 +1,180 @@
+package entity
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// useJournal gives the test an empty journal.
+func useJournal(t *testing.T) {
+	saved := Journal
+	Journal = &UndoJournal{}
+	t.Cleanup(func() { Journal = saved })
+}
+
+// journalClient records the creates and updates it runs; a create answers
+// with createdJSON.
+type journalClient struct {
+	updateClient
+	createdJSON string
+	created     [][]string
+}
+
+func (c *journalClient) Create(entity string, args ...string) ([]byte, error) {
+	c.created = append(c.created, args)
+	return []byte(c.createdJSON), nil
+}
+
+// confirmed runs the action of the ConfirmMsg cmd returns.
+func confirmed(t *testing.T, cmd tea.Cmd) tea.Msg {
+	t.Helper()
+	msgs := cmdMsgs(cmd)
+	confirm, ok := msgs[0].(ui.ConfirmMsg)
+	if len(msgs) != 1 || !ok {
+		t.Fatalf("got %v, want a confirmation", msgs)
+	}
+	return confirm.Action()
+}
+
+func TestUndoUpdate(t *testing.T) {
+	useJournal(t)
+	src := cli.EventSource{ID: 3, Name: "Shop", DbPassword: "secret", Enabled: 1}
+	stored, _ := json.Marshal(src)
+	c := &journalClient{updateClient: updateClient{fakeClient: fakeClient{getJSON: string(stored)}}}
+
+	if msgs := cmdMsgs(Journal.Undo(c)); msgs[0] != (ui.StatusMsg{Text: "Nothing to undo"}) {
+		t.Errorf("empty journal: %v", msgs)
+	}
+
+	ev := NewEditorView(c, EventSourceDef, src, false)
+	ev.form.SetValue("Name", "Eshop")
+	ev.form.SetValue("DB Password", "hunter2")
+	saveEditor(t, ev)
+	if entries := Journal.Entries(); len(entries) != 1 || entries[0].Op != undoUpdate {
+		t.Fatalf("journal = %+v", entries)
+	}
+
+	msg := confirmed(t, Journal.Undo(c))
+	if refresh, ok := msg.(ui.RefreshCurrentMsg); !ok || !strings.Contains(refresh.Status, "Restored") {
+		t.Errorf("undo = %v", msg)
+	}
+	want := []string{"--id", "3", "--name", "Shop", "--db_password", "secret"}
+	if len(c.updated) != 2 || !reflect.DeepEqual(c.updated[1], want) {
+		t.Errorf("updated %v, want %v last", c.updated, want)
+	}
+	if !Journal.Entries()[0].Undone || Journal.last() != nil {
+		t.Error("the change should be marked undone")
+	}
+}
+
+func TestUndoUpdateLosesUnknownSecrets(t *testing.T) {
+	useJournal(t)
+	c := &journalClient{}
+	Journal.Record(EventSourceDef, undoUpdate, cli.EventSource{ID: 3}, []ui.Change{{Label: "DB Password", New: "x", Secret: true}})
+	if msg := confirmed(t, Journal.Undo(c)); !strings.Contains(msg.(ui.StatusMsg).Text, "Undo failed") {
+		t.Errorf("undo = %v", msg)
+	}
+	Journal.Record(EventSourceDef, undoUpdate, cli.EventSource{ID: 3}, []ui.Change{
+		{Label: "Name", Old: "Shop", New: "Eshop"},
+		{Label: "DB Password", New: "x", Secret: true},
+	})
+	msg := confirmed(t, Journal.Undo(c)).(ui.RefreshCurrentMsg)
+	if !strings.Contains(msg.Status, "not restored: DB Password") {
+		t.Errorf("status = %q", msg.Status)
+	}
+}
+
+func TestUndoDelete(t *testing.T) {
+	useJournal(t)
+	cr := cli.Credential{ID: 5, Name: "Bank", CompanyID: 2, CredentialTypeID: 4}
+	c := &journalClient{
+		updateClient: updateClient{fakeClient: fakeClient{getJSON: `{"id":15,"name":"Bank","company_id":2,"credential_type_id":4}`}},
+		createdJSON:  `{"id":15}`,
+	}
+	dv := NewDetailView(c, CredentialDef, cr)
+	_, cmd := dv.executeActionByCommand("delete")
+	confirmed(t, cmd)
+
+	rows, _ := UndoDef.Fetch(c, 10, 0)
+	if len(rows) != 1 || rows[0].Values["op"] != "Deleted" || rows[0].Values["record"] != "Credential: Bank" {
+		t.Fatalf("history = %+v", rows)
+	}
+	undo := UndoDef.Actions[0]
+	msg := undo.Handler(c, rows[0].FullData)()
+	back, ok := msg.(ui.NavigateBackAndRefreshMsg)
+	if !ok || !strings.Contains(back.Status, "ID 5 (now 15)") {
+		t.Errorf("undo = %v", msg)
+	}
+	want := [][]string{CredentialDef.CreateArgs(map[string]string{"Name": "Bank", "Company ID": "2", "CredType ID": "4"})}
+	if !reflect.DeepEqual(c.created, want) {
+		t.Errorf("created %v, want %v", c.created, want)
+	}
+	if msg := undo.Handler(c, rows[0].FullData)(); !strings.Contains(msg.(ui.StatusMsg).Text, "undone already") {
+		t.Errorf("second undo = %v", msg)
+	}
+}
+
+// blockingClient holds its creates until release is closed.
+type blockingClient struct {
+	journalClient
+	mu      sync.Mutex
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingClient) Create(entity string, args ...string) ([]byte, error) {
+	c.started <- struct{}{}
+	<-c.release
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.journalClient.Create(entity, args...)
+}
+
+func TestUndoRunsOnce(t *testing.T) {
+	useJournal(t)
+	c := &blockingClient{
+		journalClient: journalClient{createdJSON: `{"id":15}`},
+		started:       make(chan struct{}, 2),
+		release:       make(chan struct{}),
+	}
+	Journal.Record(CredentialDef, undoDelete, cli.Credential{ID: 5, Name: "Bank", CompanyID: 2, CredentialTypeID: 4}, nil)
+	e := Journal.last()
+
+	first := make(chan error)
+	go func() {
+		_, err := Journal.undo(c, e)
+		first <- err
+	}()
+	<-c.started
+	if _, err := Journal.undo(c, e); err == nil || !strings.Contains(err.Error(), "being undone") {
+		t.Errorf("a second undo while the first runs = %v, want it refused", err)
+	}
+	close(c.release)
+	if err := <-first; err != nil {
+		t.Fatal(err)
+	}
+	if len(c.created) != 1 {
+		t.Errorf("created %d records, want 1", len(c.created))
+	}
+}
+
+func TestUndoDeleteNeedsRequiredFields(t *testing.T) {
+	useJournal(t)
+	c := &journalClient{}
+	Journal.Record(UserDef, undoDelete, cli.User{ID: 4, Login: "jan", Email: "jan@example.com"}, nil)
+	msg := confirmed(t, Journal.Undo(c)).(ui.StatusMsg)
+	if !strings.Contains(msg.Text, "without Password") || len(c.created) != 0 {
+		t.Errorf("undo = %q, created %v", msg.Text, c.created)
+	}
+	// A failed undo can be tried again.
+	if msg := confirmed(t, Journal.Undo(c)).(ui.StatusMsg); !strings.Contains(msg.Text, "without Password") {
+		t.Errorf("second undo = %q", msg.Text)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	"github.com/VitexSoftware/multiflexi-tui/internal/ui"
 )
 
@@ -11,7 +12,7 @@ var UserDef = &EntityDef{
 	Name: "👤 Users", CLIEntity: "user", DeleteAction: "delete", Limit: 10,
 	Columns: []ui.TableColumn{
 		{Header: "ID", Width: 6, Field: "id"}, {Header: "Login", Width: 20, Field: "login"},
-		{Header: "Name", Width: 25, Field: "name"}, {Header: "Email", Width: 30, Field: "email"},
+		{Header: "Name", Width: 25, Field: "name", Flex: 2}, {Header: "Email", Width: 30, Field: "email", Flex: 1},
 		{Header: "Active", Width: 7, Field: "enabled"},
 	},
 	Fetch: func(c cli.Client, limit, offset int) ([]ui.TableRow, error) {
@@ -59,29 +60,18 @@ var UserDef = &EntityDef{
 	ToEditor: func(data interface{}) []ui.EditorField {
 		u := data.(cli.User)
 		return []ui.EditorField{
-			{Label: "Login", Placeholder: "username", Value: u.Login},
-			{Label: "First Name", Placeholder: "First name", Value: u.Firstname},
-			{Label: "Last Name", Placeholder: "Last name", Value: u.Lastname},
-			{Label: "Email", Placeholder: "email@example.com", Value: u.Email},
-			{Label: "Enabled", Placeholder: "1 or 0", Value: fmt.Sprintf("%d", u.Enabled)},
-		}
-	},
-	UpdateArgs: func(data interface{}, fields map[string]string) []string {
-		u := data.(cli.User)
-		return []string{
-			"--id", fmt.Sprintf("%d", u.ID),
-			"--login", fields["Login"],
-			"--firstname", fields["First Name"],
-			"--lastname", fields["Last Name"],
-			"--email", fields["Email"],
-			"--enabled", fields["Enabled"],
+			{Label: "Login", Flag: "--login", Placeholder: "username", Value: u.Login, Required: true},
+			{Label: "First Name", Flag: "--firstname", Placeholder: "First name", Value: u.Firstname},
+			{Label: "Last Name", Flag: "--lastname", Placeholder: "Last name", Value: u.Lastname},
+			{Label: "Email", Flag: "--email", Placeholder: "email@example.com", Value: u.Email, Validators: []ui.Validator{ui.IsEmail}},
+			{Label: "Enabled", Flag: "--enabled", Value: fmt.Sprintf("%d", u.Enabled), Kind: ui.ToggleField},
 		}
 	},
 	NewFields: func() []ui.EditorField {
 		return []ui.EditorField{
 			{Label: "Login", Placeholder: "username", Required: true},
-			{Label: "Email", Placeholder: "email@example.com", Required: true},
-			{Label: "Password", Placeholder: "plaintext password", Required: true},
+			{Label: "Email", Placeholder: "email@example.com", Required: true, Validators: []ui.Validator{ui.IsEmail}},
+			{Label: "Password", Placeholder: "plaintext password", Required: true, Kind: ui.SecretField},
 			{Label: "First Name", Placeholder: "First name"},
 			{Label: "Last Name", Placeholder: "Last name"},
 		}
@@ -100,10 +90,19 @@ var UserDef = &EntityDef{
 		}
 		return args
 	},
+	Duplicate: func(data interface{}) map[string]string {
+		u := data.(cli.User)
+		return map[string]string{
+			"First Name": u.Firstname,
+			"Last Name":  u.Lastname,
+		}
+	},
+	Record:   func() interface{} { return &cli.User{} },
 	GetID:    func(data interface{}) int { return data.(cli.User).ID },
-	GetLabel: func(data interface{}) string { return fmt.Sprintf("User: %s", data.(cli.User).Login) },
+	GetLabel: func(data interface{}) string { return i18n.Tf("User: %s", data.(cli.User).Login) },
 	Actions: []ui.ActionDef{
 		{Label: "Edit", Key: "e", Command: "edit"},
+		{Label: "Duplicate", Key: "D", Command: "duplicate"},
 		{Label: "Delete", Key: "d", Command: "delete"},
 	},
 }
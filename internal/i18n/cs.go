@@ -0,0 +1,512 @@
+package i18n
+
+func init() {
+	Register("cs", &Catalog{
+		Messages: csMessages,
+		Plurals: map[string][]string{
+			"commands":             {"%d příkaz", "%d příkazy", "%d příkazů"},
+			"items":                {"%d položka", "%d položky", "%d položek"},
+			"jobs":                 {"%d úloha", "%d úlohy", "%d úloh"},
+			"saved parameter sets": {"%d uložená sada parametrů", "%d uložené sady parametrů", "%d uložených sad parametrů"},
+		},
+		PluralForm: func(n int) int {
+			switch {
+			case n == 1:
+				return 0
+			case n >= 2 && n <= 4:
+				return 1
+			}
+			return 2
+		},
+	})
+}
+
+var csMessages = map[string]string{
+	// Application chrome
+	"Status": "Stav",
+	"View system dashboard with status information": "Přehled systému a jeho stavu",
+	"Theme":                       "Motiv",
+	"Switch the colour theme":     "Přepnout barevný motiv",
+	"Help":                        "Nápověda",
+	"View help and documentation": "Nápověda a dokumentace",
+	"Quit":                        "Konec",
+	"Exit the application":        "Ukončit aplikaci",
+	"Initializing...":             "Inicializace...",
+	"Loading...":                  "Načítání...",
+	"Loading system status...":    "Načítání stavu systému...",
+	"MultiFlexi System Dashboard": "Přehled systému MultiFlexi",
+	"waiting for confirmation":    "čeká na potvrzení",
+	"At most %d tabs can be open": "Otevřít lze nejvýše %d karet",
+	"[tab %d] ":                   "[karta %d] ",
+	"←/→: navigate • enter: select • tab: content":                                             "←/→: pohyb • enter: vybrat • tab: obsah",
+	"←/→: navigate menu • enter: select • tab: content • alt+t/alt+w: new/close tab • q: quit": "←/→: pohyb v menu • enter: vybrat • tab: obsah • alt+t/alt+w: nová/zavřít kartu • q: konec",
+	"↑/↓: rows • ←/→: pages • enter: detail • e: edit • n: new • esc: back • tab: menu":        "↑/↓: řádky • ←/→: stránky • enter: detail • e: upravit • n: nový • esc: zpět • tab: menu",
+
+	// Dashboard
+	"CLI Version":      "Verze CLI",
+	"DB Migration":     "Migrace DB",
+	"User":             "Uživatel",
+	"PHP":              "PHP",
+	"OS":               "OS",
+	"Memory":           "Paměť",
+	"Companies":        "Firmy",
+	"Applications":     "Aplikace",
+	"RunTemplates":     "Šablony spuštění",
+	"Topics":           "Témata",
+	"Credentials":      "Přístupové údaje",
+	"Credential Types": "Typy přístupových údajů",
+	"Jobs":             "Úlohy",
+	"Executor":         "Vykonavatel",
+	"Scheduler":        "Plánovač",
+	"Encryption":       "Šifrování",
+	"Zabbix":           "Zabbix",
+	"Telemetry":        "Telemetrie",
+	"Database":         "Databáze",
+	"Timestamp":        "Časové razítko",
+
+	// Widgets
+	"(no items)":                     "(žádné položky)",
+	"(no selection)":                 "(nic není vybráno)",
+	"(in use)":                       "(používá se)",
+	"pg%d":                           "str%d",
+	"Confirm":                        "Potvrzení",
+	"[Y] Yes":                        "[Y] Ano",
+	"[N] No":                         "[N] Ne",
+	"esc: back":                      "esc: zpět",
+	"Button":                         "Tlačítko",
+	"active":                         "aktivní",
+	"inactive":                       "neaktivní",
+	"error":                          "chyba",
+	"description":                    "popis",
+	"Theme: %s":                      "Motiv: %s",
+	"ESC/q: Back":                    "ESC/q: Zpět",
+	" ↑/↓: scroll  esc: back":        " ↑/↓: posun  esc: zpět",
+	" ↑/↓/PgUp/PgDn: scroll [%3d%%]": " ↑/↓/PgUp/PgDn: posun [%3d%%]",
+	" ↑/↓/PgUp/PgDn: scroll  g/G: top/end  esc: back  [%3d%%]":                                          " ↑/↓/PgUp/PgDn: posun  g/G: začátek/konec  esc: zpět  [%3d%%]",
+	"↑/↓: preview • enter: apply • esc: cancel":                                                         "↑/↓: náhled • enter: použít • esc: zrušit",
+	"r: refresh • enter: detail • e: edit • n: new • D: duplicate • /: filter • o/O: sort • c: columns": "r: obnovit • enter: detail • e: upravit • n: nový • D: duplikovat • /: filtr • o/O: řazení • c: sloupce",
+	"Filter: %s": "Filtr: %s",
+	"type to filter • enter: keep • esc: clear":                                "pište pro filtrování • enter: ponechat • esc: zrušit",
+	"↑/↓: column • space: show/hide • ←/→: width • 0: auto width • esc: close": "↑/↓: sloupec • mezerník: zobrazit/skrýt • ←/→: šířka • 0: automatická šířka • esc: zavřít",
+	"%d of %s":              "%d z %s",
+	"Cannot open %s: %v":    "Nelze otevřít %s: %v",
+	"Invalid link: %v":      "Neplatný odkaz: %v",
+	"Unknown menu item: %s": "Neznámá položka menu: %s",
+	"tab/↑↓: fields • enter: save • ctrl+e: editor • esc: cancel":    "tab/↑↓: pole • enter: uložit • ctrl+e: editor • esc: zrušit",
+	"tab/↑↓: fields • enter: confirm • ctrl+e: editor • esc: cancel": "tab/↑↓: pole • enter: potvrdit • ctrl+e: editor • esc: zrušit",
+
+	// Generic entity views
+	"New %s":                      "Nový záznam: %s",
+	"Edit %s":                     "Úprava: %s",
+	"Saved %s":                    "Uloženo: %s",
+	"Error saving %s: %v":         "Chyba při ukládání %s: %v",
+	"Delete %s?":                  "Smazat %s?",
+	"Deleted %s":                  "Smazáno: %s",
+	"Error deleting %s: %v":       "Chyba při mazání %s: %v",
+	"Action '%s' not implemented": "Akce '%s' není implementována",
+	"Error: %v":                   "Chyba: %v",
+	"Error: %v\n\n%s":             "Chyba: %v\n\n%s",
+	"Edit":                        "Upravit",
+	"Delete":                      "Smazat",
+
+	// Entity names and menu hints
+	"📦 Applications":                             "📦 Aplikace",
+	"🏢 Companies":                                "🏢 Firmy",
+	"📋 Run Templates":                            "📋 Šablony spuštění",
+	"💼 Jobs":                                     "💼 Úlohy",
+	"📬 Queue":                                    "📬 Fronta",
+	"🔑 Credentials":                              "🔑 Přístupové údaje",
+	"🏷️ Credential Types":                        "🏷️ Typy přístupových údajů",
+	"🧬 Credential Prototypes":                    "🧬 Prototypy přístupových údajů",
+	"🔗 Company-App Relations":                    "🔗 Vazby firma–aplikace",
+	"📎 Artifacts":                                "📎 Artefakty",
+	"👤 Users":                                    "👤 Uživatelé",
+	"🎟️ Tokens":                                  "🎟️ Tokeny",
+	"📡 Event Sources":                            "📡 Zdroje událostí",
+	"📌 Event Rules":                              "📌 Pravidla událostí",
+	"Browse applications":                        "Procházet aplikace",
+	"View and manage companies":                  "Zobrazit a spravovat firmy",
+	"View and manage run templates":              "Zobrazit a spravovat šablony spuštění",
+	"View and manage jobs":                       "Zobrazit a spravovat úlohy",
+	"View job queue • f: fix • T: truncate":      "Fronta úloh • f: opravit • T: vyprázdnit",
+	"Manage credentials":                         "Správa přístupových údajů",
+	"Manage credential types":                    "Správa typů přístupových údajů",
+	"Manage credential prototypes • s: sync all": "Správa prototypů přístupových údajů • s: synchronizovat vše",
+	"Manage company-app assignments • a: assign • u: unassign": "Správa přiřazení aplikací k firmám • a: přiřadit • u: odebrat",
+	"View artifacts • s: save to file":                         "Zobrazit artefakty • s: uložit do souboru",
+	"Manage users":                                             "Správa uživatelů",
+	"Manage API tokens":                                        "Správa API tokenů",
+	"Manage event sources":                                     "Správa zdrojů událostí",
+	"Manage event rules":                                       "Správa pravidel událostí",
+
+	// Short entity labels
+	"CompanyApps":  "Firma–aplikace",
+	"CrPrototypes": "Prototypy",
+	"CredTypes":    "Typy údajů",
+	"EventRules":   "Pravidla událostí",
+	"EventSources": "Zdroje událostí",
+	"Artifacts":    "Artefakty",
+	"Queue":        "Fronta",
+	"Tokens":       "Tokeny",
+	"Users":        "Uživatelé",
+
+	// Record labels
+	"App: %s":         "Aplikace: %s",
+	"Company: %s":     "Firma: %s",
+	"RunTemplate: %s": "Šablona spuštění: %s",
+	"Job %d":          "Úloha %d",
+	"Queue %d":        "Fronta %d",
+	"Credential: %s":  "Přístupový údaj: %s",
+	"CredType: %s":    "Typ údajů: %s",
+	"CrPrototype: %s": "Prototyp: %s",
+	"CompanyApp %d":   "Firma–aplikace %d",
+	"Artifact: %s":    "Artefakt: %s",
+	"User: %s":        "Uživatel: %s",
+	"Token %d":        "Token %d",
+	"EventSource: %s": "Zdroj událostí: %s",
+	"EventRule %d":    "Pravidlo události %d",
+
+	// Fields
+	"ID":                           "ID",
+	"Name":                         "Název",
+	"Version":                      "Verze",
+	"UUID":                         "UUID",
+	"Description":                  "Popis",
+	"Executable":                   "Spustitelný soubor",
+	"Enabled":                      "Povoleno",
+	"Homepage":                     "Domovská stránka",
+	"Homepage URL":                 "URL domovské stránky",
+	"Application name":             "Název aplikace",
+	"Executable path":              "Cesta ke spustitelnému souboru",
+	"Config":                       "Konfigurace",
+	"Config: %s":                   "Konfigurace: %s",
+	"IC":                           "IČ",
+	"Email":                        "E-mail",
+	"Slug":                         "Zkratka",
+	"Server":                       "Server",
+	"Created":                      "Vytvořeno",
+	"Updated":                      "Aktualizováno",
+	"Created At":                   "Vytvořeno",
+	"Updated At":                   "Aktualizováno",
+	"Company name":                 "Název firmy",
+	"Company":                      "Firma",
+	"Company ID":                   "ID firmy",
+	"App":                          "Aplikace",
+	"App ID":                       "ID aplikace",
+	"Application ID":               "ID aplikace",
+	"Active":                       "Aktivní",
+	"Interval":                     "Interval",
+	"Cron":                         "Cron",
+	"Last Schedule":                "Poslední plánování",
+	"Next Schedule":                "Příští plánování",
+	"Success Jobs":                 "Úspěšné úlohy",
+	"Failed Jobs":                  "Neúspěšné úlohy",
+	"Template name":                "Název šablony",
+	"d/w/m/n/y":                    "d/w/m/n/y",
+	"Schedule":                     "Naplánovat",
+	"Schedule: %s":                 "Naplánovat: %s",
+	"Schedule Time":                "Čas spuštění",
+	"Schedule failed: %v":          "Naplánování selhalo: %v",
+	"YYYY-MM-DD HH:MM:SS":          "RRRR-MM-DD HH:MM:SS",
+	"Command":                      "Příkaz",
+	"PID":                          "PID",
+	"Exit Code":                    "Návratový kód",
+	"Schedule Type":                "Typ plánování",
+	"Schedule type":                "Typ plánování",
+	"Begin":                        "Začátek",
+	"End":                          "Konec",
+	"RunTemplate":                  "Šablona spuštění",
+	"RunTemplate ID":               "ID šablony spuštění",
+	"Scheduled":                    "Naplánováno",
+	"YYYY-MM-DD HH:MM:SS or 'now'": "RRRR-MM-DD HH:MM:SS nebo 'now'",
+	"Native":                       "Native",
+	"adhoc":                        "adhoc",
+	"Stdout":                       "Stdout",
+	"Stderr":                       "Stderr",
+	"Job %d — Stdout":              "Úloha %d — Stdout",
+	"Job %d — Stderr":              "Úloha %d — Stderr",
+	"Job":                          "Úloha",
+	"Job ID":                       "ID úlohy",
+	"After":                        "Po",
+	"Fix":                          "Opravit",
+	"Run queue fix? This repairs stuck queue entries.": "Opravit frontu? Opraví zaseknuté položky fronty.",
+	"Queue fix failed: %v":                             "Oprava fronty selhala: %v",
+	"Queue Fix Result":                                 "Výsledek opravy fronty",
+	"Truncate":                                         "Vyprázdnit",
+	"TRUNCATE entire queue? All pending jobs will be removed!": "VYPRÁZDNIT celou frontu? Všechny čekající úlohy budou odstraněny!",
+	"Queue truncate failed: %v":                                "Vyprázdnění fronty selhalo: %v",
+	"Queue truncated":                                          "Fronta vyprázdněna",
+	"Type":                                                     "Typ",
+	"Credential Type ID":                                       "ID typu přístupových údajů",
+	"CredType ID":                                              "ID typu údajů",
+	"Credential name":                                          "Název přístupového údaje",
+	"Class":                                                    "Třída",
+	"URL":                                                      "URL",
+	"Credential type name":                                     "Název typu přístupových údajů",
+	"PHP class name":                                           "Název PHP třídy",
+	"Code":                                                     "Kód",
+	"Prototype name":                                           "Název prototypu",
+	"Prototype code":                                           "Kód prototypu",
+	"Sync All":                                                 "Synchronizovat vše",
+	"Sync all credential prototypes from remote?": "Synchronizovat všechny prototypy přístupových údajů ze vzdáleného zdroje?",
+	"Sync failed: %v":                       "Synchronizace selhala: %v",
+	"CrPrototype Sync Result":               "Výsledek synchronizace prototypů",
+	"Assign":                                "Přiřadit",
+	"Assign Application to Company":         "Přiřadit aplikaci firmě",
+	"Assign Result":                         "Výsledek přiřazení",
+	"Unassign":                              "Odebrat",
+	"Unassign Application from Company":     "Odebrat aplikaci firmě",
+	"Unassign Result":                       "Výsledek odebrání",
+	"Filename":                              "Název souboru",
+	"Content Type":                          "Typ obsahu",
+	"Note":                                  "Poznámka",
+	"Save to file":                          "Uložit do souboru",
+	"Save Artifact: %s":                     "Uložit artefakt: %s",
+	"File Path":                             "Cesta k souboru",
+	"/path/to/save":                         "/cesta/k/souboru",
+	"Save failed: %v":                       "Uložení selhalo: %v",
+	"Saved to %s":                           "Uloženo do %s",
+	"Login":                                 "Přihlašovací jméno",
+	"First Name":                            "Jméno",
+	"Last Name":                             "Příjmení",
+	"First name":                            "Jméno",
+	"Last name":                             "Příjmení",
+	"2FA Enabled":                           "2FA zapnuto",
+	"Last Login IP":                         "IP posledního přihlášení",
+	"Last Login At":                         "Poslední přihlášení",
+	"Failed Logins":                         "Neúspěšná přihlášení",
+	"email@example.com":                     "email@example.com",
+	"Password":                              "Heslo",
+	"plaintext password":                    "heslo v čitelné podobě",
+	"Token":                                 "Token",
+	"User ID":                               "ID uživatele",
+	"Token value":                           "Hodnota tokenu",
+	"Token value (leave blank to generate)": "Hodnota tokenu (prázdné = vygenerovat)",
+	"Generate":                              "Vygenerovat",
+	"Generate Token for User %s":            "Vygenerovat token pro uživatele %s",
+	"Generate Token":                        "Vygenerovat token",
+	"Generated Token":                       "Vygenerovaný token",
+	"Adapter":                               "Adaptér",
+	"Adapter Type":                          "Typ adaptéru",
+	"DB Connection":                         "Připojení DB",
+	"DB Host":                               "Hostitel DB",
+	"DB Port":                               "Port DB",
+	"DB Database":                           "Databáze",
+	"DB Username":                           "Uživatel DB",
+	"DB Password":                           "Heslo DB",
+	"Poll Interval":                         "Interval dotazování",
+	"Source name":                           "Název zdroje",
+	"abraflexi-webhook-acceptor":            "abraflexi-webhook-acceptor",
+	"mysql":                                 "mysql",
+	"mysql|pgsql|sqlite":                    "mysql|pgsql|sqlite",
+	"localhost":                             "localhost",
+	"database name":                         "název databáze",
+	"username":                              "uživatel",
+	"password":                              "heslo",
+	"Test":                                  "Otestovat",
+	"Test: %s":                              "Test: %s",
+	"Source":                                "Zdroj",
+	"Evidence":                              "Evidence",
+	"Operation":                             "Operace",
+	"Template":                              "Šablona",
+	"Event Source ID":                       "ID zdroje událostí",
+	"Priority":                              "Priorita",
+	"Env Mapping":                           "Mapování prostředí",
+	"e.g. faktura-vydana":                   "např. faktura-vydana",
+	"any":                                   "any",
+	"any|create|update|delete":              "any|create|update|delete",
+	"1 or 0":                                "1 nebo 0",
+	`{"KEY":"value"}`:                       `{"KEY":"value"}`,
+
+	// Dashboard
+	"System":                 "Systém",
+	"System Status":          "Stav systému",
+	"Waiting":                "Čeká",
+	"Running now":            "Právě běží",
+	"Recent failures":        "Poslední selhání",
+	"Failed":                 "Selhalo",
+	"%d of the last %d jobs": "%d z posledních %d úloh",
+	"exit %d":                "návratový kód %d",
+	"Overdue RunTemplates":   "Zpožděné šablony spuštění",
+	"Overdue":                "Zpožděno",
+	"%s late":                "zpoždění %s",
+	"Records":                "Záznamy",
+	"… and %d more":          "… a %d dalších",
+	"refreshing…":            "obnovuji…",
+	"auto-refresh every %s":  "automatické obnovení každých %s",
+	"↑/↓: select • enter: open list • r: refresh": "↑/↓: výběr • enter: otevřít seznam • r: obnovit",
+	"running": "běžící",
+	"failed":  "selhané",
+	"overdue": "zpožděné",
+
+	// Statistics
+	"Statistics": "Statistiky",
+	"Job history charts: outcomes, durations and executors": "Grafy historie úloh: výsledky, doby běhu a vykonavatelé",
+	"Job Statistics":               "Statistiky úloh",
+	"Job history":                  "Historie úloh",
+	"24 hours":                     "24 hodin",
+	"7 days":                       "7 dní",
+	"30 days":                      "30 dní",
+	"Succeeded":                    "Úspěšné",
+	"Running":                      "Běží",
+	"Duration":                     "Doba běhu",
+	"Average duration":             "Průměrná doba běhu",
+	"Jobs per executor":            "Úlohy podle vykonavatele",
+	"(none)":                       "(žádný)",
+	"Loading job history...":       "Načítám historii úloh...",
+	"No jobs in this time window.": "V tomto období nejsou žádné úlohy.",
+	"Only the newest %d jobs were read; older ones are missing.": "Načteno jen %d nejnovějších úloh; starší chybí.",
+	"1/2/3, ←/→: time window • r: reload":                        "1/2/3, ←/→: období • r: znovu načíst",
+
+	// Alerts
+	"Alerts":                              "Upozornění",
+	"🔔 Alerts":                            "🔔 Upozornění",
+	"Health rule breaches and recoveries": "Porušení a obnovení pravidel stavu",
+	"Time":                                "Čas",
+	"State":                               "Stav",
+	"Rule":                                "Pravidlo",
+	"Detail":                              "Podrobnosti",
+	"breached":                            "porušeno",
+	"resolved":                            "vyřešeno",
+	"Alert: %s (%s)":                      "Upozornění: %s (%s)",
+	"Resolved: %s (%s)":                   "Vyřešeno: %s (%s)",
+	"Scheduler not active":                "Plánovač neběží",
+	"Queue backed up":                     "Fronta je zahlcená",
+	"Jobs failing":                        "Úlohy selhávají",
+
+	// Command runner
+	"⌨ Commands": "⌨ Příkazy",
+	"Commands":   "Příkazy",
+	"Run any multiflexi-cli command from a generated form": "Spustit libovolný příkaz multiflexi-cli z vygenerovaného formuláře",
+	"%s is required":                    "%s je povinné",
+	"%s must be a number":               "%s musí být číslo",
+	"%s needs the arguments before it":  "%s vyžaduje argumenty před sebou",
+	"Command failed: %v":                "Příkaz selhal: %v",
+	"This command takes no parameters.": "Tento příkaz nemá žádné parametry.",
+	"history %d of %d":                  "historie %d z %d",
+	"tab/↑↓: fields • space: toggle • enter: run • ctrl+p/ctrl+n: history • esc: back": "tab/↑↓: pole • mezerník: přepnout • enter: spustit • ctrl+p/ctrl+n: historie • esc: zpět",
+
+	// Help browser
+	"Loading commands...":   "Načítám příkazy...",
+	"Loading help...":       "Načítám nápovědu...",
+	"No matching commands.": "Žádné odpovídající příkazy.",
+	"searching help texts…": "prohledávám nápovědu…",
+	"type to search names and help texts • enter: done • esc: clear": "pište pro hledání v názvech a nápovědě • enter: hotovo • esc: zrušit",
+	"↑/↓: command • pgup/pgdn: scroll help • /: search • esc: back":  "↑/↓: příkaz • pgup/pgdn: posun nápovědy • /: hledat • esc: zpět",
+
+	// Typed form fields
+	"space: toggle": "mezerník: přepnout",
+	"←/→: choose":   "←/→: vybrat",
+	"←/→: part • +/-: change • n: now • del: clear": "←/→: část • +/-: změnit • n: nyní • del: smazat",
+	"ctrl+r: show/hide":              "ctrl+r: zobrazit/skrýt",
+	"enter: new line • ctrl+s: save": "enter: nový řádek • ctrl+s: uložit",
+	"now":                            "nyní",
+
+	// Select field choices
+	"MySQL":         "MySQL",
+	"PostgreSQL":    "PostgreSQL",
+	"SQLite":        "SQLite",
+	"disabled":      "vypnuto",
+	"every minute":  "každou minutu",
+	"hourly":        "každou hodinu",
+	"daily":         "denně",
+	"weekly":        "týdně",
+	"monthly":       "měsíčně",
+	"yearly":        "ročně",
+	"custom (cron)": "vlastní (cron)",
+
+	// Form validation
+	"Required":                 "Povinné",
+	"Must be a whole number":   "Musí být celé číslo",
+	"Must be an email address": "Musí být e-mailová adresa",
+	"Must be a cron expression: minute hour day month weekday": "Musí být výraz cronu: minuta hodina den měsíc den_v_týdnu",
+	"Must be valid JSON": "Musí být platný JSON",
+	"Must be a date and time (YYYY-MM-DD HH:MM:SS) or now": "Musí být datum a čas (RRRR-MM-DD HH:MM:SS) nebo now",
+	"Must be a date (YYYY-MM-DD)":                          "Musí být datum (RRRR-MM-DD)",
+	"Must be an http:// or https:// URL":                   "Musí být adresa URL začínající http:// nebo https://",
+	"Must be a UUID":                                       "Musí být UUID",
+	"Must be a version such as 1.0.0":                      "Musí být verze, např. 1.0.0",
+	"Must be lowercase letters, digits and dashes":         "Smí obsahovat jen malá písmena, číslice a pomlčky",
+	"Must be an 8-digit IČO with a valid check digit":      "Musí být osmimístné IČO s platnou kontrolní číslicí",
+
+	// Record pickers
+	"Choose %s": "Vyberte %s",
+	"enter: choose • type: search • del: clear":              "enter: vybrat • psaní: hledat • del: vymazat",
+	"type: search • ↑/↓: move • enter: choose • esc: cancel": "psaní: hledat • ↑/↓: pohyb • enter: vybrat • esc: zrušit",
+
+	// External editor
+	"Must be valid YAML":     "Musí být platný YAML",
+	"Editor failed: %v":      "Editor selhal: %v",
+	"Edited %s is not valid": "Upravené pole %s není platné",
+	"Updated %s":             "Pole %s aktualizováno",
+
+	// Drafts
+	"Discard unsaved changes?":         "Zahodit neuložené změny?",
+	"Restore unsaved changes from %s?": "Obnovit neuložené změny z %s?",
+
+	// Update preview
+	"No changes to save":       "Žádné změny k uložení",
+	"You are about to change:": "Chystáte se změnit:",
+	"(empty)":                  "(prázdné)",
+	"(changed)":                "(změněno)",
+
+	// Edit conflicts
+	"The record was changed by someone else since you opened it.": "Záznam mezitím změnil někdo jiný.",
+	"Original":                          "Původní",
+	"Theirs":                            "Jejich",
+	"Mine":                              "Moje",
+	"None of the edited fields differ.": "Žádné z upravovaných polí se neliší.",
+	"Save aborted":                      "Uložení zrušeno",
+	"o: overwrite • m: merge field by field • esc: abort":               "o: přepsat • m: sloučit po polích • esc: zrušit",
+	"↑/↓: field • space/←/→: theirs or mine • enter: apply • esc: back": "↑/↓: pole • mezerník/←/→: jejich nebo moje • enter: použít • esc: zpět",
+
+	// Duplicate
+	"Duplicate":    "Duplikovat",
+	"Duplicate %s": "Duplikovat %s",
+
+	// Undo journal
+	"Undo History":                          "Historie změn",
+	"↶ Undo History":                        "↶ Historie změn",
+	"Changes made in this session, to undo": "Změny provedené v této relaci, k vrácení",
+	"Change":                                "Změna",
+	"Record":                                "Záznam",
+	"Deleted":                               "Smazáno",
+	"undone":                                "vráceno",
+	"deleted %s":                            "smazání %s",
+	"updated %s":                            "úprava %s",
+	"Nothing to undo":                       "Není co vrátit",
+	"Undo":                                  "Vrátit",
+	"Undo: %s":                              "Vrátit: %s",
+	"Undo: %s?":                             "Vrátit: %s?",
+	"Undo this change?":                     "Vrátit tuto změnu?",
+	"Undo failed: %v":                       "Vrácení selhalo: %v",
+	"Restored %s":                           "Obnoveno: %s",
+	"not restored: %s":                      "neobnoveno: %s",
+	"ID %d":                                 "ID %d",
+	"ID %d (now %d)":                        "ID %d (nyní %d)",
+	"%s was undone already":                 "%s již bylo vráceno",
+	"%s is being undone":                    "%s se právě vrací",
+	"%s cannot be created again":            "%s nelze znovu vytvořit",
+	"%s cannot be created again without %s": "%s nelze znovu vytvořit bez %s",
+	"none of the changes to %s can be restored": "žádnou ze změn %s nelze obnovit",
+	"The change is no longer in the journal":    "Změna už v historii není",
+
+	// Audit log
+	"📜 Audit Log": "📜 Auditní protokol",
+	"Audit Log":   "Auditní protokol",
+	"Who created, changed and deleted what, from the audit log": "Kdo co vytvořil, změnil a smazal, z auditního protokolu",
+	"Argument %d": "Argument %d",
+	"Entity":      "Entita",
+	"Error":       "Chyba",
+	"Profile":     "Profil",
+	"Result":      "Výsledek",
+	"ok":          "ok",
+
+	// Company settings and runtemplate config
+	"Settings":                        "Nastavení",
+	"KEY: value, one per line":        "KLÍČ: hodnota, jedna na řádek",
+	"Must be one KEY: value per line": "Musí být jeden KLÍČ: hodnota na řádek",
+
+	// Commands browser confirmation
+	"Run %s?": "Spustit %s?",
+}
@@ -0,0 +1,19 @@
+package i18n
+
+func init() {
+	Register("en", &Catalog{
+		Messages: map[string]string{},
+		Plurals: map[string][]string{
+			"commands":             {"%d command", "%d commands"},
+			"items":                {"%d item", "%d items"},
+			"jobs":                 {"%d job", "%d jobs"},
+			"saved parameter sets": {"%d saved parameter set", "%d saved parameter sets"},
+		},
+		PluralForm: func(n int) int {
+			if n == 1 {
+				return 0
+			}
+			return 1
+		},
+	})
+}
@@ -0,0 +1,125 @@
+// Package i18n translates the user interface.
+//
+// Messages are keyed by their English text, so untranslated strings fall back
+// to English and the English catalog only needs plural forms. Labels used as
+// map keys (EditorField.Label, DetailField.Label, …) stay English in the code
+// and are translated only where they are rendered.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Catalog holds the translations of one language.
+type Catalog struct {
+	// Messages maps English msgids to translations.
+	Messages map[string]string
+	// Plurals maps a plural key to its forms, ordered as PluralForm numbers them.
+	Plurals map[string][]string
+	// PluralForm returns the index of the plural form used for n.
+	PluralForm func(n int) int
+}
+
+// DefaultLanguage is used when no supported language is requested.
+const DefaultLanguage = "en"
+
+var (
+	catalogs = map[string]*Catalog{}
+	lang     = DefaultLanguage
+)
+
+// Register adds or replaces the catalog of a language.
+func Register(language string, c *Catalog) {
+	catalogs[language] = c
+}
+
+// Languages lists the registered language codes.
+func Languages() []string {
+	out := make([]string, 0, len(catalogs))
+	for l := range catalogs {
+		out = append(out, l)
+	}
+	return out
+}
+
+// CatalogFor returns the catalog of a language, or nil.
+func CatalogFor(language string) *Catalog {
+	return catalogs[language]
+}
+
+// Language returns the active language code.
+func Language() string { return lang }
+
+// SetLanguage activates a language. Locale names such as "cs_CZ.UTF-8" are
+// reduced to their language code; unsupported languages fall back to English.
+func SetLanguage(locale string) {
+	code := normalize(locale)
+	if _, ok := catalogs[code]; ok {
+		lang = code
+		return
+	}
+	lang = DefaultLanguage
+}
+
+// Detect returns the language requested by the environment, checking
+// LC_ALL, LC_MESSAGES and LANG in the order POSIX gives them precedence.
+func Detect() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			return normalize(v)
+		}
+	}
+	return DefaultLanguage
+}
+
+func normalize(locale string) string {
+	code := strings.ToLower(locale)
+	if i := strings.IndexAny(code, "_.@-"); i >= 0 {
+		code = code[:i]
+	}
+	if code == "" || code == "c" || code == "posix" {
+		return DefaultLanguage
+	}
+	return code
+}
+
+// T translates msgid into the active language.
+func T(msgid string) string {
+	if c := catalogs[lang]; c != nil {
+		if s, ok := c.Messages[msgid]; ok && s != "" {
+			return s
+		}
+	}
+	return msgid
+}
+
+// Tf translates a format string and formats it with args.
+func Tf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// N returns the plural form of key that matches n, formatted with n, e.g.
+// N("items", 3) → "3 items" / "3 položky".
+func N(key string, n int) string {
+	for _, code := range []string{lang, DefaultLanguage} {
+		c := catalogs[code]
+		if c == nil {
+			continue
+		}
+		forms := c.Plurals[key]
+		if len(forms) == 0 {
+			continue
+		}
+		i := 0
+		if c.PluralForm != nil {
+			i = c.PluralForm(n)
+		}
+		if i >= len(forms) {
+			i = len(forms) - 1
+		}
+		return fmt.Sprintf(forms[i], n)
+	}
+	return fmt.Sprintf("%d %s", n, key)
+}
@@ -0,0 +1,192 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// translatedFields are struct fields whose string literals are rendered
+// through T by the generic views; the lower-case ones belong to view-local
+// structs such as the dashboard panels.
+var translatedFields = map[string]bool{
+	"Label": true, "Hint": true, "Placeholder": true, "Header": true,
+	"Confirm": true, "Text": true, "Status": true, "label": true, "title": true,
+}
+
+// translatedCalls are functions whose first string argument is rendered through T.
+var translatedCalls = map[string]bool{
+	"T": true, "Tf": true, "NewViewer": true, "SetContent": true,
+	"NewActionFormView": true, "NewTableWidget": true, "SetWhere": true,
+}
+
+// collectMsgids walks the Go sources of the module and returns every string
+// literal that reaches the user through the translation layer.
+func collectMsgids(t *testing.T) map[string]string {
+	t.Helper()
+	root, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := map[string]string{}
+	add := func(fset *token.FileSet, lit ast.Expr) {
+		bl, ok := lit.(*ast.BasicLit)
+		if !ok || bl.Kind != token.STRING {
+			return
+		}
+		s, err := strconv.Unquote(bl.Value)
+		if err != nil || strings.TrimSpace(s) == "" || !strings.ContainsAny(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+			return
+		}
+		if _, seen := ids[s]; !seen {
+			ids[s] = fset.Position(bl.Pos()).String()
+		}
+	}
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.ValueSpec:
+				// Help texts kept in constants, e.g. entity.defaultHelp.
+				for i, name := range n.Names {
+					if strings.HasSuffix(name.Name, "Help") && i < len(n.Values) {
+						add(fset, n.Values[i])
+					}
+				}
+			case *ast.CompositeLit:
+				isEntityDef := false
+				if id, ok := n.Type.(*ast.Ident); ok && id.Name == "EntityDef" {
+					isEntityDef = true
+				}
+				for _, el := range n.Elts {
+					kv, ok := el.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+					key, ok := kv.Key.(*ast.Ident)
+					if !ok {
+						continue
+					}
+					if translatedFields[key.Name] || (isEntityDef && key.Name == "Name") {
+						add(fset, kv.Value)
+					}
+				}
+			case *ast.CallExpr:
+				name := ""
+				switch fn := n.Fun.(type) {
+				case *ast.SelectorExpr:
+					name = fn.Sel.Name
+				case *ast.Ident:
+					name = fn.Name
+				}
+				if translatedCalls[name] && len(n.Args) > 0 {
+					add(fset, n.Args[0])
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ids
+}
+
+func TestCatalogsHaveAllKeys(t *testing.T) {
+	ids := collectMsgids(t)
+	if len(ids) < 50 {
+		t.Fatalf("expected to find many translatable strings, found %d", len(ids))
+	}
+	for _, code := range Languages() {
+		if code == DefaultLanguage {
+			continue
+		}
+		c := CatalogFor(code)
+		var missing []string
+		for id, pos := range ids {
+			if _, ok := c.Messages[id]; !ok {
+				missing = append(missing, strconv.Quote(id)+"  // "+pos)
+			}
+		}
+		sort.Strings(missing)
+		for _, m := range missing {
+			t.Errorf("%s: missing translation %s", code, m)
+		}
+	}
+}
+
+func TestCatalogsHaveAllPlurals(t *testing.T) {
+	en := CatalogFor(DefaultLanguage)
+	for _, code := range Languages() {
+		c := CatalogFor(code)
+		for key := range en.Plurals {
+			if len(c.Plurals[key]) == 0 {
+				t.Errorf("%s: missing plural forms for %q", code, key)
+			}
+		}
+	}
+}
+
+func TestPluralForms(t *testing.T) {
+	defer SetLanguage(DefaultLanguage)
+
+	SetLanguage("en_US.UTF-8")
+	if got := N("items", 1); got != "1 item" {
+		t.Errorf("en N(1) = %q", got)
+	}
+	if got := N("items", 5); got != "5 items" {
+		t.Errorf("en N(5) = %q", got)
+	}
+
+	SetLanguage("cs_CZ.UTF-8")
+	for n, want := range map[int]string{1: "1 položka", 3: "3 položky", 5: "5 položek", 0: "0 položek"} {
+		if got := N("items", n); got != want {
+			t.Errorf("cs N(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestFallbackToEnglish(t *testing.T) {
+	defer SetLanguage(DefaultLanguage)
+	SetLanguage("xx")
+	if Language() != DefaultLanguage {
+		t.Errorf("unsupported language should fall back to %s, got %s", DefaultLanguage, Language())
+	}
+	SetLanguage("cs")
+	if got := T("no such message id"); got != "no such message id" {
+		t.Errorf("unknown msgid should be returned as is, got %q", got)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "cs_CZ.UTF-8")
+	if got := Detect(); got != "cs" {
+		t.Errorf("Detect() = %q, want cs", got)
+	}
+	t.Setenv("LC_ALL", "C")
+	if got := Detect(); got != DefaultLanguage {
+		t.Errorf("Detect() with LC_ALL=C = %q, want %s", got, DefaultLanguage)
+	}
+}
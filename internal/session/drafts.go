@@ -0,0 +1,85 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Drafts keeps the unsaved values of editors, one file per record in Dir,
+// so they survive a crash or a lost SSH session. The zero value keeps
+// nothing.
+type Drafts struct {
+	Dir string
+}
+
+// Draft is the saved state of one editor: the values that differ from the
+// record, keyed by field label.
+type Draft struct {
+	Values map[string]string `json:"values"`
+	Saved  time.Time         `json:"saved"`
+}
+
+// path names the draft of a record; ID 0 is the editor of a new record.
+func (d Drafts) path(entity string, id int) string {
+	name := fmt.Sprintf("%s-%d.json", entity, id)
+	if id == 0 {
+		name = entity + "-new.json"
+	}
+	return filepath.Join(d.Dir, name)
+}
+
+// Save writes the draft of a record, replacing the previous one atomically.
+func (d Drafts) Save(entity string, id int, values map[string]string) error {
+	if d.Dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(d.Dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(Draft{Values: values, Saved: time.Now()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := d.path(entity, id)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load reads the draft of a record; it returns nil when there is none.
+func (d Drafts) Load(entity string, id int) (*Draft, error) {
+	if d.Dir == "" {
+		return nil, nil
+	}
+	path := d.path(entity, id)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var draft Draft
+	if err := json.Unmarshal(data, &draft); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &draft, nil
+}
+
+// Remove deletes the draft of a record, if any.
+func (d Drafts) Remove(entity string, id int) error {
+	if d.Dir == "" {
+		return nil
+	}
+	err := os.Remove(d.path(entity, id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
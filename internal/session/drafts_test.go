@@ -0,0 +1,45 @@
+package session
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDraftsRoundTrip(t *testing.T) {
+	d := Drafts{Dir: filepath.Join(t.TempDir(), "drafts")}
+	if draft, err := d.Load("runtemplate", 7); draft != nil || err != nil {
+		t.Fatalf("Load without a draft = %v, %v", draft, err)
+	}
+	if err := d.Save("runtemplate", 7, map[string]string{"Name": "Nightly"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Save("runtemplate", 0, map[string]string{"Name": "New one"}); err != nil {
+		t.Fatal(err)
+	}
+	draft, err := d.Load("runtemplate", 7)
+	if err != nil || draft == nil || draft.Values["Name"] != "Nightly" || draft.Saved.IsZero() {
+		t.Fatalf("Load = %+v, %v", draft, err)
+	}
+	if err := d.Remove("runtemplate", 7); err != nil {
+		t.Fatal(err)
+	}
+	if draft, _ := d.Load("runtemplate", 7); draft != nil {
+		t.Error("a removed draft should be gone")
+	}
+	if draft, _ := d.Load("runtemplate", 0); draft == nil || draft.Values["Name"] != "New one" {
+		t.Error("the draft of a new record is kept apart")
+	}
+	if err := d.Remove("runtemplate", 7); err != nil {
+		t.Errorf("removing a missing draft: %v", err)
+	}
+}
+
+func TestZeroDraftsKeepNothing(t *testing.T) {
+	var d Drafts
+	if err := d.Save("job", 1, map[string]string{"a": "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if draft, err := d.Load("job", 1); draft != nil || err != nil {
+		t.Errorf("Load = %v, %v", draft, err)
+	}
+}
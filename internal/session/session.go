@@ -0,0 +1,135 @@
+// Package session saves and restores where the user left off: the active
+// menu item, per-entity list state and the record that was open.
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// State is the persisted session. The zero value is an empty session.
+type State struct {
+	Menu   string                `json:"menu,omitempty"`   // label of the last active menu item
+	Lists  map[string]*ListState `json:"lists,omitempty"`  // keyed by CLI entity
+	Record *Record               `json:"record,omitempty"` // record open in a detail view
+
+	// Commands holds the parameter sets commands were run with, newest
+	// first, keyed by command line (e.g. "job status").
+	Commands map[string][]map[string]string `json:"commands,omitempty"`
+}
+
+// maxCommandHistory is how many parameter sets are kept per command.
+const maxCommandHistory = 20
+
+// ListState is the state of one entity list.
+type ListState struct {
+	Offset int            `json:"offset,omitempty"`
+	Filter string         `json:"filter,omitempty"`
+	Sort   string         `json:"sort,omitempty"` // column field
+	Desc   bool           `json:"desc,omitempty"`
+	Hidden []string       `json:"hidden,omitempty"` // hidden column fields
+	Widths map[string]int `json:"widths,omitempty"` // column widths fixed by the user, by field
+	Extra  []string       `json:"extra,omitempty"`  // FullData fields shown as extra columns
+}
+
+// Record identifies a single entity record.
+type Record struct {
+	Entity string `json:"entity"` // CLI entity, e.g. "job"
+	ID     int    `json:"id"`
+}
+
+// Recorder is implemented by views that show a single record, so the
+// record can be reopened in the next session.
+type Recorder interface {
+	SessionRecord() Record
+}
+
+// New returns an empty session.
+func New() *State {
+	return &State{Lists: map[string]*ListState{}}
+}
+
+// Load reads the session from path. A missing file yields an empty session.
+func Load(path string) (*State, error) {
+	s := New()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return New(), fmt.Errorf("parse %s: %w", path, err)
+	}
+	if s.Lists == nil {
+		s.Lists = map[string]*ListState{}
+	}
+	return s, nil
+}
+
+// Save writes the session to path, creating its directory. The file is
+// replaced atomically so a crash never leaves a truncated session behind.
+func (s *State) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// List returns the state of an entity list, creating it on first use.
+func (s *State) List(entity string) *ListState {
+	if s.Lists == nil {
+		s.Lists = map[string]*ListState{}
+	}
+	ls := s.Lists[entity]
+	if ls == nil {
+		ls = &ListState{}
+		s.Lists[entity] = ls
+	}
+	return ls
+}
+
+// CommandHistory returns the parameter sets a command was run with, newest
+// first.
+func (s *State) CommandHistory(command string) []map[string]string {
+	return s.Commands[command]
+}
+
+// AddCommandHistory records the parameters of a command run. A set already
+// in the history moves to the front instead of being repeated.
+func (s *State) AddCommandHistory(command string, params map[string]string) {
+	if s.Commands == nil {
+		s.Commands = map[string][]map[string]string{}
+	}
+	history := []map[string]string{params}
+	for _, h := range s.Commands[command] {
+		if !sameParams(h, params) && len(history) < maxCommandHistory {
+			history = append(history, h)
+		}
+	}
+	s.Commands[command] = history
+}
+
+func sameParams(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "session.json")
+
+	s := New()
+	s.Menu = "Jobs"
+	s.List("job").Offset = 20
+	s.List("job").Filter = "failed"
+	s.List("job").Sort = "status"
+	s.List("job").Desc = true
+	s.List("job").Hidden = []string{"schedule"}
+	s.List("job").Widths = map[string]int{"command": 40}
+	s.List("job").Extra = []string{"executor"}
+	s.Record = &Record{Entity: "job", ID: 4711}
+	s.AddCommandHistory("prune", map[string]string{"keep": "500"})
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Menu != "Jobs" {
+		t.Errorf("menu = %q", got.Menu)
+	}
+	ls := got.List("job")
+	if ls.Offset != 20 || ls.Filter != "failed" || ls.Sort != "status" || !ls.Desc || len(ls.Hidden) != 1 ||
+		ls.Widths["command"] != 40 || len(ls.Extra) != 1 {
+		t.Errorf("list state not restored: %+v", ls)
+	}
+	if got.Record == nil || *got.Record != (Record{Entity: "job", ID: 4711}) {
+		t.Errorf("record = %+v", got.Record)
+	}
+	if h := got.CommandHistory("prune"); len(h) != 1 || h[0]["keep"] != "500" {
+		t.Errorf("command history = %+v", h)
+	}
+}
+
+func TestCommandHistoryMovesRepeatsToFront(t *testing.T) {
+	s := New()
+	s.AddCommandHistory("job status", map[string]string{"id": "1"})
+	s.AddCommandHistory("job status", map[string]string{"id": "2"})
+	s.AddCommandHistory("job status", map[string]string{"id": "1"})
+	h := s.CommandHistory("job status")
+	if len(h) != 2 || h[0]["id"] != "1" || h[1]["id"] != "2" {
+		t.Errorf("history = %+v", h)
+	}
+	for i := 0; i < 2*maxCommandHistory; i++ {
+		s.AddCommandHistory("prune", map[string]string{"keep": string(rune('a' + i))})
+	}
+	if n := len(s.CommandHistory("prune")); n != maxCommandHistory {
+		t.Errorf("history should be capped at %d, got %d", maxCommandHistory, n)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("missing file should not be an error: %v", err)
+	}
+	if s.Menu != "" || s.Record != nil {
+		t.Errorf("expected empty session, got %+v", s)
+	}
+}
+
+func TestLoadCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(path)
+	if err == nil {
+		t.Error("expected a parse error")
+	}
+	if s == nil || s.Lists == nil {
+		t.Error("a corrupt file should still yield a usable empty session")
+	}
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"math"
+	"strings"
+)
+
+// sparkBlocks are the eight heights of a sparkline cell, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// barBlocks are the partial widths of a bar's last cell in eighths, so bars
+// grow smoothly instead of a whole column at a time.
+var barBlocks = []rune(" ▏▎▍▌▋▊▉")
+
+// Sparkline renders values as a one-line chart exactly width columns wide,
+// one block per value scaled against max (the largest value when max <= 0).
+// Zero values stay blank so an idle period is told apart from a quiet one.
+// Fewer values than columns are stretched to fill the width; more values
+// are averaged in groups.
+func Sparkline(values []float64, width int, max float64) string {
+	if width <= 0 {
+		return ""
+	}
+	if len(values) == 0 {
+		return strings.Repeat(" ", width)
+	}
+	cells := resample(values, width)
+	if max <= 0 {
+		for _, v := range cells {
+			if v > max {
+				max = v
+			}
+		}
+	}
+	var b strings.Builder
+	for _, v := range cells {
+		if v <= 0 || max <= 0 {
+			b.WriteByte(' ')
+			continue
+		}
+		level := int(math.Ceil(v/max*float64(len(sparkBlocks)))) - 1
+		if level >= len(sparkBlocks) {
+			level = len(sparkBlocks) - 1
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// resample maps values onto n cells: each value covers the cells that fall
+// in its share of the width, and each cell averages the values in its share.
+func resample(values []float64, n int) []float64 {
+	cells := make([]float64, n)
+	for c := range cells {
+		lo := c * len(values) / n
+		hi := (c + 1) * len(values) / n
+		if hi <= lo {
+			cells[c] = values[lo]
+			continue
+		}
+		sum := 0.0
+		for _, v := range values[lo:hi] {
+			sum += v
+		}
+		cells[c] = sum / float64(hi-lo)
+	}
+	return cells
+}
+
+// Bar is one row of a bar chart. Text is shown after the bar, typically
+// the formatted value.
+type Bar struct {
+	Label string
+	Value float64
+	Text  string
+}
+
+// BarChart renders one horizontal bar per entry in at most width columns:
+// the label, the bar scaled against the largest value, then the text.
+// Labels are aligned to the widest one, up to a third of the width.
+func BarChart(bars []Bar, width int) string {
+	labelW, textW, max := 0, 0, 0.0
+	for _, bar := range bars {
+		if w := Width(bar.Label); w > labelW {
+			labelW = w
+		}
+		if w := Width(bar.Text); w > textW {
+			textW = w
+		}
+		if bar.Value > max {
+			max = bar.Value
+		}
+	}
+	if labelW > width/3 {
+		labelW = width / 3
+	}
+	barW := width - labelW - textW - 2
+	if barW < 1 {
+		barW = 1
+	}
+
+	lines := make([]string, len(bars))
+	for i, bar := range bars {
+		lines[i] = Fit(bar.Label, labelW) + " " + barCells(bar.Value, max, barW) + " " + bar.Text
+	}
+	return strings.Join(lines, "\n")
+}
+
+// barCells draws a bar of v against max in width columns, padded to width.
+func barCells(v, max float64, width int) string {
+	eighths := 0
+	if max > 0 && v > 0 {
+		eighths = int(math.Round(v / max * float64(width*8)))
+		if eighths == 0 {
+			eighths = 1 // a non-zero value is never invisible
+		}
+	}
+	bar := strings.Repeat("█", eighths/8)
+	if rest := eighths % 8; rest > 0 {
+		bar += string(barBlocks[rest])
+	}
+	return PadRight(bar, width)
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	for _, tc := range []struct {
+		values []float64
+		width  int
+		max    float64
+		want   string
+	}{
+		{[]float64{0, 1, 2, 4, 8}, 5, 0, " ▁▂▄█"},
+		{[]float64{1, 2}, 4, 0, "▄▄██"},     // stretched to the width
+		{[]float64{1, 3, 8, 8}, 2, 0, "▂█"}, // averaged in pairs
+		{[]float64{2, 4}, 2, 8, "▂▄"},       // shared scale
+		{[]float64{0, 0}, 2, 0, "  "},       // nothing to scale against
+		{nil, 3, 0, "   "},
+	} {
+		if got := Sparkline(tc.values, tc.width, tc.max); got != tc.want {
+			t.Errorf("Sparkline(%v, %d, %v) = %q, want %q", tc.values, tc.width, tc.max, got, tc.want)
+		}
+	}
+}
+
+func TestBarChart(t *testing.T) {
+	chart := BarChart([]Bar{
+		{Label: "Native", Value: 8, Text: "8"},
+		{Label: "Docker", Value: 2, Text: "2"},
+		{Label: "Kubernetes", Value: 0, Text: "0"},
+	}, 24)
+	lines := strings.Split(chart, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a line per bar:\n%s", chart)
+	}
+	for _, l := range lines {
+		if Width(l) != 24 {
+			t.Errorf("bar line %q is %d columns wide, want 24", l, Width(l))
+		}
+	}
+	// Labels are cut to a third of the width; the largest bar fills the rest.
+	if !strings.HasPrefix(lines[0], "Native   "+strings.Repeat("█", 13)+" 8") {
+		t.Errorf("largest bar should fill the chart: %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "Docker   ███▎ ") || !strings.HasPrefix(lines[2], "Kuberne… ") {
+		t.Errorf("unexpected bars:\n%s", chart)
+	}
+}
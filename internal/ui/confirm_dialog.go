@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -11,6 +12,8 @@ import (
 type ConfirmDialog struct {
 	label  string
 	action func() tea.Msg
+	cancel func() tea.Msg
+	zones  Zones
 }
 
 // NewConfirmDialog creates a confirmation dialog.
@@ -18,16 +21,29 @@ func NewConfirmDialog(label string, action func() tea.Msg) *ConfirmDialog {
 	return &ConfirmDialog{label: label, action: action}
 }
 
+// WithCancel sets what runs when the user declines.
+func (m *ConfirmDialog) WithCancel(cancel func() tea.Msg) *ConfirmDialog {
+	m.cancel = cancel
+	return m
+}
+
 func (m *ConfirmDialog) Init() tea.Cmd { return nil }
 
 func (m *ConfirmDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case ClickMsg:
+		switch id, _ := m.zones.Hit(msg.X, msg.Y); id {
+		case "yes":
+			return m, func() tea.Msg { return ConfirmYesMsg{Action: m.action} }
+		case "no":
+			return m, func() tea.Msg { return ConfirmNoMsg{Action: m.cancel} }
+		}
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "y", "Y":
 			return m, func() tea.Msg { return ConfirmYesMsg{Action: m.action} }
 		case "n", "N", "esc":
-			return m, func() tea.Msg { return ConfirmNoMsg{} }
+			return m, func() tea.Msg { return ConfirmNoMsg{Action: m.cancel} }
 		}
 	}
 	return m, nil
@@ -35,10 +51,15 @@ func (m *ConfirmDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *ConfirmDialog) View() string {
 	var b strings.Builder
-	b.WriteString(TitleStyle().Render("⚠️  Confirm"))
+	m.zones.Reset()
+	b.WriteString(TitleStyle().Render("⚠️  " + i18n.T("Confirm")))
 	b.WriteString("\n\n")
-	b.WriteString(fmt.Sprintf("%s\n\n", m.label))
-	b.WriteString(SelectedStyle().Render("[Y] Yes") + "   " + UnselectedStyle().Render("[N] No"))
+	b.WriteString(fmt.Sprintf("%s\n\n", i18n.T(m.label)))
+	yes, no := i18n.T("[Y] Yes"), i18n.T("[N] No")
+	x, y := Cursor(&b)
+	m.zones.Add("yes", x, y, Width(yes))
+	m.zones.Add("no", x+Width(yes)+3, y, Width(no))
+	b.WriteString(SelectedStyle().Render(yes) + "   " + UnselectedStyle().Render(no))
 	b.WriteString("\n")
 	return b.String()
 }
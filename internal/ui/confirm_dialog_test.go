@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestConfirmDialogClick(t *testing.T) {
+	d := NewConfirmDialog("Delete?", nil)
+	view := d.View()
+	y := lineOf(view, "[N] No")
+	line := strings.Split(view, "\n")[y]
+
+	_, cmd := d.Update(ClickMsg{X: lipgloss.Width(line[:strings.Index(line, "[N]")]) + 1, Y: y})
+	if _, ok := cmd().(ConfirmNoMsg); !ok {
+		t.Error("clicking [N] No should decline")
+	}
+	_, cmd = d.Update(ClickMsg{X: 1, Y: y})
+	if _, ok := cmd().(ConfirmYesMsg); !ok {
+		t.Error("clicking [Y] Yes should confirm")
+	}
+	if _, cmd = d.Update(ClickMsg{X: 1, Y: 0}); cmd != nil {
+		t.Error("clicks outside the buttons should do nothing")
+	}
+}
+
+func TestConfirmDialogCancel(t *testing.T) {
+	declined := false
+	d := NewConfirmDialog("Restore?", nil).WithCancel(func() tea.Msg { declined = true; return nil })
+	_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	no, ok := cmd().(ConfirmNoMsg)
+	if !ok || no.Action == nil {
+		t.Fatal("declining should carry the cancel action")
+	}
+	no.Action()
+	if !declined {
+		t.Error("the cancel action should run when declined")
+	}
+}
@@ -0,0 +1,763 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FieldKind selects the widget a form shows for an EditorField.
+type FieldKind int
+
+const (
+	TextField     FieldKind = iota // single-line text
+	ToggleField                    // on or off, valued "1" or "0"
+	SelectField                    // one of Options
+	IntField                       // whole number, kept within Min..Max when Max > Min
+	DateTimeField                  // DateTimeLayout, "now" or empty
+	SecretField                    // text shown masked
+	TextAreaField                  // multi-line text
+	PickerField                    // ID of a Ref record, chosen from a popup
+)
+
+// DateTimeLayout is the format of date-time field values, as multiflexi-cli
+// takes them.
+const DateTimeLayout = "2006-01-02 15:04:05"
+
+// FieldOption is one choice of a select field. Label, translated when
+// shown, defaults to Value.
+type FieldOption struct {
+	Value string
+	Label string
+}
+
+// FormLabelWidth is the least width of form labels; longer labels widen
+// the label column of the whole form.
+const FormLabelWidth = 15
+
+// Keys of the focused field, shown by forms next to their own keys.
+const (
+	toggleFieldHelp   = "space: toggle"
+	selectFieldHelp   = "←/→: choose"
+	dateTimeFieldHelp = "←/→: part • +/-: change • n: now • del: clear"
+	secretFieldHelp   = "ctrl+r: show/hide"
+	textAreaFieldHelp = "enter: new line • ctrl+s: save"
+	pickerFieldHelp   = "enter: choose • type: search • del: clear"
+)
+
+const (
+	textAreaHeight = 4
+	defaultWidth   = 80
+)
+
+// dateTimeParts are the byte ranges of year, month, day, hour, minute and
+// second in DateTimeLayout.
+var dateTimeParts = [][2]int{{0, 4}, {5, 7}, {8, 10}, {11, 13}, {14, 16}, {17, 19}}
+
+// clock is the time "now" and the first change of an empty date-time field
+// start from.
+var clock = time.Now
+
+// formField is the widget state of one form field.
+type formField struct {
+	def   EditorField
+	input textinput.Model // text, number and secret fields
+	area  textarea.Model  // text areas
+	value string          // toggles, selects, date-times and pickers
+	name  string          // name of the record a picker holds
+	part  int             // date-time part +/- changes
+	err   error           // shown under the field until the value is fixed
+	orig  string          // value the form started with
+}
+
+// Form edits a list of EditorFields, each with the widget of its Kind:
+// labels on the left, one field per line except text areas, and the
+// validation error of a field below it. The views embedding it handle
+// submitting (enter, ctrl+s, after Validate) and leaving (esc); the form
+// handles moving between fields (tab, ↑/↓), editing and clicks.
+type Form struct {
+	fields []*formField
+	focus  int
+	width  int
+}
+
+// NewForm creates a form with the first field focused.
+func NewForm(fields []EditorField) *Form {
+	f := &Form{width: defaultWidth}
+	for _, def := range fields {
+		ff := newFormField(def)
+		ff.orig = ff.text()
+		f.fields = append(f.fields, ff)
+	}
+	if len(f.fields) > 0 {
+		f.fields[0].focus()
+	}
+	f.SetWidth(defaultWidth)
+	return f
+}
+
+func newFormField(def EditorField) *formField {
+	ff := &formField{def: def}
+	switch def.Kind {
+	case ToggleField:
+		ff.value = "0"
+		if isOn(def.Value) {
+			ff.value = "1"
+		}
+	case SelectField:
+		ff.value = def.Value
+		known := false
+		for _, o := range def.Options {
+			known = known || o.Value == def.Value
+		}
+		switch {
+		case !known && def.Value == "":
+			ff.def.Options = append([]FieldOption{{Value: "", Label: "(none)"}}, def.Options...)
+		case !known:
+			ff.def.Options = append(append([]FieldOption(nil), def.Options...), FieldOption{Value: def.Value})
+		}
+	case DateTimeField, PickerField:
+		ff.value = def.Value
+	case TextAreaField:
+		ff.area = textarea.New()
+		ff.area.ShowLineNumbers = false
+		ff.area.Prompt = ""
+		ff.area.CharLimit = 0
+		ff.area.FocusedStyle.CursorLine = lipgloss.NewStyle()
+		ff.area.SetHeight(textAreaHeight)
+		ff.area.Placeholder = i18n.T(def.Placeholder)
+		ff.area.SetValue(def.Value)
+	default:
+		ff.input = textinput.New()
+		ff.input.Placeholder = i18n.T(def.Placeholder)
+		ff.input.SetValue(def.Value)
+		if def.Kind == SecretField {
+			ff.input.EchoMode = textinput.EchoPassword
+			ff.input.EchoCharacter = '•'
+		}
+	}
+	return ff
+}
+
+// isOn reads the usual spellings of a true flag.
+func isOn(v string) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+func (ff *formField) focus() {
+	switch ff.def.Kind {
+	case TextAreaField:
+		ff.area.Focus()
+	case TextField, IntField, SecretField:
+		ff.input.Focus()
+	}
+}
+
+func (ff *formField) blur() {
+	switch ff.def.Kind {
+	case TextAreaField:
+		ff.area.Blur()
+	case TextField, IntField, SecretField:
+		ff.input.Blur()
+	}
+	if ff.def.Kind == IntField && ff.def.Max > ff.def.Min {
+		if n, err := strconv.Atoi(strings.TrimSpace(ff.input.Value())); err == nil {
+			if n < ff.def.Min {
+				n = ff.def.Min
+			}
+			if n > ff.def.Max {
+				n = ff.def.Max
+			}
+			ff.input.SetValue(strconv.Itoa(n))
+		}
+	}
+	ff.err = ff.validate()
+}
+
+// validate checks the field: required fields must be filled in, numbers
+// and dates must parse, then the field's own validators run.
+func (ff *formField) validate() error {
+	v := ff.text()
+	if strings.TrimSpace(v) == "" {
+		if ff.def.Required {
+			return errRequired
+		}
+		return nil
+	}
+	switch ff.def.Kind {
+	case IntField, PickerField:
+		if err := IsInt(v); err != nil {
+			return err
+		}
+	case DateTimeField:
+		if err := IsDateTime(v); err != nil {
+			return err
+		}
+	}
+	switch ff.def.Ext {
+	case ".json":
+		if err := IsJSON(v); err != nil {
+			return err
+		}
+	case ".yaml", ".yml":
+		if err := IsYAML(v); err != nil {
+			return err
+		}
+	}
+	for _, check := range ff.def.Validators {
+		if err := check(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// text returns the field's value as the CreateArgs map and updates take
+// it.
+func (ff *formField) text() string {
+	switch ff.def.Kind {
+	case ToggleField, SelectField, DateTimeField, PickerField:
+		return ff.value
+	case TextAreaField:
+		return ff.area.Value()
+	}
+	return ff.input.Value()
+}
+
+func (ff *formField) setValue(v string) {
+	switch ff.def.Kind {
+	case ToggleField:
+		ff.value = "0"
+		if isOn(v) {
+			ff.value = "1"
+		}
+	case SelectField, DateTimeField:
+		ff.value = v
+	case PickerField:
+		ff.value, ff.name = v, ""
+	case TextAreaField:
+		ff.area.SetValue(v)
+	default:
+		ff.input.SetValue(v)
+	}
+}
+
+// Len returns the number of fields.
+func (f *Form) Len() int { return len(f.fields) }
+
+// Focused returns the index of the focused field.
+func (f *Form) Focused() int { return f.focus }
+
+// Focus moves the focus to field i.
+func (f *Form) Focus(i int) {
+	if i < 0 || i >= len(f.fields) || i == f.focus {
+		return
+	}
+	f.fields[f.focus].blur()
+	f.focus = i
+	f.fields[i].focus()
+}
+
+// Values returns the field values keyed by label.
+func (f *Form) Values() map[string]string {
+	values := make(map[string]string, len(f.fields))
+	for _, ff := range f.fields {
+		values[ff.def.Label] = ff.text()
+	}
+	return values
+}
+
+// Change is a field whose value differs from the one the form started
+// with.
+type Change struct {
+	Label    string
+	Old, New string
+	Secret   bool // a SecretField: the values are not to be shown
+}
+
+// Changes returns the changed fields in form order.
+func (f *Form) Changes() []Change {
+	var changes []Change
+	for _, ff := range f.fields {
+		if v := ff.text(); v != ff.orig {
+			changes = append(changes, Change{Label: ff.def.Label, Old: ff.orig, New: v, Secret: ff.def.Kind == SecretField})
+		}
+	}
+	return changes
+}
+
+// Dirty reports whether any field was changed.
+func (f *Form) Dirty() bool { return len(f.Changes()) > 0 }
+
+// SetOriginal replaces the value the field with the given label is compared
+// with, e.g. after the record was changed elsewhere; the edited value stays.
+func (f *Form) SetOriginal(label, v string) {
+	for _, ff := range f.fields {
+		if ff.def.Label == label {
+			def := ff.def
+			def.Value = v
+			ff.orig = newFormField(def).text()
+		}
+	}
+}
+
+// SetValue sets the value of the field with the given label.
+func (f *Form) SetValue(label, v string) {
+	for _, ff := range f.fields {
+		if ff.def.Label == label {
+			ff.setValue(v)
+		}
+	}
+}
+
+// FocusedField returns the focused field with its current value, or false
+// when the form has no fields.
+func (f *Form) FocusedField() (EditorField, bool) {
+	if len(f.fields) == 0 {
+		return EditorField{}, false
+	}
+	ff := f.fields[f.focus]
+	def := ff.def
+	def.Value = ff.text()
+	return def, true
+}
+
+// SetEdited sets the value of the field with the given label, edited
+// outside the form, and checks it as if the field had been left. It returns
+// the error shown under the field.
+func (f *Form) SetEdited(label, v string) error {
+	for _, ff := range f.fields {
+		if ff.def.Label == label {
+			ff.setValue(v)
+			ff.err = ff.validate()
+			return ff.err
+		}
+	}
+	return nil
+}
+
+// SetPicked puts the ID of the record chosen for the picker field with the
+// given label, shown with the record's name.
+func (f *Form) SetPicked(label, id, name string) {
+	for _, ff := range f.fields {
+		if ff.def.Label == label && ff.def.Kind == PickerField {
+			ff.value, ff.name = id, name
+			if ff.err != nil {
+				ff.err = ff.validate()
+			}
+		}
+	}
+}
+
+// Validate checks every field and focuses the first invalid one. It
+// reports whether the form may be submitted.
+func (f *Form) Validate() bool {
+	first := -1
+	for i, ff := range f.fields {
+		if ff.err = ff.validate(); ff.err != nil && first < 0 {
+			first = i
+		}
+	}
+	if first >= 0 {
+		f.Focus(first)
+	}
+	return first < 0
+}
+
+// Err returns the validation error shown under the field with the given
+// label, or nil.
+func (f *Form) Err(label string) error {
+	for _, ff := range f.fields {
+		if ff.def.Label == label {
+			return ff.err
+		}
+	}
+	return nil
+}
+
+// WantsEnter reports whether enter belongs to the focused field (a new line
+// in a text area, the popup of a picker) rather than submitting the form.
+func (f *Form) WantsEnter() bool {
+	if len(f.fields) == 0 {
+		return false
+	}
+	kind := f.fields[f.focus].def.Kind
+	return kind == TextAreaField || kind == PickerField
+}
+
+// Hint returns the keys of the focused field, untranslated, or "".
+func (f *Form) Hint() string {
+	if len(f.fields) == 0 {
+		return ""
+	}
+	switch f.fields[f.focus].def.Kind {
+	case ToggleField:
+		return toggleFieldHelp
+	case SelectField:
+		return selectFieldHelp
+	case DateTimeField:
+		return dateTimeFieldHelp
+	case SecretField:
+		return secretFieldHelp
+	case TextAreaField:
+		return textAreaFieldHelp
+	case PickerField:
+		return pickerFieldHelp
+	}
+	return ""
+}
+
+// SetWidth sets the width the form renders in.
+func (f *Form) SetWidth(w int) {
+	if w <= 0 {
+		w = defaultWidth
+	}
+	f.width = w
+	fieldW := w - f.labelWidth() - 1
+	if fieldW < 10 {
+		fieldW = 10
+	}
+	for _, ff := range f.fields {
+		if ff.def.Kind == TextAreaField {
+			ff.area.SetWidth(fieldW)
+		}
+	}
+}
+
+// labelWidth fits the widest label.
+func (f *Form) labelWidth() int {
+	w := FormLabelWidth
+	for _, ff := range f.fields {
+		if n := Width(ff.label()); n > w {
+			w = n
+		}
+	}
+	return w
+}
+
+// label is the translated label, marked with * when required.
+func (ff *formField) label() string {
+	label := i18n.T(ff.def.Label)
+	if ff.def.Required {
+		label += "*"
+	}
+	return label + ":"
+}
+
+// Init returns the cursor blink of the focused field.
+func (f *Form) Init() tea.Cmd { return textinput.Blink }
+
+// Update moves between fields and edits the focused one. Clicks are in
+// the form's own coordinates, its first field on line 0.
+func (f *Form) Update(msg tea.Msg) tea.Cmd {
+	if len(f.fields) == 0 {
+		return nil
+	}
+	switch msg := msg.(type) {
+	case ClickMsg:
+		return f.click(msg)
+	case tea.KeyMsg:
+		ff := f.fields[f.focus]
+		switch msg.String() {
+		case "tab":
+			f.Focus((f.focus + 1) % len(f.fields))
+			return textinput.Blink
+		case "shift+tab":
+			f.Focus((f.focus - 1 + len(f.fields)) % len(f.fields))
+			return textinput.Blink
+		case "down":
+			// Text areas keep the arrows until the cursor leaves their last line.
+			if ff.def.Kind != TextAreaField || ff.area.Line() >= ff.area.LineCount()-1 {
+				f.Focus((f.focus + 1) % len(f.fields))
+				return textinput.Blink
+			}
+		case "up":
+			if ff.def.Kind != TextAreaField || ff.area.Line() == 0 {
+				f.Focus((f.focus - 1 + len(f.fields)) % len(f.fields))
+				return textinput.Blink
+			}
+		}
+		cmd := ff.update(msg)
+		if ff.err != nil {
+			ff.err = ff.validate() // clear the error as soon as it is fixed
+		}
+		return cmd
+	}
+	ff := f.fields[f.focus]
+	var cmd tea.Cmd
+	switch ff.def.Kind {
+	case TextAreaField:
+		ff.area, cmd = ff.area.Update(msg)
+	case TextField, IntField, SecretField:
+		ff.input, cmd = ff.input.Update(msg)
+	}
+	return cmd
+}
+
+// update handles a key for the focused field.
+func (ff *formField) update(msg tea.KeyMsg) tea.Cmd {
+	key := msg.String()
+	var cmd tea.Cmd
+	switch ff.def.Kind {
+	case ToggleField:
+		switch key {
+		case " ", "left", "right", "h", "l", "x":
+			ff.toggle()
+		}
+	case SelectField:
+		switch key {
+		case " ", "right", "l":
+			ff.cycle(1)
+		case "left", "h":
+			ff.cycle(-1)
+		}
+	case DateTimeField:
+		ff.updateDateTime(key)
+	case PickerField:
+		switch {
+		case key == "enter" || key == " ":
+			return ff.pick("")
+		case key == "backspace" || key == "delete":
+			ff.value, ff.name = "", ""
+		case msg.Type == tea.KeyRunes:
+			return ff.pick(string(msg.Runes))
+		}
+	case TextAreaField:
+		ff.area, cmd = ff.area.Update(msg)
+	case IntField:
+		if msg.Type == tea.KeyRunes {
+			msg.Runes = []rune(strings.Map(func(r rune) rune {
+				if r >= '0' && r <= '9' || r == '-' {
+					return r
+				}
+				return -1
+			}, string(msg.Runes)))
+			if len(msg.Runes) == 0 {
+				return nil
+			}
+		}
+		ff.input, cmd = ff.input.Update(msg)
+	case SecretField:
+		if key == "ctrl+r" {
+			if ff.input.EchoMode == textinput.EchoPassword {
+				ff.input.EchoMode = textinput.EchoNormal
+			} else {
+				ff.input.EchoMode = textinput.EchoPassword
+			}
+			return nil
+		}
+		ff.input, cmd = ff.input.Update(msg)
+	default:
+		ff.input, cmd = ff.input.Update(msg)
+	}
+	return cmd
+}
+
+func (ff *formField) toggle() {
+	if ff.value == "1" {
+		ff.value = "0"
+	} else {
+		ff.value = "1"
+	}
+}
+
+// pick asks the view holding the form for the popup of a picker field,
+// its search started with filter.
+func (ff *formField) pick(filter string) tea.Cmd {
+	msg := PickMsg{Label: ff.def.Label, Ref: ff.def.Ref, Filter: filter}
+	return func() tea.Msg { return msg }
+}
+
+// cycle selects the option delta places after the current one.
+func (ff *formField) cycle(delta int) {
+	opts := ff.def.Options
+	if len(opts) == 0 {
+		return
+	}
+	i := 0
+	for j, o := range opts {
+		if o.Value == ff.value {
+			i = j
+		}
+	}
+	ff.value = opts[(i+delta+len(opts))%len(opts)].Value
+}
+
+// updateDateTime handles the keys of a date-time picker.
+func (ff *formField) updateDateTime(key string) {
+	switch key {
+	case "left", "h":
+		if ff.part > 0 {
+			ff.part--
+		}
+	case "right", "l":
+		if ff.part < len(dateTimeParts)-1 {
+			ff.part++
+		}
+	case "+", "=", "k":
+		ff.value = shiftDateTime(ff.value, ff.part, 1)
+	case "-", "_", "j":
+		ff.value = shiftDateTime(ff.value, ff.part, -1)
+	case "n":
+		ff.value = "now"
+	case "backspace", "delete":
+		ff.value = ""
+	}
+}
+
+// shiftDateTime changes one part of a date-time value; "now", an empty or
+// an unreadable value starts from the current time.
+func shiftDateTime(v string, part, delta int) string {
+	t, err := time.ParseInLocation(DateTimeLayout, v, time.Local)
+	if err != nil {
+		t = clock().Truncate(time.Second)
+	}
+	switch part {
+	case 0:
+		t = t.AddDate(delta, 0, 0)
+	case 1:
+		t = t.AddDate(0, delta, 0)
+	case 2:
+		t = t.AddDate(0, 0, delta)
+	case 3:
+		t = t.Add(time.Duration(delta) * time.Hour)
+	case 4:
+		t = t.Add(time.Duration(delta) * time.Minute)
+	default:
+		t = t.Add(time.Duration(delta) * time.Second)
+	}
+	return t.Format(DateTimeLayout)
+}
+
+// click focuses the field under the click; toggles flip, selects move on,
+// pickers open their popup and text fields put the cursor at the clicked
+// column.
+func (f *Form) click(msg ClickMsg) tea.Cmd {
+	i := f.fieldAt(msg.Y)
+	if i < 0 {
+		return nil
+	}
+	f.Focus(i)
+	ff := f.fields[i]
+	switch ff.def.Kind {
+	case ToggleField:
+		ff.toggle()
+	case SelectField:
+		ff.cycle(1)
+	case PickerField:
+		return ff.pick("")
+	case TextField, IntField, SecretField:
+		ff.input.SetCursor(msg.X - f.labelWidth() - 1 - Width(ff.input.Prompt))
+	}
+	return textinput.Blink
+}
+
+// fieldAt returns the field shown on line y, or -1.
+func (f *Form) fieldAt(y int) int {
+	for i, ff := range f.fields {
+		if y < 0 {
+			break
+		}
+		if y < ff.height() {
+			return i
+		}
+		y -= ff.height()
+	}
+	return -1
+}
+
+// height is the number of lines the field takes, its error included.
+func (ff *formField) height() int {
+	h := 1
+	if ff.def.Kind == TextAreaField {
+		h = ff.area.Height()
+	}
+	if ff.err != nil {
+		h++
+	}
+	return h
+}
+
+// View renders the fields, one label per field.
+func (f *Form) View() string {
+	var b strings.Builder
+	labelW := f.labelWidth()
+	for i, ff := range f.fields {
+		label := PadRight(ff.label(), labelW)
+		if i == f.focus {
+			label = SelectedStyle().Render(label)
+		}
+		for j, line := range strings.Split(ff.view(i == f.focus), "\n") {
+			if j == 0 {
+				b.WriteString(label + " ")
+			} else {
+				b.WriteString(strings.Repeat(" ", labelW+1))
+			}
+			b.WriteString(line + "\n")
+		}
+		if ff.err != nil {
+			b.WriteString(strings.Repeat(" ", labelW+1) + ErrorStyle().Render(i18n.T(ff.err.Error())) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// view renders the widget of a field.
+func (ff *formField) view(focused bool) string {
+	desc := DescriptionStyle()
+	switch ff.def.Kind {
+	case ToggleField:
+		if ff.value == "1" {
+			return "[x]"
+		}
+		return "[ ]"
+	case SelectField:
+		label := ff.value
+		for _, o := range ff.def.Options {
+			if o.Value == ff.value && o.Label != "" {
+				label = o.Label
+			}
+		}
+		label = i18n.T(label)
+		if focused {
+			return "◂ " + SelectedStyle().Render(label) + " ▸"
+		}
+		return label
+	case DateTimeField:
+		switch {
+		case ff.value == "":
+			return desc.Render(i18n.T(ff.def.Placeholder))
+		case ff.value == "now":
+			return i18n.T("now")
+		case focused && len(ff.value) == len(DateTimeLayout):
+			p := dateTimeParts[ff.part]
+			return ff.value[:p[0]] + SelectedStyle().Render(ff.value[p[0]:p[1]]) + ff.value[p[1]:]
+		}
+		return ff.value
+	case PickerField:
+		switch {
+		case ff.value == "":
+			return desc.Render(i18n.T(ff.def.Placeholder))
+		case ff.name != "":
+			return ff.value + "  " + desc.Render(ff.name)
+		}
+		return ff.value
+	case TextAreaField:
+		return ff.area.View()
+	case IntField:
+		if ff.def.Max > ff.def.Min {
+			return ff.input.View() + desc.Render(" ("+strconv.Itoa(ff.def.Min)+"–"+strconv.Itoa(ff.def.Max)+")")
+		}
+	}
+	return ff.input.View()
+}
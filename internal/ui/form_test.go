@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func pressKeys(f *Form, ks ...string) {
+	for _, k := range ks {
+		var msg tea.KeyMsg
+		switch k {
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "up":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "left":
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		case "right":
+			msg = tea.KeyMsg{Type: tea.KeyRight}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "space":
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+		case "ctrl+r":
+			msg = tea.KeyMsg{Type: tea.KeyCtrlR}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		f.Update(msg)
+	}
+}
+
+func TestFormToggleAndSelect(t *testing.T) {
+	f := NewForm([]EditorField{
+		{Label: "Enabled", Value: "true", Kind: ToggleField},
+		{Label: "Interval", Value: "d", Kind: SelectField, Options: []FieldOption{{Value: "d", Label: "daily"}, {Value: "w", Label: "weekly"}}},
+		{Label: "Executor", Value: "Custom", Kind: SelectField, Options: []FieldOption{{Value: "Native"}}},
+	})
+	if got := f.Values()["Enabled"]; got != "1" {
+		t.Errorf("toggle = %q, want 1", got)
+	}
+	pressKeys(f, "space")
+	pressKeys(f, "tab", "right")
+	pressKeys(f, "tab", "left")
+	v := f.Values()
+	if v["Enabled"] != "0" || v["Interval"] != "w" || v["Executor"] != "Native" {
+		t.Errorf("values = %v", v)
+	}
+	if !strings.Contains(f.View(), "weekly") {
+		t.Error("a select should show the label of its value")
+	}
+}
+
+func TestFormEmptySelectOffersNone(t *testing.T) {
+	f := NewForm([]EditorField{{Label: "Interval", Kind: SelectField, Options: []FieldOption{{Value: "d"}}}})
+	pressKeys(f, "right", "right")
+	if got := f.Values()["Interval"]; got != "" {
+		t.Errorf("cycling should come back to the empty choice, got %q", got)
+	}
+}
+
+func TestFormIntField(t *testing.T) {
+	f := NewForm([]EditorField{
+		{Label: "Port", Kind: IntField, Min: 1, Max: 65535},
+		{Label: "Name"},
+	})
+	pressKeys(f, "7a0b000")
+	if got := f.Values()["Port"]; got != "70000" {
+		t.Fatalf("only digits should be typed, got %q", got)
+	}
+	pressKeys(f, "tab")
+	if got := f.Values()["Port"]; got != "65535" {
+		t.Errorf("leaving the field should clamp it to the range, got %q", got)
+	}
+}
+
+func TestFormDateTimePicker(t *testing.T) {
+	saved := clock
+	defer func() { clock = saved }()
+	clock = func() time.Time { return time.Date(2026, 3, 31, 10, 20, 30, 0, time.Local) }
+
+	f := NewForm([]EditorField{{Label: "Scheduled", Value: "now", Kind: DateTimeField}})
+	pressKeys(f, "+")
+	if got := f.Values()["Scheduled"]; got != "2027-03-31 10:20:30" {
+		t.Fatalf("+ on now should start from the current time, got %q", got)
+	}
+	pressKeys(f, "right", "right", "right", "-", "-")
+	if got := f.Values()["Scheduled"]; got != "2027-03-31 08:20:30" {
+		t.Errorf("hours = %q", got)
+	}
+	pressKeys(f, "n")
+	if got := f.Values()["Scheduled"]; got != "now" {
+		t.Errorf("n should reset to now, got %q", got)
+	}
+}
+
+func TestFormSecretIsMasked(t *testing.T) {
+	f := NewForm([]EditorField{{Label: "Password", Value: "hunter2", Kind: SecretField}})
+	if strings.Contains(f.View(), "hunter2") {
+		t.Error("a secret should be masked")
+	}
+	pressKeys(f, "ctrl+r")
+	if !strings.Contains(f.View(), "hunter2") {
+		t.Error("ctrl+r should reveal the secret")
+	}
+	if f.Values()["Password"] != "hunter2" {
+		t.Error("masking should not change the value")
+	}
+}
+
+func TestFormTextArea(t *testing.T) {
+	f := NewForm([]EditorField{
+		{Label: "Name"},
+		{Label: "Env Mapping", Kind: TextAreaField},
+		{Label: "Priority"},
+	})
+	pressKeys(f, "tab")
+	if !f.WantsEnter() {
+		t.Fatal("a text area should take enter")
+	}
+	pressKeys(f, "{", "enter", "}")
+	if got := f.Values()["Env Mapping"]; got != "{\n}" {
+		t.Fatalf("text area = %q", got)
+	}
+	pressKeys(f, "up")
+	if f.Focused() != 1 {
+		t.Error("up inside a text area should move the cursor, not the focus")
+	}
+	pressKeys(f, "down", "down")
+	if f.Focused() != 2 {
+		t.Error("down on the last line should leave the text area")
+	}
+
+	// The field after a text area starts below all of its lines.
+	f.Update(ClickMsg{X: 20, Y: 1 + textAreaHeight})
+	if f.Focused() != 2 {
+		t.Errorf("focused = %d after clicking below the text area", f.Focused())
+	}
+	f.Update(ClickMsg{X: 20, Y: 2})
+	if f.Focused() != 1 {
+		t.Errorf("focused = %d after clicking inside the text area", f.Focused())
+	}
+}
+
+func TestFormPickerField(t *testing.T) {
+	f := NewForm([]EditorField{{Label: "Company ID", Kind: PickerField, Ref: "company"}})
+	if !f.WantsEnter() {
+		t.Fatal("enter on a picker should open its popup")
+	}
+	msg := f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ac")})()
+	if pick, ok := msg.(PickMsg); !ok || pick.Ref != "company" || pick.Filter != "ac" {
+		t.Fatalf("typing should ask for the popup searching for it, got %#v", msg)
+	}
+
+	f.SetPicked("Company ID", "7", "Acme")
+	if f.Values()["Company ID"] != "7" || !strings.Contains(f.View(), "Acme") {
+		t.Errorf("a picked record should set the ID and show the name:\n%s", f.View())
+	}
+	pressKeys(f, "tab")
+	f.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if f.Values()["Company ID"] != "" || strings.Contains(f.View(), "Acme") {
+		t.Error("backspace should clear the picker")
+	}
+}
+
+func TestFormChanges(t *testing.T) {
+	f := NewForm([]EditorField{
+		{Label: "Name", Value: "Acme"},
+		{Label: "Enabled", Value: "true", Kind: ToggleField},
+		{Label: "Password", Kind: SecretField},
+	})
+	if f.Dirty() {
+		t.Fatal("a new form should not be dirty")
+	}
+	pressKeys(f, "tab", "space", "tab", "x")
+	changes := f.Changes()
+	if len(changes) != 2 || changes[0] != (Change{Label: "Enabled", Old: "1", New: "0"}) || !changes[1].Secret {
+		t.Errorf("changes = %+v", changes)
+	}
+	f.SetValue("Enabled", "1")
+	f.SetValue("Password", "")
+	if f.Dirty() {
+		t.Error("values changed back should not count")
+	}
+}
+
+func TestFormSetOriginal(t *testing.T) {
+	f := NewForm([]EditorField{
+		{Label: "Name", Value: "Acme"},
+		{Label: "Enabled", Value: "1", Kind: ToggleField},
+	})
+	f.SetValue("Name", "Acme Ltd")
+	f.SetOriginal("Name", "Acme Ltd")
+	f.SetOriginal("Enabled", "true")
+	if f.Dirty() {
+		t.Errorf("changes = %+v, want none", f.Changes())
+	}
+	f.SetOriginal("Enabled", "0")
+	if changes := f.Changes(); len(changes) != 1 || changes[0] != (Change{Label: "Enabled", Old: "0", New: "1"}) {
+		t.Errorf("changes = %+v", changes)
+	}
+}
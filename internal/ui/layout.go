@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FitBlock clips or pads every line of s to exactly w visible columns and
+// the block to exactly h lines, so it can be placed next to another block.
+// ANSI styling is preserved. A non-positive h keeps the original line count.
+func FitBlock(s string, w, h int) string {
+	if w < 0 {
+		w = 0
+	}
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if h > 0 {
+		if len(lines) > h {
+			lines = lines[:h]
+		}
+		for len(lines) < h {
+			lines = append(lines, "")
+		}
+	}
+	clip := lipgloss.NewStyle().MaxWidth(w)
+	for i, line := range lines {
+		if Width(line) > w {
+			line = clip.Render(line)
+		}
+		if pad := w - Width(line); pad > 0 {
+			line += strings.Repeat(" ", pad)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -19,6 +19,15 @@ type NavigateBackAndRefreshMsg struct {
 	Status string // optional status text to show in the footer
 }
 
+// NavigateBackAndOpenMsg pops and refreshes like NavigateBackAndRefreshMsg,
+// with the refreshed list's cursor on the record Select when it is listed,
+// then opens View on top. Use this after creating a record.
+type NavigateBackAndOpenMsg struct {
+	Status string
+	Select int // ID of the record to select; 0 = none
+	View   tea.Model
+}
+
 // RefreshCurrentMsg refreshes the currently active view without navigating away.
 // Use this when a list-level action completes and the list is already visible.
 type RefreshCurrentMsg struct {
@@ -30,15 +39,49 @@ type Refreshable interface {
 	Refresh() tea.Cmd
 }
 
+// Selector is implemented by refreshable views listing records. Select puts
+// the cursor on the record with the given ID once the next refresh loads it.
+type Selector interface {
+	Select(id int)
+}
+
+// InputCapturer is implemented by views that can take over the keyboard,
+// e.g. while a filter is typed. While CapturingInput is true the App passes
+// esc, q and tab to the view instead of navigating.
+type InputCapturer interface {
+	CapturingInput() bool
+}
+
+// HelpTopic is implemented by views documented by a multiflexi-cli command,
+// usually their entity. F1 opens the help browser at that command.
+type HelpTopic interface {
+	HelpTopic() string
+}
+
+// HomeMsg is implemented by results meant for the home view, such as the
+// dashboard's polls. They reach it even while another view is in front, so
+// polling and alerting go on in the background.
+type HomeMsg interface {
+	HomeMsg()
+}
+
 // StatusMsg displays a transient message in the footer.
 type StatusMsg struct {
 	Text string
 }
 
-// ConfirmMsg is sent when the user confirms an action.
+// NotifyMsg displays Text in the footer like StatusMsg and announces it on
+// the terminal in the alert notification mode (bell or OSC 9).
+type NotifyMsg struct {
+	Text string
+}
+
+// ConfirmMsg is sent when the user confirms an action. Cancel, if set, runs
+// when the user declines.
 type ConfirmMsg struct {
 	Label  string
 	Action func() tea.Msg
+	Cancel func() tea.Msg
 }
 
 // ConfirmYesMsg is sent when user confirms.
@@ -47,7 +90,9 @@ type ConfirmYesMsg struct {
 }
 
 // ConfirmNoMsg is sent when user cancels.
-type ConfirmNoMsg struct{}
+type ConfirmNoMsg struct {
+	Action func() tea.Msg
+}
 
 // HelpLoadedMsg carries loaded help text.
 type HelpLoadedMsg struct {
@@ -77,12 +122,34 @@ type DetailField struct {
 	Value string
 }
 
-// EditorField defines one form field.
+// EditorField defines one form field. Kind picks its widget; whatever the
+// kind, the value is a string keyed by Label in the CreateArgs map. Flag
+// is the CLI option an update passes a changed value with.
 type EditorField struct {
 	Label       string
 	Placeholder string
 	Value       string
 	Required    bool
+	Flag        string // update option, e.g. "--name"; none = not updatable
+	// Args, if set, turns a value into its update arguments in place of
+	// Flag and the value, e.g. one --config KEY=VALUE per line.
+	Args func(value string) []string
+
+	Kind       FieldKind
+	Options    []FieldOption // choices of a SelectField
+	Min, Max   int           // range of an IntField; none when Max <= Min
+	Validators []Validator   // checked on leaving the field and on submit
+	Ref        string        // CLI entity whose records a PickerField chooses
+	Ext        string        // file extension for an external editor; ".json" and ".yaml" values are checked
+}
+
+// PickMsg asks the view holding a form to choose a record for the
+// PickerField with the given label: a Ref record, searched for with Filter.
+// The view hands the choice back through Form.SetPicked.
+type PickMsg struct {
+	Label  string
+	Ref    string
+	Filter string
 }
 
 // ActionDef defines an action button on a detail view.
@@ -107,11 +174,16 @@ type ListActionDef struct {
 	Confirm string // if non-empty, prompts before calling Handler
 }
 
-// TableColumn defines a column in a table.
+// TableColumn defines a column in a table. Width is the preferred width;
+// the table fits columns to the terminal by shrinking them towards MinWidth
+// and growing those with a Flex weight, in proportion to it, up to MaxWidth.
 type TableColumn struct {
-	Header string
-	Width  int
-	Field  string
+	Header   string
+	Width    int
+	Field    string
+	MinWidth int // 0 = the smaller of Width and 6
+	MaxWidth int // 0 = unlimited
+	Flex     int // share of spare width; 0 = never grows
 }
 
 // TableRow holds one row of table data.
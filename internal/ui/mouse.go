@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"strings"
+	"time"
+)
+
+// DoubleClickInterval is the longest pause between the two clicks of a
+// double click.
+const DoubleClickInterval = 400 * time.Millisecond
+
+// ClickMsg is a left mouse click in the coordinates of the view receiving
+// it: X and Y count from the view's top-left corner. Double is set on the
+// second click of a double click on the same cell.
+type ClickMsg struct {
+	X, Y   int
+	Double bool
+}
+
+// Zone is a clickable single-line area of a rendered view.
+type Zone struct {
+	ID   string
+	Y    int
+	X, W int
+}
+
+// Zones collects the clickable areas of a view while it renders, so clicks
+// are hit-tested against what is actually on screen.
+type Zones struct {
+	zones []Zone
+}
+
+// Reset forgets the zones of the previous render.
+func (z *Zones) Reset() { z.zones = z.zones[:0] }
+
+// Add records a zone of w columns starting at column x of line y.
+func (z *Zones) Add(id string, x, y, w int) {
+	z.zones = append(z.zones, Zone{ID: id, X: x, Y: y, W: w})
+}
+
+// Hit returns the ID of the zone under (x, y).
+func (z *Zones) Hit(x, y int) (string, bool) {
+	for _, zone := range z.zones {
+		if y == zone.Y && x >= zone.X && x < zone.X+zone.W {
+			return zone.ID, true
+		}
+	}
+	return "", false
+}
+
+// Cursor reports the line and column where the next character written to b
+// will appear, counting display columns of styled text.
+func Cursor(b *strings.Builder) (x, y int) {
+	s := b.String()
+	y = strings.Count(s, "\n")
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		s = s[i+1:]
+	}
+	return Width(s), y
+}
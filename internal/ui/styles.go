@@ -3,49 +3,76 @@ package ui
 import "github.com/charmbracelet/lipgloss"
 
 var (
-	// TurboVision-inspired color scheme
+	titleStyle           lipgloss.Style
+	selectedItemStyle    lipgloss.Style
+	activeMenuItemStyle  lipgloss.Style
+	unselectedItemStyle  lipgloss.Style
+	itemDescriptionStyle lipgloss.Style
+	footerStyle          lipgloss.Style
+	errorStyle           lipgloss.Style
+	buttonStyle          lipgloss.Style
+	activeStatusStyle    lipgloss.Style
+	disabledStatusStyle  lipgloss.Style
+	debugStyle           lipgloss.Style
+)
+
+func init() {
+	applyTheme(TurboVisionTheme)
+}
+
+// applyTheme rebuilds every style from the theme palette.
+func applyTheme(t Theme) {
+	current = t
+
 	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Background(lipgloss.Color("21")).
-			Foreground(lipgloss.Color("15"))
+		Bold(true).
+		Background(lipgloss.Color(t.TitleBg)).
+		Foreground(lipgloss.Color(t.TitleFg))
 
 	selectedItemStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color("0")).
-				Background(lipgloss.Color("14"))
+		Bold(true).
+		Foreground(lipgloss.Color(t.SelectedFg)).
+		Background(lipgloss.Color(t.SelectedBg))
 
 	activeMenuItemStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color("10"))
+		Bold(true).
+		Foreground(lipgloss.Color(t.ActiveMenuFg))
 
 	unselectedItemStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("7"))
+		Foreground(lipgloss.Color(t.UnselectedFg))
 
 	itemDescriptionStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("8"))
+		Foreground(lipgloss.Color(t.DescriptionFg))
 
 	footerStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("8"))
+		Foreground(lipgloss.Color(t.FooterFg))
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("9")).
-			Bold(true)
+		Foreground(lipgloss.Color(t.ErrorFg)).
+		Bold(true)
 
 	buttonStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("15")).
-			Background(lipgloss.Color("4")).
-			Padding(0, 1)
+		Foreground(lipgloss.Color(t.ButtonFg)).
+		Background(lipgloss.Color(t.ButtonBg)).
+		Padding(0, 1)
 
 	activeStatusStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("10")).
-				Bold(true)
+		Foreground(lipgloss.Color(t.ActiveFg)).
+		Bold(true)
 
 	disabledStatusStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("9"))
+		Foreground(lipgloss.Color(t.DisabledFg))
 
 	debugStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("11")) // bright yellow — visible but clearly secondary
-)
+		Foreground(lipgloss.Color(t.DebugFg))
+
+	// Without colours the cursor and titles must still stand out.
+	if noColor {
+		titleStyle = titleStyle.Reverse(true)
+		selectedItemStyle = selectedItemStyle.Reverse(true)
+		buttonStyle = buttonStyle.Reverse(true)
+	}
+}
 
 // Public accessors for styles.
 func TitleStyle() lipgloss.Style          { return titleStyle }
@@ -2,7 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 )
 
 // tableOverhead is the number of non-data lines rendered by View():
@@ -12,12 +17,21 @@ const tableOverhead = 5
 // minTableRows is the minimum number of data rows the table will display.
 const minTableRows = 3
 
+// chooserNameWidth is the width of the column names in the column chooser.
+const chooserNameWidth = 24
+
 // TableWidget renders a paginated table with cursor selection.
+//
+// Filtering and sorting apply to the rows of the loaded page: the CLI pages
+// by ID, so the widget narrows and orders what it was given.
 type TableWidget struct {
 	title    string
+	scope    string // names a restriction of the listed rows, shown after the title
 	columns  []TableColumn
 	rows     []TableRow
+	view     []TableRow // rows after filtering and sorting
 	cursor   int
+	top      int // first row on screen when there are more than limit
 	offset   int
 	limit    int
 	loading  bool
@@ -25,6 +39,24 @@ type TableWidget struct {
 	hasMore  bool
 	pageNum  int
 	helpText string
+
+	filter    string
+	editing   bool // typing the filter
+	sortField string
+	sortDesc  bool
+	hidden    map[string]bool
+
+	width   int            // display width the columns are fitted to; 0 = preferred widths
+	widths  map[string]int // widths fixed by the user, by field
+	laidOut map[string]int // widths of the last render, by field
+	extra   []string       // FullData fields shown as extra columns
+	fields  []string       // FullData fields seen in the loaded rows
+
+	choosing      bool // column chooser open
+	chooserCursor int
+	chooserTop    int // first chooser entry on screen
+
+	zones Zones // clickable rows, headers and pager arrows of the last render
 }
 
 // NewTableWidget creates a new table widget.
@@ -38,6 +70,9 @@ func NewTableWidget(title string, columns []TableColumn, limit int, helpText str
 		limit:    limit,
 		helpText: helpText,
 		loading:  true,
+		hidden:   map[string]bool{},
+		widths:   map[string]int{},
+		laidOut:  map[string]int{},
 	}
 }
 
@@ -62,45 +97,271 @@ func (t *TableWidget) SetData(rows []TableRow) {
 	t.err = nil
 	t.hasMore = len(rows) >= t.limit
 	t.pageNum = (t.offset / t.limit) + 1
-	if t.cursor >= len(rows) && len(rows) > 0 {
-		t.cursor = len(rows) - 1
+	t.addFieldValues(t.rows)
+	t.applyView()
+}
+
+// applyView rebuilds the filtered and sorted rows and keeps the cursor on them.
+func (t *TableWidget) applyView() {
+	needle := strings.ToLower(t.filter)
+	t.view = make([]TableRow, 0, len(t.rows))
+	for _, r := range t.rows {
+		if needle == "" || t.matches(r, needle) {
+			t.view = append(t.view, r)
+		}
+	}
+	if t.sortField != "" {
+		field, desc := t.sortField, t.sortDesc
+		sort.SliceStable(t.view, func(i, j int) bool {
+			c := compareValues(t.view[i].Values[field], t.view[j].Values[field])
+			if desc {
+				return c > 0
+			}
+			return c < 0
+		})
 	}
-	if len(rows) == 0 {
+	if t.cursor >= len(t.view) && len(t.view) > 0 {
+		t.cursor = len(t.view) - 1
+	}
+	if len(t.view) == 0 {
 		t.cursor = 0
 	}
 }
 
+// matches reports whether any visible column value of r contains needle.
+func (t *TableWidget) matches(r TableRow, needle string) bool {
+	for _, col := range t.visibleColumns() {
+		if strings.Contains(strings.ToLower(r.Values[col.Field]), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareValues orders numbers numerically and everything else case-insensitively.
+func compareValues(a, b string) int {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
 func (t *TableWidget) SetLoading(l bool) { t.loading = l }
 func (t *TableWidget) SetError(e error)  { t.err = e; t.loading = false }
 func (t *TableWidget) Cursor() int       { return t.cursor }
 func (t *TableWidget) Offset() int       { return t.offset }
 func (t *TableWidget) Limit() int        { return t.limit }
+func (t *TableWidget) Filter() string    { return t.filter }
+
+// SetHasMore overrides whether the next page arrow is offered, for callers
+// that load more rows than the table shows at once.
+func (t *TableWidget) SetHasMore(more bool) { t.hasMore = more }
+
+// SetOffset moves to the page starting at offset; the caller re-fetches.
+func (t *TableWidget) SetOffset(offset int) {
+	if offset < 0 {
+		offset = 0
+	}
+	t.offset = offset
+	t.cursor = 0
+}
+
+// SetScope names a restriction of the rows the caller lists, e.g. "failed".
+func (t *TableWidget) SetScope(label string) { t.scope = label }
+
+// SetFilter shows only rows with a column containing s (case-insensitive).
+func (t *TableWidget) SetFilter(s string) {
+	t.filter = s
+	t.applyView()
+}
+
+// Sort returns the sort column field ("" = unsorted) and direction.
+func (t *TableWidget) Sort() (field string, desc bool) { return t.sortField, t.sortDesc }
+
+// SetSort orders rows by the column with the given field; unknown fields unsort.
+func (t *TableWidget) SetSort(field string, desc bool) {
+	t.sortField, t.sortDesc = "", false
+	for _, col := range t.visibleColumns() {
+		if col.Field == field {
+			t.sortField, t.sortDesc = field, desc
+		}
+	}
+	t.applyView()
+}
+
+// HiddenColumns returns the fields of the hidden columns in column order.
+func (t *TableWidget) HiddenColumns() []string {
+	var out []string
+	for _, col := range t.columns {
+		if t.hidden[col.Field] {
+			out = append(out, col.Field)
+		}
+	}
+	return out
+}
+
+// SetHiddenColumns hides the columns with the given fields. At least one
+// column always stays visible.
+func (t *TableWidget) SetHiddenColumns(fields []string) {
+	t.hidden = map[string]bool{}
+	for _, f := range fields {
+		t.hidden[f] = true
+	}
+	if len(t.visibleColumns()) == 0 {
+		t.hidden = map[string]bool{}
+	}
+}
+
+// visibleColumns returns the columns that are not hidden, followed by the
+// extra columns.
+func (t *TableWidget) visibleColumns() []TableColumn {
+	cols := make([]TableColumn, 0, len(t.columns)+len(t.extra))
+	for _, col := range t.columns {
+		if !t.hidden[col.Field] {
+			cols = append(cols, col)
+		}
+	}
+	for _, f := range t.extra {
+		cols = append(cols, extraColumn(f))
+	}
+	return cols
+}
+
+// Capturing reports whether the table is taking text input (filter) or
+// runs the column chooser, so keys must not trigger list actions.
+func (t *TableWidget) Capturing() bool { return t.editing || t.choosing }
 
 // SelectedRow returns the row at the cursor, or nil.
 func (t *TableWidget) SelectedRow() *TableRow {
-	if len(t.rows) == 0 || t.cursor < 0 || t.cursor >= len(t.rows) {
+	if len(t.view) == 0 || t.cursor < 0 || t.cursor >= len(t.view) {
 		return nil
 	}
-	return &t.rows[t.cursor]
+	return &t.view[t.cursor]
+}
+
+// SelectID moves the cursor to the row of the record with the given ID. It
+// reports whether the record is listed.
+func (t *TableWidget) SelectID(id int) bool {
+	for i, r := range t.view {
+		if r.ID == id {
+			t.cursor = i
+			t.scrollToCursor()
+			return true
+		}
+	}
+	return false
+}
+
+// handleFilterKey edits the filter while it is being typed.
+func (t *TableWidget) handleFilterKey(key string) {
+	switch key {
+	case "enter":
+		t.editing = false
+	case "esc":
+		t.editing = false
+		t.filter = ""
+	case "backspace":
+		if t.filter != "" {
+			_, size := utf8.DecodeLastRuneInString(t.filter)
+			t.filter = t.filter[:len(t.filter)-size]
+		}
+	default:
+		if utf8.RuneCountInString(key) == 1 {
+			t.filter += key
+		}
+	}
+	t.applyView()
+}
+
+// handleChooserKey moves through the column chooser, toggles columns and
+// changes their width.
+func (t *TableWidget) handleChooserKey(key string) {
+	switch key {
+	case "up", "k":
+		if t.chooserCursor > 0 {
+			t.chooserCursor--
+		}
+	case "down", "j":
+		if t.chooserCursor < len(t.chooserColumns())-1 {
+			t.chooserCursor++
+		}
+	case " ", "x":
+		t.toggleColumn(t.chooserCursor)
+	case "right", "l", "+":
+		t.resizeColumn(t.chooserCursor, 1)
+	case "left", "h", "-":
+		t.resizeColumn(t.chooserCursor, -1)
+	case "0":
+		delete(t.widths, t.chooserColumns()[t.chooserCursor].Field)
+	case "esc", "enter", "c":
+		t.choosing = false
+	}
+	if t.sortField != "" {
+		t.SetSort(t.sortField, t.sortDesc) // the sort column may be gone
+	}
+}
+
+// cycleSort sorts by the next visible column; after the last one the table
+// returns to the fetched order.
+func (t *TableWidget) cycleSort() {
+	cols := t.visibleColumns()
+	next := ""
+	if t.sortField == "" {
+		next = cols[0].Field
+	} else {
+		for i, col := range cols {
+			if col.Field == t.sortField && i+1 < len(cols) {
+				next = cols[i+1].Field
+			}
+		}
+	}
+	t.SetSort(next, false)
 }
 
 // HandleKey processes navigation keys. Returns action flags.
 func (t *TableWidget) HandleKey(key string) (refresh, nextPage, prevPage, openDetail, openEditor, openCreate bool) {
+	if t.editing {
+		t.handleFilterKey(key)
+		return false, false, false, false, false, false
+	}
+	if t.choosing {
+		t.handleChooserKey(key)
+		return false, false, false, false, false, false
+	}
 	switch key {
+	case "/":
+		t.editing = true
+	case "o":
+		t.cycleSort()
+	case "O":
+		if t.sortField != "" {
+			t.SetSort(t.sortField, !t.sortDesc)
+		}
+	case "c":
+		t.choosing = true
+		t.chooserCursor, t.chooserTop = 0, 0
 	case "up", "k":
 		if t.cursor > 0 {
 			t.cursor--
 		}
 	case "down", "j":
-		if t.cursor < len(t.rows)-1 {
+		if t.cursor < len(t.view)-1 {
 			t.cursor++
 		}
 	case "enter", " ":
-		if len(t.rows) > 0 {
+		if len(t.view) > 0 {
 			return false, false, false, true, false, false
 		}
 	case "e":
-		if len(t.rows) > 0 {
+		if len(t.view) > 0 {
 			return false, false, false, false, true, false
 		}
 	case "n":
@@ -127,69 +388,129 @@ func (t *TableWidget) HandleKey(key string) (refresh, nextPage, prevPage, openDe
 	return false, false, false, false, false, false
 }
 
+// Click handles a click at view coordinates. A click selects a row, a
+// double click returns "enter"; the pager arrows return "left" / "right"
+// so the caller handles them like the keys. Clicking a column header sorts
+// by it, clicking it again reverses the order.
+func (t *TableWidget) Click(x, y int, double bool) string {
+	id, ok := t.zones.Hit(x, y)
+	if !ok {
+		return ""
+	}
+	kind, arg, _ := strings.Cut(id, ":")
+	switch kind {
+	case "row":
+		i, _ := strconv.Atoi(arg)
+		t.cursor = i
+		if double {
+			return "enter"
+		}
+	case "col":
+		t.SetSort(arg, arg == t.sortField && !t.sortDesc)
+	case "choose":
+		i, _ := strconv.Atoi(arg)
+		t.chooserCursor = i
+		t.handleChooserKey(" ")
+	case "prev":
+		return "left"
+	case "next":
+		return "right"
+	}
+	return ""
+}
+
 // View renders the table filling available height.
 func (t *TableWidget) View() string {
 	var b strings.Builder
+	t.zones.Reset()
 
-	// Title
+	// Title, followed by the filter while one is set or being typed
 	if t.title != "" {
-		b.WriteString(TitleStyle().Render(t.title))
+		b.WriteString(TitleStyle().Render(i18n.T(t.title)))
+	}
+	if t.scope != "" {
+		b.WriteString(" " + ActiveMenuStyle().Render("["+i18n.T(t.scope)+"]"))
+	}
+	if t.filter != "" || t.editing {
+		cursor := ""
+		if t.editing {
+			cursor = "█"
+		}
+		b.WriteString("  " + DescriptionStyle().Render(i18n.Tf("Filter: %s", t.filter+cursor)))
+	}
+	if t.title != "" || t.scope != "" || t.filter != "" || t.editing {
 		b.WriteString("\n")
 	}
 
 	if t.loading {
-		b.WriteString(DescriptionStyle().Render("  Loading..."))
+		b.WriteString(DescriptionStyle().Render("  " + i18n.T("Loading...")))
 		b.WriteString("\n")
 		return b.String()
 	}
 	if t.err != nil {
-		b.WriteString(ErrorStyle().Render(fmt.Sprintf("  Error: %v", t.err)))
+		b.WriteString(ErrorStyle().Render("  " + i18n.Tf("Error: %v", t.err)))
 		b.WriteString("\n")
 		return b.String()
 	}
+	if t.choosing {
+		t.writeChooser(&b)
+		return b.String()
+	}
+
+	columns := t.visibleColumns()
+	widths := fitColumns(columns, t.widths, t.width)
+	t.laidOut = make(map[string]int, len(columns))
 
 	// Compute total column width for separators
 	totalWidth := 1 // leading indicator char
-	for _, col := range t.columns {
-		totalWidth += col.Width + 1
+	for i, col := range columns {
+		t.laidOut[col.Field] = widths[i]
+		totalWidth += widths[i] + 1
 	}
 	sep := strings.Repeat("─", totalWidth)
 
-	// Column headers
-	parts := make([]string, len(t.columns))
-	for i, col := range t.columns {
-		parts[i] = fmt.Sprintf("%-*s", col.Width, col.Header)
+	// Column headers; the sort column carries an arrow
+	_, headerY := Cursor(&b)
+	x := 1
+	parts := make([]string, len(columns))
+	for i, col := range columns {
+		t.zones.Add("col:"+col.Field, x, headerY, widths[i])
+		x += widths[i] + 1
+		header := i18n.T(col.Header)
+		if col.Field == t.sortField {
+			arrow := " ▲"
+			if t.sortDesc {
+				arrow = " ▼"
+			}
+			header += arrow
+		}
+		parts[i] = Fit(header, widths[i])
 	}
 	b.WriteString(" " + strings.Join(parts, " ") + "\n")
 	b.WriteString(sep + "\n")
 
 	// Data rows
-	if len(t.rows) == 0 {
-		b.WriteString(DescriptionStyle().Render("  (no items)") + "\n")
+	if len(t.view) == 0 {
+		b.WriteString(DescriptionStyle().Render("  "+i18n.T("(no items)")) + "\n")
 	} else {
-		count := len(t.rows)
-		if count > t.limit {
-			count = t.limit
-		}
-		for i := 0; i < count; i++ {
-			row := t.rows[i]
-			rowParts := make([]string, len(t.columns))
-			for j, col := range t.columns {
-				val := row.Values[col.Field]
-				if len(val) > col.Width {
-					if col.Width > 3 {
-						val = val[:col.Width-3] + "..."
-					} else {
-						val = val[:col.Width]
-					}
-				}
-				rowParts[j] = fmt.Sprintf("%-*s", col.Width, val)
+		t.scrollToCursor()
+		end := t.top + t.limit
+		if end > len(t.view) {
+			end = len(t.view)
+		}
+		for i := t.top; i < end; i++ {
+			row := t.view[i]
+			rowParts := make([]string, len(columns))
+			for j, col := range columns {
+				rowParts[j] = Fit(row.Values[col.Field], widths[j])
 			}
 			indicator := " "
 			if i == t.cursor {
 				indicator = "►"
 			}
 			line := indicator + strings.Join(rowParts, " ")
+			_, y := Cursor(&b)
+			t.zones.Add(fmt.Sprintf("row:%d", i), 0, y, totalWidth)
 			if i == t.cursor {
 				b.WriteString(SelectedStyle().Render(line))
 			} else {
@@ -210,11 +531,74 @@ func (t *TableWidget) View() string {
 		nextStr = SelectedStyle().Render("[→]")
 	}
 	hint := ""
-	if t.helpText != "" {
-		hint = "  " + DescriptionStyle().Render(t.helpText)
+	switch {
+	case t.editing:
+		hint = "  " + DescriptionStyle().Render(i18n.T("type to filter • enter: keep • esc: clear"))
+	case t.helpText != "":
+		hint = "  " + DescriptionStyle().Render(i18n.T(t.helpText))
 	}
-	b.WriteString(fmt.Sprintf(" %s pg%d  %d items  %s%s\n",
-		prevStr, t.pageNum, len(t.rows), nextStr, hint))
+	count := i18n.N("items", len(t.rows))
+	if len(t.view) != len(t.rows) {
+		count = i18n.Tf("%d of %s", len(t.view), count)
+	}
+	pager := fmt.Sprintf(" %s %s  %s  ", prevStr, i18n.Tf("pg%d", t.pageNum), count)
+	_, pagerY := Cursor(&b)
+	t.zones.Add("prev", 1, pagerY, Width(prevStr))
+	t.zones.Add("next", Width(pager), pagerY, Width(nextStr))
+	b.WriteString(pager + nextStr + hint + "\n")
 
 	return b.String()
 }
+
+// scrollToCursor moves the rows on screen so they include the cursor,
+// for tables given more rows than their limit.
+func (t *TableWidget) scrollToCursor() {
+	if t.cursor < t.top {
+		t.top = t.cursor
+	}
+	if t.cursor >= t.top+t.limit {
+		t.top = t.cursor - t.limit + 1
+	}
+	if last := len(t.view) - t.limit; t.top > last {
+		t.top = last
+	}
+	if t.top < 0 {
+		t.top = 0
+	}
+}
+
+// writeChooser renders the column chooser in place of the rows: the
+// entity's columns, then the FullData fields that can be added. Shown
+// columns carry their width, marked with * when the user fixed it. Long
+// lists scroll with the cursor within the height of the table.
+func (t *TableWidget) writeChooser(b *strings.Builder) {
+	cols := t.chooserColumns()
+	rows := t.limit + tableOverhead - 2 // less title and help line
+	if t.chooserCursor < t.chooserTop {
+		t.chooserTop = t.chooserCursor
+	}
+	if t.chooserCursor >= t.chooserTop+rows {
+		t.chooserTop = t.chooserCursor - rows + 1
+	}
+	end := t.chooserTop + rows
+	if end > len(cols) {
+		end = len(cols)
+	}
+	for i := t.chooserTop; i < end; i++ {
+		col := cols[i]
+		mark, width := "[ ]", ""
+		if t.shown(col, i) {
+			mark, width = "[x]", t.columnWidthLabel(col.Field)
+		}
+		line := fmt.Sprintf("  %s %s %4s", mark, Fit(i18n.T(col.Header), chooserNameWidth), width)
+		_, y := Cursor(b)
+		t.zones.Add(fmt.Sprintf("choose:%d", i), 0, y, Width(line))
+		if i == t.chooserCursor {
+			b.WriteString(SelectedStyle().Render("►" + line[1:]))
+		} else {
+			b.WriteString(UnselectedStyle().Render(line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(DescriptionStyle().Render("  "+i18n.T("↑/↓: column • space: show/hide • ←/→: width • 0: auto width • esc: close")) + "\n")
+}
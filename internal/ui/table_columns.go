@@ -0,0 +1,327 @@
+package ui
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/audit"
+)
+
+// Extra columns show FullData fields that the entity does not list itself.
+const (
+	extraColumnWidth    = 12
+	extraColumnMaxWidth = 40
+	minColumnWidth      = 3 // narrowest a user can make a column
+)
+
+// minWidth returns the width the column may shrink to.
+func (c TableColumn) minWidth() int {
+	if c.MinWidth > 0 {
+		return c.MinWidth
+	}
+	if c.Width < 6 {
+		return c.Width
+	}
+	return 6
+}
+
+// clamp limits w to the column's min and max width.
+func (c TableColumn) clamp(w int) int {
+	if c.MaxWidth > 0 && w > c.MaxWidth {
+		w = c.MaxWidth
+	}
+	if min := c.minWidth(); w < min {
+		w = min
+	}
+	return w
+}
+
+// fitColumns returns the width of each column so a row fills avail display
+// columns: the cursor indicator plus the columns separated by spaces.
+// Columns with a width in fixed keep it. The rest shrink towards their
+// minimum in proportion to the room they have left, or grow by Flex weight
+// up to their maximum. A non-positive avail keeps the preferred widths; a
+// table that cannot shrink enough is left wider than avail.
+func fitColumns(cols []TableColumn, fixed map[string]int, avail int) []int {
+	widths := make([]int, len(cols))
+	used := 0
+	for i, col := range cols {
+		if w, ok := fixed[col.Field]; ok {
+			widths[i] = w
+		} else {
+			widths[i] = col.clamp(col.Width)
+		}
+		used += widths[i]
+	}
+	if avail <= 0 {
+		return widths
+	}
+	diff := avail - len(cols) - used
+
+	// Each round hands out (or takes back) what is left by weight; a share
+	// of at least one column per round guarantees progress.
+	for diff != 0 {
+		weights := make([]int, len(cols))
+		total := 0
+		for i, col := range cols {
+			if _, ok := fixed[col.Field]; ok {
+				continue
+			}
+			switch {
+			case diff > 0 && col.Flex > 0 && (col.MaxWidth == 0 || widths[i] < col.MaxWidth):
+				weights[i] = col.Flex
+			case diff < 0 && widths[i] > col.minWidth():
+				weights[i] = widths[i] - col.minWidth()
+			}
+			total += weights[i]
+		}
+		if total == 0 {
+			break
+		}
+		left := diff
+		if left < 0 {
+			left = -left
+		}
+		moved := 0
+		for i, col := range cols {
+			if weights[i] == 0 || moved == left {
+				continue
+			}
+			share := left * weights[i] / total
+			if share == 0 {
+				share = 1
+			}
+			if share > left-moved {
+				share = left - moved
+			}
+			if diff > 0 {
+				share = col.clamp(widths[i]+share) - widths[i]
+				widths[i] += share
+			} else {
+				share = widths[i] - col.clamp(widths[i]-share)
+				widths[i] -= share
+			}
+			moved += share
+		}
+		if moved == 0 {
+			break
+		}
+		if diff > 0 {
+			diff -= moved
+		} else {
+			diff += moved
+		}
+	}
+	return widths
+}
+
+// fieldValues flattens the top-level JSON fields of v into display strings.
+// Nested objects and arrays are shown as compact JSON. Secrets such as
+// passwords and tokens are left out, so no column can show them.
+func fieldValues(v interface{}) map[string]string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) != nil {
+		return nil
+	}
+	out := make(map[string]string, len(fields))
+	for k, raw := range fields {
+		var s string
+		switch {
+		case audit.IsSecret(k), string(raw) == "null":
+		case json.Unmarshal(raw, &s) == nil:
+			out[k] = s
+		default:
+			out[k] = string(raw)
+		}
+	}
+	return out
+}
+
+// addFieldValues completes each row's values with the FullData fields the
+// columns do not compute themselves, so extra columns can show them, and
+// records which fields are available.
+func (t *TableWidget) addFieldValues(rows []TableRow) {
+	defined := map[string]bool{}
+	for _, col := range t.columns {
+		defined[col.Field] = true
+	}
+	seen := map[string]bool{}
+	for _, f := range t.fields {
+		seen[f] = true
+	}
+	for i, r := range rows {
+		extra := fieldValues(r.FullData)
+		if len(extra) == 0 {
+			continue
+		}
+		values := make(map[string]string, len(r.Values)+len(extra))
+		for k, v := range extra {
+			values[k] = v
+			if !defined[k] && !seen[k] {
+				seen[k] = true
+				t.fields = append(t.fields, k)
+			}
+		}
+		for k, v := range r.Values {
+			values[k] = v
+		}
+		rows[i].Values = values
+	}
+	sort.Strings(t.fields)
+}
+
+// extraColumn returns the column showing a FullData field.
+func extraColumn(field string) TableColumn {
+	return TableColumn{Header: field, Width: extraColumnWidth, Field: field, MaxWidth: extraColumnMaxWidth, Flex: 1}
+}
+
+// chooserColumns returns the entries of the column chooser: the entity's
+// columns followed by every FullData field that can be added as a column.
+func (t *TableWidget) chooserColumns() []TableColumn {
+	cols := append([]TableColumn{}, t.columns...)
+	listed := map[string]bool{}
+	for _, col := range t.columns {
+		listed[col.Field] = true
+	}
+	fields := append(append([]string{}, t.fields...), t.extra...)
+	sort.Strings(fields)
+	for _, f := range fields {
+		if !listed[f] {
+			listed[f] = true
+			cols = append(cols, extraColumn(f))
+		}
+	}
+	return cols
+}
+
+// isExtra reports whether field is shown as an extra column.
+func (t *TableWidget) isExtra(field string) bool {
+	for _, f := range t.extra {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// shown reports whether the chooser entry col is currently displayed.
+func (t *TableWidget) shown(col TableColumn, i int) bool {
+	if i < len(t.columns) {
+		return !t.hidden[col.Field]
+	}
+	return t.isExtra(col.Field)
+}
+
+// toggleColumn shows or hides chooser entry i. At least one column always
+// stays visible.
+func (t *TableWidget) toggleColumn(i int) {
+	cols := t.chooserColumns()
+	if i < 0 || i >= len(cols) {
+		return
+	}
+	f := cols[i].Field
+	if i < len(t.columns) {
+		t.hidden[f] = !t.hidden[f]
+		if len(t.visibleColumns()) == 0 {
+			t.hidden[f] = false
+		}
+		return
+	}
+	if !t.isExtra(f) {
+		t.extra = append(t.extra, f)
+		return
+	}
+	var kept []string
+	for _, e := range t.extra {
+		if e != f {
+			kept = append(kept, e)
+		}
+	}
+	if len(t.visibleColumns()) > 1 {
+		t.extra = kept
+	}
+}
+
+// resizeColumn widens (delta > 0) or narrows chooser entry i, fixing its
+// width so auto-fit leaves it alone.
+func (t *TableWidget) resizeColumn(i, delta int) {
+	cols := t.chooserColumns()
+	if i < 0 || i >= len(cols) {
+		return
+	}
+	f := cols[i].Field
+	w, ok := t.widths[f]
+	if !ok {
+		w, ok = t.laidOut[f]
+	}
+	if !ok {
+		w = cols[i].Width
+	}
+	w += delta
+	if w < minColumnWidth {
+		w = minColumnWidth
+	}
+	t.widths[f] = w
+}
+
+// SetWidth sets the display width the columns are fitted to; 0 keeps the
+// preferred widths.
+func (t *TableWidget) SetWidth(w int) { t.width = w }
+
+// ColumnWidths returns the widths the user fixed, keyed by field.
+func (t *TableWidget) ColumnWidths() map[string]int {
+	if len(t.widths) == 0 {
+		return nil
+	}
+	out := make(map[string]int, len(t.widths))
+	for f, w := range t.widths {
+		out[f] = w
+	}
+	return out
+}
+
+// SetColumnWidths fixes the widths of the given columns.
+func (t *TableWidget) SetColumnWidths(widths map[string]int) {
+	t.widths = map[string]int{}
+	for f, w := range widths {
+		if w >= minColumnWidth {
+			t.widths[f] = w
+		}
+	}
+}
+
+// ExtraColumns returns the FullData fields shown as extra columns.
+func (t *TableWidget) ExtraColumns() []string {
+	return append([]string(nil), t.extra...)
+}
+
+// SetExtraColumns shows the given FullData fields as extra columns after
+// the entity's own. Fields the entity already lists are ignored.
+func (t *TableWidget) SetExtraColumns(fields []string) {
+	t.extra = nil
+	for _, f := range fields {
+		defined := false
+		for _, col := range t.columns {
+			defined = defined || col.Field == f
+		}
+		if !defined && !t.isExtra(f) {
+			t.extra = append(t.extra, f)
+		}
+	}
+}
+
+// columnWidthLabel describes the width of a shown column in the chooser.
+func (t *TableWidget) columnWidthLabel(field string) string {
+	if w, ok := t.widths[field]; ok {
+		return strconv.Itoa(w) + "*"
+	}
+	if w, ok := t.laidOut[field]; ok {
+		return strconv.Itoa(w)
+	}
+	return ""
+}
@@ -1,6 +1,12 @@
 package ui
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/cli"
+	"github.com/charmbracelet/lipgloss"
+)
 
 func TestTableWidgetNavigation(t *testing.T) {
 	tw := NewTableWidget("Test", []TableColumn{
@@ -35,6 +41,17 @@ func TestTableWidgetNavigation(t *testing.T) {
 	}
 }
 
+func TestTableWidgetSelectID(t *testing.T) {
+	tw := NewTableWidget("Test", []TableColumn{{Header: "ID", Width: 5, Field: "id"}}, 10, "")
+	tw.SetData([]TableRow{{ID: 7}, {ID: 5}, {ID: 3}})
+	if !tw.SelectID(3) || tw.Cursor() != 2 {
+		t.Errorf("cursor = %d, want 2", tw.Cursor())
+	}
+	if tw.SelectID(4) || tw.Cursor() != 2 {
+		t.Error("an unlisted ID should leave the cursor alone")
+	}
+}
+
 func TestTableWidgetOpenDetail(t *testing.T) {
 	tw := NewTableWidget("Test", []TableColumn{{Header: "ID", Width: 5, Field: "id"}}, 10, "")
 	tw.SetData([]TableRow{{ID: 1, Values: map[string]string{"id": "1"}}})
@@ -52,3 +69,275 @@ func TestTableWidgetView(t *testing.T) {
 		t.Error("table view should not be empty")
 	}
 }
+
+func TestTableWidgetFilterAndSort(t *testing.T) {
+	tw := NewTableWidget("Test", []TableColumn{
+		{Header: "ID", Width: 5, Field: "id"},
+		{Header: "Name", Width: 10, Field: "name"},
+	}, 10, "")
+	tw.SetData([]TableRow{
+		{ID: 1, Values: map[string]string{"id": "1", "name": "beta"}},
+		{ID: 2, Values: map[string]string{"id": "2", "name": "Alpha"}},
+		{ID: 10, Values: map[string]string{"id": "10", "name": "alphabet"}},
+	})
+
+	tw.HandleKey("/")
+	if !tw.Capturing() {
+		t.Fatal("/ should start typing a filter")
+	}
+	for _, k := range []string{"a", "l", "p"} {
+		tw.HandleKey(k)
+	}
+	tw.HandleKey("enter")
+	if tw.Capturing() || tw.Filter() != "alp" {
+		t.Fatalf("filter = %q, capturing = %v", tw.Filter(), tw.Capturing())
+	}
+	if tw.SelectedRow().ID != 2 {
+		t.Errorf("first filtered row = %d, want 2", tw.SelectedRow().ID)
+	}
+
+	tw.SetSort("id", true)
+	if tw.SelectedRow().ID != 10 {
+		t.Errorf("numeric descending sort should put 10 first, got %d", tw.SelectedRow().ID)
+	}
+
+	tw.HandleKey("/")
+	tw.HandleKey("esc")
+	if tw.Filter() != "" || tw.SelectedRow().ID != 10 {
+		t.Error("esc should clear the filter and keep the sort")
+	}
+}
+
+func TestTableWidgetColumnChooser(t *testing.T) {
+	tw := NewTableWidget("Test", []TableColumn{
+		{Header: "ID", Width: 5, Field: "id"},
+		{Header: "Name", Width: 10, Field: "name"},
+	}, 10, "")
+	tw.SetData([]TableRow{{ID: 1, Values: map[string]string{"id": "1", "name": "x"}}})
+
+	tw.HandleKey("c")
+	tw.HandleKey(" ") // hide ID
+	tw.HandleKey("down")
+	tw.HandleKey(" ") // the last visible column cannot be hidden
+	tw.HandleKey("esc")
+
+	if got := tw.HiddenColumns(); len(got) != 1 || got[0] != "id" {
+		t.Errorf("hidden = %v, want [id]", got)
+	}
+}
+
+func TestFitColumns(t *testing.T) {
+	cols := []TableColumn{
+		{Header: "ID", Width: 5, Field: "id"},
+		{Header: "Name", Width: 10, Field: "name", Flex: 2},
+		{Header: "Note", Width: 10, Field: "note", Flex: 1, MaxWidth: 14},
+	}
+	sum := func(w []int) int {
+		n := len(w) // indicator and separators
+		for _, x := range w {
+			n += x
+		}
+		return n
+	}
+
+	if got := fitColumns(cols, nil, 0); got[0] != 5 || got[1] != 10 || got[2] != 10 {
+		t.Errorf("unknown width should keep preferred widths, got %v", got)
+	}
+
+	wide := fitColumns(cols, nil, 60)
+	if sum(wide) != 60 || wide[0] != 5 || wide[2] != 14 {
+		t.Errorf("wide: %v; spare width should go to flex columns up to their max", wide)
+	}
+
+	narrow := fitColumns(cols, nil, 20)
+	if sum(narrow) != 20 {
+		t.Errorf("narrow: %v should fill 20 columns", narrow)
+	}
+	for i, w := range narrow {
+		if w < cols[i].minWidth() {
+			t.Errorf("narrow: column %d shrank to %d, below its minimum", i, w)
+		}
+	}
+
+	tiny := fitColumns(cols, nil, 5)
+	if tiny[0] != 5 || tiny[1] != 6 || tiny[2] != 6 {
+		t.Errorf("tiny: %v; columns should stop at their minimum", tiny)
+	}
+
+	fixed := fitColumns(cols, map[string]int{"name": 8}, 60)
+	if fixed[1] != 8 || fixed[2] != 14 {
+		t.Errorf("fixed: %v; a user width must not be changed by auto-fit", fixed)
+	}
+}
+
+func TestTableWidgetFillsWidth(t *testing.T) {
+	tw := NewTableWidget("", []TableColumn{
+		{Header: "ID", Width: 5, Field: "id"},
+		{Header: "Name", Width: 10, Field: "name", Flex: 1},
+	}, 10, "")
+	tw.SetWidth(50)
+	tw.SetData([]TableRow{{ID: 1, Values: map[string]string{"id": "1", "name": "x"}}})
+	for _, l := range strings.Split(tw.View(), "\n") {
+		if strings.HasPrefix(l, "─") && Width(l) != 51 {
+			t.Errorf("separator is %d columns, want the row width 50 + 1", Width(l))
+		}
+	}
+}
+
+type extraRecord struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Owner  string `json:"owner"`
+	Active bool   `json:"active"`
+}
+
+func TestTableWidgetExtraColumns(t *testing.T) {
+	tw := NewTableWidget("", []TableColumn{
+		{Header: "ID", Width: 5, Field: "id"},
+		{Header: "Name", Width: 10, Field: "name"},
+	}, 10, "")
+	tw.SetData([]TableRow{
+		{ID: 1, Values: map[string]string{"id": "1", "name": "Alpha"}, FullData: extraRecord{1, "Alpha", "root", true}},
+		{ID: 2, Values: map[string]string{"id": "2", "name": "Beta"}, FullData: extraRecord{2, "Beta", "vitex", false}},
+	})
+
+	tw.HandleKey("c")
+	view := tw.View()
+	if !strings.Contains(view, "active") || !strings.Contains(view, "owner") {
+		t.Fatalf("chooser should offer the FullData fields:\n%s", view)
+	}
+	if strings.Count(view, "name") != 0 {
+		t.Error("fields the entity lists already must not be offered again")
+	}
+	for tw.chooserColumns()[tw.chooserCursor].Field != "owner" {
+		tw.HandleKey("down")
+	}
+	tw.HandleKey(" ")
+	tw.HandleKey("esc")
+
+	if got := tw.ExtraColumns(); len(got) != 1 || got[0] != "owner" {
+		t.Fatalf("extra = %v, want [owner]", got)
+	}
+	if view := tw.View(); !strings.Contains(view, "vitex") {
+		t.Errorf("extra column values should be rendered:\n%s", view)
+	}
+	tw.SetSort("owner", true)
+	if row := tw.SelectedRow(); row.ID != 2 {
+		t.Error("extra columns should be sortable")
+	}
+}
+
+func TestTableWidgetHidesSecretFields(t *testing.T) {
+	tw := NewTableWidget("", []TableColumn{{Header: "Login", Width: 10, Field: "login"}}, 10, "")
+	user := cli.User{ID: 3, Login: "jane", Password: "hunter2"}
+	tw.SetData([]TableRow{{ID: 3, Values: map[string]string{"login": "jane"}, FullData: user}})
+
+	for _, c := range tw.chooserColumns() {
+		if c.Field == "password" {
+			t.Fatal("the column chooser must not offer the password")
+		}
+	}
+	if v, ok := tw.SelectedRow().Values["password"]; ok {
+		t.Errorf("row values hold the password %q", v)
+	}
+}
+
+func TestTableWidgetResizeColumn(t *testing.T) {
+	tw := NewTableWidget("", []TableColumn{
+		{Header: "ID", Width: 5, Field: "id"},
+		{Header: "Name", Width: 10, Field: "name", Flex: 1},
+	}, 10, "")
+	tw.SetWidth(40)
+	tw.SetData([]TableRow{{ID: 1, Values: map[string]string{"id": "1", "name": "x"}}})
+	tw.View()
+
+	tw.HandleKey("c")
+	tw.HandleKey("down")
+	tw.HandleKey("left")
+	tw.HandleKey("left")
+	if got := tw.ColumnWidths()["name"]; got != 31 {
+		t.Errorf("narrowing twice should fix Name at its laid-out 33 - 2, got %d", got)
+	}
+	tw.HandleKey("0")
+	if len(tw.ColumnWidths()) != 0 {
+		t.Error("0 should return the column to auto width")
+	}
+	for i := 0; i < 40; i++ {
+		tw.HandleKey("-")
+	}
+	if got := tw.ColumnWidths()["name"]; got != minColumnWidth {
+		t.Errorf("width = %d, should stop at %d", got, minColumnWidth)
+	}
+}
+
+// lineOf returns the index of the first line of view containing s.
+func lineOf(view, s string) int {
+	for i, l := range strings.Split(view, "\n") {
+		if strings.Contains(l, s) {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestTableWidgetClick(t *testing.T) {
+	tw := NewTableWidget("Test", []TableColumn{
+		{Header: "ID", Width: 5, Field: "id"},
+		{Header: "Name", Width: 10, Field: "name"},
+	}, 2, "")
+	tw.SetData([]TableRow{
+		{ID: 1, Values: map[string]string{"id": "1", "name": "one"}},
+		{ID: 2, Values: map[string]string{"id": "2", "name": "two"}},
+		{ID: 3, Values: map[string]string{"id": "3", "name": "three"}},
+	})
+	view := tw.View()
+
+	y := lineOf(view, "two")
+	if key := tw.Click(3, y, false); key != "" || tw.SelectedRow().ID != 2 {
+		t.Errorf("single click should select row 2 only (key %q)", key)
+	}
+	if key := tw.Click(3, y, true); key != "enter" {
+		t.Errorf("double click should open, got %q", key)
+	}
+
+	header := lineOf(view, "Name")
+	tw.Click(8, header, false)
+	if f, desc := tw.Sort(); f != "name" || desc {
+		t.Errorf("header click sorts ascending, got %s desc=%v", f, desc)
+	}
+	tw.View()
+	tw.Click(8, header, false)
+	if _, desc := tw.Sort(); !desc {
+		t.Error("second header click should reverse the order")
+	}
+
+	pager := lineOf(tw.View(), "pg1")
+	if key := tw.Click(2, pager, false); key != "left" {
+		t.Errorf("click on [←] = %q", key)
+	}
+	line := strings.Split(tw.View(), "\n")[pager]
+	x := strings.Index(line, "[→]")
+	if key := tw.Click(lipgloss.Width(line[:x]), pager, false); key != "right" {
+		t.Errorf("click on [→] = %q", key)
+	}
+}
+
+func TestTableWidgetScrollsToCursor(t *testing.T) {
+	tw := NewTableWidget("", []TableColumn{{Header: "Name", Width: 10, Field: "name"}}, 3, "")
+	var rows []TableRow
+	for _, name := range []string{"alpha", "bravo", "charlie", "delta", "echo"} {
+		rows = append(rows, TableRow{Values: map[string]string{"name": name}})
+	}
+	tw.SetData(rows)
+	for i := 0; i < 4; i++ {
+		tw.HandleKey("down")
+	}
+	view := tw.View()
+	if !strings.Contains(view, "echo") || strings.Contains(view, "bravo") {
+		t.Errorf("rows past the limit should scroll into view:\n%s", view)
+	}
+	tw.SetFilter("ha")
+	if view := tw.View(); !strings.Contains(view, "alpha") {
+		t.Errorf("a narrower filter should scroll back:\n%s", view)
+	}
+}
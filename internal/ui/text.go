@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Ellipsis marks text cut short by Truncate.
+const Ellipsis = "…"
+
+// Width returns the number of terminal columns s occupies. It counts
+// grapheme clusters rather than bytes or runes, so wide CJK characters and
+// emoji take two columns while combining marks and joiners take none. ANSI
+// escape sequences are ignored, so styled text can be measured too.
+func Width(s string) int {
+	return runewidth.StringWidth(stripANSI(s))
+}
+
+// Truncate shortens plain text s to at most w columns, ending it with an
+// ellipsis when anything was cut. It only cuts between grapheme clusters,
+// so an accent is never separated from its letter nor an emoji sequence
+// split. The result may be a column short of w when a wide character did
+// not fit; PadRight evens it out.
+func Truncate(s string, w int) string {
+	if w <= 0 {
+		return ""
+	}
+	if runewidth.StringWidth(s) <= w {
+		return s
+	}
+	if w <= runewidth.StringWidth(Ellipsis) {
+		return runewidth.Truncate(s, w, "")
+	}
+	return runewidth.Truncate(s, w, Ellipsis)
+}
+
+// PadRight pads s with spaces to w columns. Text already w columns wide or
+// wider is returned unchanged.
+func PadRight(s string, w int) string {
+	if n := w - Width(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// Fit truncates s to w columns and pads it to exactly w columns, the shape
+// of a table cell or an aligned label.
+func Fit(s string, w int) string {
+	return PadRight(Truncate(s, w), w)
+}
+
+// stripANSI removes CSI escape sequences (colours, attributes) from s.
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\x1b[") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '[' {
+			i += 2
+			for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+				i++
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWidth(t *testing.T) {
+	for s, want := range map[string]int{
+		"Jobs":       4,
+		"Úlohy":      5,
+		"Cafe\u0301": 4, // e + combining acute accent
+		"日本語":        6,
+		"💼 Jobs":     7,
+		"\U0001F468\u200D\U0001F469\u200D\U0001F467": 2, // family ZWJ sequence
+		"\x1b[1mbold\x1b[0m":                         4,
+	} {
+		if got := Width(s); got != want {
+			t.Errorf("Width(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestTruncateKeepsGraphemes(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		w    int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"Přehled úloh", 6, "Přehl…"},
+		{"Cafe\u0301 au lait", 5, "Cafe\u0301…"},
+		{"日本語テキスト", 7, "日本語…"},
+		{"日本語テキスト", 6, "日本…"}, // the third ideograph would overflow
+		{"💼💼💼", 4, "💼…"},
+		{"\U0001F468\u200D\U0001F469\u200D\U0001F467 family", 3, "\U0001F468\u200D\U0001F469\u200D\U0001F467…"},
+		{"abc", 1, "a"},
+		{"abc", 0, ""},
+	} {
+		got := Truncate(tc.in, tc.w)
+		if got != tc.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tc.in, tc.w, got, tc.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("Truncate(%q, %d) produced invalid UTF-8", tc.in, tc.w)
+		}
+		if Width(got) > tc.w {
+			t.Errorf("Truncate(%q, %d) is %d columns wide", tc.in, tc.w, Width(got))
+		}
+	}
+}
+
+func TestFitPadsToDisplayWidth(t *testing.T) {
+	for _, s := range []string{"id", "Čeština", "Cafe\u0301", "日本語", "💼 Jobs", "a very long value indeed"} {
+		if got := Width(Fit(s, 8)); got != 8 {
+			t.Errorf("Fit(%q, 8) is %d columns wide", s, got)
+		}
+	}
+}
+
+func TestTableAlignsWideAndCombiningText(t *testing.T) {
+	tw := NewTableWidget("Test", []TableColumn{
+		{Header: "Name", Width: 6, Field: "name"},
+		{Header: "ID", Width: 3, Field: "id"},
+	}, 10, "")
+	tw.SetData([]TableRow{
+		{ID: 1, Values: map[string]string{"name": "ascii", "id": "1"}},
+		{ID: 2, Values: map[string]string{"name": "日本語テキスト", "id": "2"}},
+		{ID: 3, Values: map[string]string{"name": "Cafe\u0301 cre\u0300me", "id": "3"}},
+		{ID: 4, Values: map[string]string{"name": "💼💼💼💼", "id": "4"}},
+	})
+	view := tw.View()
+
+	// The ID column must start at the same display column on every row.
+	want := -1
+	for _, id := range []string{"1", "2", "3", "4"} {
+		line := strings.Split(view, "\n")[lineOf(view, " "+id+"  ")]
+		col := Width(line[:strings.LastIndex(line, " "+id+"  ")])
+		if want < 0 {
+			want = col
+		} else if col != want {
+			t.Errorf("row %s: ID column at %d, want %d\n%s", id, col, want, view)
+		}
+	}
+	if !utf8.ValidString(view) {
+		t.Error("table view contains split runes")
+	}
+}
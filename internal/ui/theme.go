@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Theme is a named colour palette. Colours are ANSI indexes ("21") or
+// hex values ("#1F4E9C").
+type Theme struct {
+	Name    string `json:"name"`
+	Extends string `json:"extends,omitempty"` // user themes: base theme for unset colours
+
+	TitleFg       string `json:"title_fg,omitempty"`
+	TitleBg       string `json:"title_bg,omitempty"`
+	SelectedFg    string `json:"selected_fg,omitempty"`
+	SelectedBg    string `json:"selected_bg,omitempty"`
+	ActiveMenuFg  string `json:"active_menu_fg,omitempty"`
+	UnselectedFg  string `json:"unselected_fg,omitempty"`
+	DescriptionFg string `json:"description_fg,omitempty"`
+	FooterFg      string `json:"footer_fg,omitempty"`
+	ErrorFg       string `json:"error_fg,omitempty"`
+	ButtonFg      string `json:"button_fg,omitempty"`
+	ButtonBg      string `json:"button_bg,omitempty"`
+	ActiveFg      string `json:"active_fg,omitempty"`
+	DisabledFg    string `json:"disabled_fg,omitempty"`
+	DebugFg       string `json:"debug_fg,omitempty"`
+}
+
+// AutoTheme picks a built-in theme from the detected terminal background.
+const AutoTheme = "auto"
+
+// Built-in themes.
+var (
+	// TurboVisionTheme is the classic TurboVision-inspired colour scheme.
+	TurboVisionTheme = Theme{
+		Name:    "turbovision",
+		TitleFg: "15", TitleBg: "21",
+		SelectedFg: "0", SelectedBg: "14",
+		ActiveMenuFg:  "10",
+		UnselectedFg:  "7",
+		DescriptionFg: "8",
+		FooterFg:      "8",
+		ErrorFg:       "9",
+		ButtonFg:      "15", ButtonBg: "4",
+		ActiveFg:   "10",
+		DisabledFg: "9",
+		DebugFg:    "11", // bright yellow — visible but clearly secondary
+	}
+
+	DarkTheme = Theme{
+		Name:    "dark",
+		TitleFg: "#FAFAFA", TitleBg: "#5A56E0",
+		SelectedFg: "#1A1A1A", SelectedBg: "#7D9FF5",
+		ActiveMenuFg:  "#A6E22E",
+		UnselectedFg:  "#D0D0D0",
+		DescriptionFg: "#8A8A8A",
+		FooterFg:      "#767676",
+		ErrorFg:       "#FF5F5F",
+		ButtonFg:      "#FAFAFA", ButtonBg: "#3C3C8C",
+		ActiveFg:   "#5FD75F",
+		DisabledFg: "#FF5F5F",
+		DebugFg:    "#D7AF00",
+	}
+
+	LightTheme = Theme{
+		Name:    "light",
+		TitleFg: "#FFFFFF", TitleBg: "#1F4E9C",
+		SelectedFg: "#FFFFFF", SelectedBg: "#1F6FEB",
+		ActiveMenuFg:  "#0A7A0A",
+		UnselectedFg:  "#1C1C1C",
+		DescriptionFg: "#5F5F5F",
+		FooterFg:      "#5F5F5F",
+		ErrorFg:       "#B00020",
+		ButtonFg:      "#FFFFFF", ButtonBg: "#1F4E9C",
+		ActiveFg:   "#0A7A0A",
+		DisabledFg: "#B00020",
+		DebugFg:    "#8A5A00",
+	}
+
+	HighContrastTheme = Theme{
+		Name:    "high-contrast",
+		TitleFg: "0", TitleBg: "15",
+		SelectedFg: "0", SelectedBg: "11",
+		ActiveMenuFg:  "14",
+		UnselectedFg:  "15",
+		DescriptionFg: "15",
+		FooterFg:      "15",
+		ErrorFg:       "9",
+		ButtonFg:      "0", ButtonBg: "15",
+		ActiveFg:   "10",
+		DisabledFg: "9",
+		DebugFg:    "11",
+	}
+)
+
+var (
+	current  Theme
+	noColor  bool
+	registry = map[string]Theme{}
+)
+
+func init() {
+	for _, t := range []Theme{TurboVisionTheme, DarkTheme, LightTheme, HighContrastTheme} {
+		registry[t.Name] = t
+	}
+}
+
+// InitColor honours the NO_COLOR convention (https://no-color.org): when the
+// variable is set, all colours are dropped and highlights use reverse video.
+func InitColor() {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		noColor = true
+		lipgloss.SetColorProfile(termenv.Ascii)
+		applyTheme(current)
+	}
+}
+
+// CurrentTheme returns the theme in use.
+func CurrentTheme() Theme { return current }
+
+// ThemeNames lists all registered themes, built-in and user-defined, sorted.
+func ThemeNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetTheme switches to the named theme. AutoTheme selects TurboVision on
+// dark terminal backgrounds and the light theme otherwise.
+func SetTheme(name string) error {
+	if name == "" || name == AutoTheme {
+		name = TurboVisionTheme.Name
+		if !termenv.HasDarkBackground() {
+			name = LightTheme.Name
+		}
+	}
+	t, ok := registry[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	applyTheme(t)
+	return nil
+}
+
+// themeFile is the layout of the user themes file.
+type themeFile struct {
+	Themes []Theme `json:"themes"`
+}
+
+// LoadThemes registers the user themes defined in a JSON file:
+//
+//	{"themes": [{"name": "solarized", "extends": "dark", "title_bg": "#268BD2"}]}
+//
+// Colours a theme leaves unset are taken from its "extends" base (default
+// "dark"). A missing file is not an error.
+func LoadThemes(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var f themeFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	for _, t := range f.Themes {
+		if t.Name == "" {
+			return fmt.Errorf("%s: theme without a name", path)
+		}
+		baseName := t.Extends
+		if baseName == "" {
+			baseName = DarkTheme.Name
+		}
+		base, ok := registry[strings.ToLower(baseName)]
+		if !ok {
+			return fmt.Errorf("%s: theme %q extends unknown theme %q", path, t.Name, baseName)
+		}
+		registry[strings.ToLower(t.Name)] = mergeTheme(base, t)
+	}
+	return nil
+}
+
+// mergeTheme fills the unset colours of t from base. The name is the
+// registry key, so the current theme can be found among ThemeNames.
+func mergeTheme(base, t Theme) Theme {
+	pick := func(v, def string) string {
+		if v != "" {
+			return v
+		}
+		return def
+	}
+	return Theme{
+		Name:          strings.ToLower(t.Name),
+		TitleFg:       pick(t.TitleFg, base.TitleFg),
+		TitleBg:       pick(t.TitleBg, base.TitleBg),
+		SelectedFg:    pick(t.SelectedFg, base.SelectedFg),
+		SelectedBg:    pick(t.SelectedBg, base.SelectedBg),
+		ActiveMenuFg:  pick(t.ActiveMenuFg, base.ActiveMenuFg),
+		UnselectedFg:  pick(t.UnselectedFg, base.UnselectedFg),
+		DescriptionFg: pick(t.DescriptionFg, base.DescriptionFg),
+		FooterFg:      pick(t.FooterFg, base.FooterFg),
+		ErrorFg:       pick(t.ErrorFg, base.ErrorFg),
+		ButtonFg:      pick(t.ButtonFg, base.ButtonFg),
+		ButtonBg:      pick(t.ButtonBg, base.ButtonBg),
+		ActiveFg:      pick(t.ActiveFg, base.ActiveFg),
+		DisabledFg:    pick(t.DisabledFg, base.DisabledFg),
+		DebugFg:       pick(t.DebugFg, base.DebugFg),
+	}
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ThemePicker lists the available themes and previews the one under the
+// cursor. Enter keeps it, Esc restores the theme that was active before.
+type ThemePicker struct {
+	names    []string
+	cursor   int
+	original string
+}
+
+// NewThemePicker creates a picker positioned on the current theme.
+func NewThemePicker() *ThemePicker {
+	p := &ThemePicker{names: ThemeNames(), original: CurrentTheme().Name}
+	for i, n := range p.names {
+		if n == p.original {
+			p.cursor = i
+		}
+	}
+	return p
+}
+
+func (m *ThemePicker) Init() tea.Cmd { return nil }
+
+func (m *ThemePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok || len(m.names) == 0 {
+		return m, nil
+	}
+	switch key.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+			_ = SetTheme(m.names[m.cursor])
+		}
+	case "down", "j":
+		if m.cursor < len(m.names)-1 {
+			m.cursor++
+			_ = SetTheme(m.names[m.cursor])
+		}
+	case "enter", " ":
+		name := m.names[m.cursor]
+		_ = SetTheme(name)
+		m.original = name
+		return m, func() tea.Msg { return StatusMsg{Text: i18n.Tf("Theme: %s", name)} }
+	case "esc", "q":
+		_ = SetTheme(m.original)
+		return m, func() tea.Msg { return NavigateBackMsg{} }
+	}
+	return m, nil
+}
+
+func (m *ThemePicker) View() string {
+	var b strings.Builder
+	b.WriteString(TitleStyle().Render(" " + i18n.T("Theme") + " "))
+	b.WriteString("\n\n")
+	for i, name := range m.names {
+		line := "  " + name
+		if name == m.original {
+			line += " " + i18n.T("(in use)")
+		}
+		if i == m.cursor {
+			b.WriteString(SelectedStyle().Render("►" + line[1:]))
+		} else {
+			b.WriteString(UnselectedStyle().Render(line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(ButtonStyle().Render(i18n.T("Button")) + " ")
+	b.WriteString(ActiveStatusStyle().Render(i18n.T("active")) + " ")
+	b.WriteString(DisabledStatusStyle().Render(i18n.T("inactive")) + " ")
+	b.WriteString(ErrorStyle().Render(i18n.T("error")) + " ")
+	b.WriteString(DescriptionStyle().Render(i18n.T("description")))
+	b.WriteString("\n\n")
+	b.WriteString(FooterStyle().Render(i18n.T("↑/↓: preview • enter: apply • esc: cancel")))
+	b.WriteString("\n")
+	return b.String()
+}
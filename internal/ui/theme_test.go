@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetThemeBuiltins(t *testing.T) {
+	defer applyTheme(TurboVisionTheme)
+	for _, name := range []string{"turbovision", "dark", "light", "high-contrast"} {
+		if err := SetTheme(name); err != nil {
+			t.Errorf("SetTheme(%q): %v", name, err)
+		}
+		if CurrentTheme().Name != name {
+			t.Errorf("current theme = %q, want %q", CurrentTheme().Name, name)
+		}
+	}
+	if err := SetTheme("no-such-theme"); err == nil {
+		t.Error("expected error for unknown theme")
+	}
+}
+
+func TestLoadThemesExtendsBase(t *testing.T) {
+	defer applyTheme(TurboVisionTheme)
+	saved := make(map[string]Theme, len(registry))
+	for name, th := range registry {
+		saved[name] = th
+	}
+	t.Cleanup(func() { registry = saved })
+	path := filepath.Join(t.TempDir(), "themes.json")
+	data := `{"themes":[{"name":"Ocean","extends":"light","title_bg":"#005F87"}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadThemes(path); err != nil {
+		t.Fatalf("LoadThemes: %v", err)
+	}
+	if err := SetTheme("ocean"); err != nil {
+		t.Fatalf("SetTheme: %v", err)
+	}
+	th := CurrentTheme()
+	if th.TitleBg != "#005F87" {
+		t.Errorf("TitleBg = %q, want override", th.TitleBg)
+	}
+	if th.SelectedBg != LightTheme.SelectedBg {
+		t.Errorf("SelectedBg = %q, want inherited %q", th.SelectedBg, LightTheme.SelectedBg)
+	}
+
+	// The picker finds the theme in use despite the capitalised name.
+	p := NewThemePicker()
+	if p.names[p.cursor] != "ocean" || !strings.Contains(p.View(), "ocean (in use)") {
+		t.Errorf("picker cursor on %q, want ocean marked in use", p.names[p.cursor])
+	}
+}
+
+func TestLoadThemesMissingFile(t *testing.T) {
+	if err := LoadThemes(filepath.Join(t.TempDir(), "absent.json")); err != nil {
+		t.Errorf("missing themes file should be ignored, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validator checks a field value and returns an error whose text, an
+// untranslated message, is shown under the field. Validators only see
+// non-empty values; EditorField.Required rejects empty ones.
+type Validator func(value string) error
+
+// Validation messages. Forms translate them when shown.
+var (
+	errRequired = errors.New("Required")
+	errInt      = errors.New("Must be a whole number")
+	errEmail    = errors.New("Must be an email address")
+	errCron     = errors.New("Must be a cron expression: minute hour day month weekday")
+	errJSON     = errors.New("Must be valid JSON")
+	errDateTime = errors.New("Must be a date and time (YYYY-MM-DD HH:MM:SS) or now")
+	errDate     = errors.New("Must be a date (YYYY-MM-DD)")
+	errYAML     = errors.New("Must be valid YAML")
+)
+
+// IsInt accepts whole numbers. Integer fields check it themselves.
+func IsInt(v string) error {
+	if _, err := strconv.Atoi(strings.TrimSpace(v)); err != nil {
+		return errInt
+	}
+	return nil
+}
+
+// IsEmail accepts a bare email address.
+func IsEmail(v string) error {
+	a, err := mail.ParseAddress(v)
+	if err != nil || a.Address != strings.TrimSpace(v) || a.Name != "" {
+		return errEmail
+	}
+	return nil
+}
+
+// IsJSON accepts any JSON document.
+func IsJSON(v string) error {
+	if !json.Valid([]byte(v)) {
+		return errJSON
+	}
+	return nil
+}
+
+// IsYAML accepts text that is well-formed as YAML as far as a scan without
+// a parser can tell: indented with spaces, with its flow collections and
+// quoted scalars closed.
+func IsYAML(v string) error {
+	depth := 0     // open flow collections
+	var quote byte // open quoted scalar
+	for _, line := range strings.Split(v, "\n") {
+		if quote == 0 && depth == 0 {
+			body := strings.TrimLeft(line, " \t")
+			if body != "" && strings.Contains(line[:len(line)-len(body)], "\t") {
+				return errYAML
+			}
+		}
+		var last byte // last character outside quotes, 0 at the line start
+		for i := 0; i < len(line); i++ {
+			c := line[i]
+			switch {
+			case quote == '"' && c == '\\':
+				i++
+				continue
+			case quote != 0:
+				if c == quote && quote == '\'' && i+1 < len(line) && line[i+1] == '\'' {
+					i++ // '' is a quote within single quotes
+				} else if c == quote {
+					quote = 0
+				}
+				continue
+			case c == '#' && (i == 0 || line[i-1] == ' '):
+				i = len(line)
+				continue
+			case c == ' ' || c == '\t':
+				continue
+			}
+			scalarStart := last == 0 || strings.IndexByte(":-?,[{", last) >= 0
+			switch {
+			case scalarStart && (c == '"' || c == '\''):
+				quote = c
+			case (scalarStart || depth > 0) && (c == '[' || c == '{'):
+				depth++
+			case depth > 0 && (c == ']' || c == '}'):
+				depth--
+			}
+			last = c
+		}
+	}
+	if quote != 0 || depth != 0 {
+		return errYAML
+	}
+	return nil
+}
+
+// IsDateTime accepts DateTimeLayout and "now". Date-time fields check it
+// themselves.
+func IsDateTime(v string) error {
+	if v == "now" {
+		return nil
+	}
+	if _, err := time.Parse(DateTimeLayout, v); err != nil {
+		return errDateTime
+	}
+	return nil
+}
+
+// IsDate accepts a calendar date.
+func IsDate(v string) error {
+	if _, err := time.Parse("2006-01-02", strings.TrimSpace(v)); err != nil {
+		return errDate
+	}
+	return nil
+}
+
+// cronFields are the ranges and names of the five fields of a cron
+// expression.
+var cronFields = []struct {
+	min, max int
+	names    []string // names for min, min+1, …
+}{
+	{0, 59, nil},
+	{0, 23, nil},
+	{1, 31, nil},
+	{1, 12, []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{0, 7, []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// cronMacros are the shorthands cron accepts for a whole expression.
+var cronMacros = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// IsCron accepts five-field cron expressions, e.g. "*/5 * * * *" or
+// "0 8 * * mon-fri", and the @daily style shorthands.
+func IsCron(v string) error {
+	v = strings.TrimSpace(v)
+	if cronMacros[strings.ToLower(v)] {
+		return nil
+	}
+	fields := strings.Fields(v)
+	if len(fields) != len(cronFields) {
+		return errCron
+	}
+	for i, field := range fields {
+		for _, item := range strings.Split(field, ",") {
+			if !cronItem(strings.ToLower(item), i) {
+				return errCron
+			}
+		}
+	}
+	return nil
+}
+
+// cronItem checks one comma-separated item of cron field i: "*", a value
+// or a range, optionally with a "/step".
+func cronItem(item string, i int) bool {
+	f := cronFields[i]
+	if base, step, ok := strings.Cut(item, "/"); ok {
+		if n, err := strconv.Atoi(step); err != nil || n < 1 {
+			return false
+		}
+		item = base
+	}
+	if item == "*" {
+		return true
+	}
+	lo, hi, isRange := strings.Cut(item, "-")
+	a, ok := cronValue(lo, f.min, f.max, f.names)
+	if !ok {
+		return false
+	}
+	if !isRange {
+		return true
+	}
+	b, ok := cronValue(hi, f.min, f.max, f.names)
+	return ok && a <= b
+}
+
+// cronValue reads a number or name within min..max.
+func cronValue(s string, min, max int, names []string) (int, bool) {
+	for j, name := range names {
+		if s == name {
+			return min + j, true
+		}
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil && n >= min && n <= max
+}
+
+// Matches returns a validator accepting values that match the regular
+// expression pattern; message is shown otherwise.
+func Matches(pattern, message string) Validator {
+	re := regexp.MustCompile(pattern)
+	return func(v string) error {
+		if !re.MatchString(v) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
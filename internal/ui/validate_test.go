@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		check Validator
+		ok    []string
+		bad   []string
+	}{
+		{"int", IsInt, []string{"0", "-12", "42"}, []string{"4.2", "x", "1e3"}},
+		{"email", IsEmail, []string{"info@example.com"}, []string{"info", "a@", "Info <info@example.com>"}},
+		{"json", IsJSON, []string{`{"KEY":"value"}`, `[]`}, []string{`{KEY: value}`, `{"a":`}},
+		{"datetime", IsDateTime, []string{"now", "2026-10-18 08:00:00"}, []string{"2026-10-18", "tomorrow", "2026-13-01 00:00:00"}},
+		{"date", IsDate, []string{"2026-10-18"}, []string{"18.10.2026", "2026-02-30"}},
+		{"cron", IsCron, []string{"*/5 * * * *", "0 8 * * mon-fri", "0 0 1,15 jan-jun *", "@daily"},
+			[]string{"* * * *", "60 * * * *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "@often"}},
+		{"yaml", IsYAML, []string{"key: value\nlist:\n  - it's fine\n  - \"quoted # not a comment\"", "flow: {a: [1, 2],\n  b: 3}", "# comment: ["},
+			[]string{"key:\n\t- tabbed", "flow: [1, 2", "name: 'open"}},
+		{"matches", Matches(`^\d+\.\d+$`, "Must be a version"), []string{"1.0"}, []string{"1", "v1.0"}},
+	} {
+		for _, v := range tc.ok {
+			if err := tc.check(v); err != nil {
+				t.Errorf("%s(%q) = %v, want ok", tc.name, v, err)
+			}
+		}
+		for _, v := range tc.bad {
+			if err := tc.check(v); err == nil {
+				t.Errorf("%s(%q) passed, want an error", tc.name, v)
+			}
+		}
+	}
+}
+
+func TestFormValidateFocusesFirstInvalidField(t *testing.T) {
+	f := NewForm([]EditorField{
+		{Label: "Name", Value: "Acme", Required: true},
+		{Label: "Email", Validators: []Validator{IsEmail}},
+		{Label: "Port", Kind: IntField},
+		{Label: "Slug", Required: true},
+	})
+	pressKeys(f, "tab", "nobody")
+	if f.Err("Email") != nil {
+		t.Error("the field being typed in should not be checked yet")
+	}
+	pressKeys(f, "tab")
+	if f.Err("Email") == nil {
+		t.Fatal("leaving a field should check it")
+	}
+	if !strings.Contains(f.View(), "Must be an email address") {
+		t.Error("the error should be shown under the field")
+	}
+
+	if f.Validate() {
+		t.Fatal("a form with errors should not validate")
+	}
+	if f.Focused() != 1 || f.Err("Slug") == nil || f.Err("Port") != nil {
+		t.Errorf("focused = %d, slug error = %v, port error = %v", f.Focused(), f.Err("Slug"), f.Err("Port"))
+	}
+
+	pressKeys(f, "@example.com")
+	if f.Err("Email") != nil {
+		t.Error("the error should clear as soon as the value is fixed")
+	}
+	f.SetValue("Slug", "acme")
+	if !f.Validate() {
+		t.Error("the corrected form should validate")
+	}
+}
+
+func TestFormMarksRequiredFields(t *testing.T) {
+	f := NewForm([]EditorField{{Label: "Name", Required: true}, {Label: "Email"}})
+	view := f.View()
+	if !strings.Contains(view, "Name*:") || strings.Contains(view, "Email*") {
+		t.Errorf("only required fields should be marked:\n%s", view)
+	}
+}
+
+func TestFormChecksExtensionSyntax(t *testing.T) {
+	f := NewForm([]EditorField{{Label: "Env Mapping", Kind: TextAreaField, Ext: ".json"}})
+	if err := f.SetEdited("Env Mapping", "{KEY: value}"); err == nil {
+		t.Error("a .json field should be checked as JSON")
+	}
+	if err := f.SetEdited("Env Mapping", `{"KEY": "value"}`); err != nil || f.Values()["Env Mapping"] != `{"KEY": "value"}` {
+		t.Errorf("err = %v, value = %q", err, f.Values()["Env Mapping"])
+	}
+}
@@ -1,9 +1,9 @@
 package ui
 
 import (
-	"fmt"
 	"strings"
 
+	"github.com/VitexSoftware/multiflexi-tui/internal/i18n"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -100,16 +100,16 @@ func (m *Viewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *Viewer) View() string {
 	var b strings.Builder
-	b.WriteString(TitleStyle().Render(m.title))
+	b.WriteString(TitleStyle().Render(i18n.T(m.title)))
 	b.WriteString("\n")
 
 	if m.err != nil {
 		b.WriteString(ErrorStyle().Render(m.err.Error()) + "\n")
-		b.WriteString(FooterStyle().Render("esc: back") + "\n")
+		b.WriteString(FooterStyle().Render(i18n.T("esc: back")) + "\n")
 		return b.String()
 	}
 	if m.content == "" {
-		b.WriteString(DescriptionStyle().Render("Loading...") + "\n")
+		b.WriteString(DescriptionStyle().Render(i18n.T("Loading...")) + "\n")
 		return b.String()
 	}
 
@@ -139,9 +139,9 @@ func (m *Viewer) View() string {
 			pct = (m.scroll * 100) / (len(lines) - vis)
 		}
 		b.WriteString(FooterStyle().Render(
-			fmt.Sprintf(" ↑/↓/PgUp/PgDn: scroll  g/G: top/end  esc: back  [%3d%%]", pct)))
+			i18n.Tf(" ↑/↓/PgUp/PgDn: scroll  g/G: top/end  esc: back  [%3d%%]", pct)))
 	} else {
-		b.WriteString(FooterStyle().Render(" ↑/↓: scroll  esc: back"))
+		b.WriteString(FooterStyle().Render(i18n.T(" ↑/↓: scroll  esc: back")))
 	}
 	b.WriteString("\n")
 